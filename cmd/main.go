@@ -2,40 +2,65 @@ package main
 
 import (
 	"context"
-	"fmt"
 	"log/slog"
 	"net/http"
 	"os"
 	"os/signal"
+	"sync"
 	"syscall"
 	"time"
 
-	"time-tracker/internal/config"
-	tasksHandler "time-tracker/internal/controller/task"
-	usersHandler "time-tracker/internal/controller/user"
-	"time-tracker/internal/lib/logger"
-	"time-tracker/internal/lib/logger/sl"
-	"time-tracker/internal/repository/externalapi"
-	storage "time-tracker/internal/repository/postgres"
-	taskService "time-tracker/internal/service/task"
-	usersService "time-tracker/internal/service/user"
-
-	_ "time-tracker/docs"
-
-	"github.com/go-chi/chi/middleware"
-	"github.com/go-chi/chi/v5"
-	httpSwagger "github.com/swaggo/http-swagger/v2"
+	v1docs "github.com/Alhanaqtah/effective-mobile-test-task/docs/v1"
+	"github.com/Alhanaqtah/effective-mobile-test-task/internal/config"
+	"github.com/Alhanaqtah/effective-mobile-test-task/internal/controller/accesslog"
+	boardHandler "github.com/Alhanaqtah/effective-mobile-test-task/internal/controller/board"
+	syncHandler "github.com/Alhanaqtah/effective-mobile-test-task/internal/controller/datasync"
+	eventsHandler "github.com/Alhanaqtah/effective-mobile-test-task/internal/controller/events"
+	"github.com/Alhanaqtah/effective-mobile-test-task/internal/controller/gql"
+	"github.com/Alhanaqtah/effective-mobile-test-task/internal/controller/idempotency"
+	"github.com/Alhanaqtah/effective-mobile-test-task/internal/controller/openapi"
+	"github.com/Alhanaqtah/effective-mobile-test-task/internal/controller/ratelimit"
+	"github.com/Alhanaqtah/effective-mobile-test-task/internal/controller/router"
+	"github.com/Alhanaqtah/effective-mobile-test-task/internal/controller/rpc"
+	tasksHandler "github.com/Alhanaqtah/effective-mobile-test-task/internal/controller/task"
+	usersHandler "github.com/Alhanaqtah/effective-mobile-test-task/internal/controller/user"
+	importHandler "github.com/Alhanaqtah/effective-mobile-test-task/internal/controller/userimport"
+	webhookHandler "github.com/Alhanaqtah/effective-mobile-test-task/internal/controller/webhook"
+	"github.com/Alhanaqtah/effective-mobile-test-task/internal/events"
+	"github.com/Alhanaqtah/effective-mobile-test-task/internal/health"
+	"github.com/Alhanaqtah/effective-mobile-test-task/internal/lib/i18n"
+	"github.com/Alhanaqtah/effective-mobile-test-task/internal/lib/logger"
+	"github.com/Alhanaqtah/effective-mobile-test-task/internal/lib/logger/sl"
+	"github.com/Alhanaqtah/effective-mobile-test-task/internal/metrics"
+	"github.com/Alhanaqtah/effective-mobile-test-task/internal/repository/externalapi"
+	storage "github.com/Alhanaqtah/effective-mobile-test-task/internal/repository/postgres"
+	syncService "github.com/Alhanaqtah/effective-mobile-test-task/internal/service/datasync"
+	reportService "github.com/Alhanaqtah/effective-mobile-test-task/internal/service/report"
+	taskService "github.com/Alhanaqtah/effective-mobile-test-task/internal/service/task"
+	usersService "github.com/Alhanaqtah/effective-mobile-test-task/internal/service/user"
+	importService "github.com/Alhanaqtah/effective-mobile-test-task/internal/service/userimport"
+	webhookService "github.com/Alhanaqtah/effective-mobile-test-task/internal/service/webhook"
+	"github.com/Alhanaqtah/effective-mobile-test-task/internal/tracing"
+	pb "github.com/Alhanaqtah/effective-mobile-test-task/pkg/api/timetracker/v1"
 )
 
-// @title Time Tracker API
-// @version 1.0
-// @description Test task for Effective-mobile.
 func main() {
 	cfg := config.MustLoad()
 
+	// Messages built outside of a request, e.g. by background jobs, and
+	// requests without a negotiated language use the configured one
+	i18n.SetDefault(cfg.Language)
+
 	log := logger.New(cfg.Env)
 	log.Info("initializing server...", slog.String("port", cfg.Server.Port))
 
+	// Spans of requests, service methods, queries and external calls
+	shutdownTracing, err := tracing.Setup(context.Background(), cfg.Tracing)
+	if err != nil {
+		log.Error("tracing initial error", sl.Error(err))
+		return
+	}
+
 	// Data layer
 	storage, err := storage.New(cfg.Storage)
 	if err != nil {
@@ -43,36 +68,148 @@ func main() {
 		return
 	}
 
-	externalAPI := externalapi.New(cfg.ExternalAPI)
+	// Metrics of the HTTP API, the pool, the people info service and business
+	// gauges, served on /metrics
+	metrics := metrics.New(log)
+	metrics.RegisterPool(storage)
+	metrics.RegisterStats(storage)
+
+	externalAPI := externalapi.New(cfg.ExternalAPI, tracing.Transport("people_info", metrics.Transport("people_info", nil)))
+
+	// Live events of users and tasks
+	broker := events.New(cfg.Events.BufferSize)
+
+	// Events of all instances, this one included, arrive through Postgres
+	// notifications sent by the transactions making the changes
+	cluster := events.NewCluster(broker, storage, log)
+
+	clusterCtx, stopCluster := context.WithCancel(context.Background())
+	go cluster.Run(clusterCtx)
 
 	// Service layer
-	usersService := usersService.New(storage, externalAPI, log)
-	tasksService := taskService.New(storage, log)
+	usersService := usersService.New(storage, externalAPI, cluster, log)
+	tasksService := taskService.New(storage, cluster, log)
+	syncService := syncService.New(storage, usersService, tasksService, log)
+	importService := importService.New(storage, usersService, cfg.Import.Concurrency, log)
+	reportService := reportService.New(storage, log)
+	dispatcher := webhookService.NewDispatcher(storage, log)
+	webhookService := webhookService.New(storage, log)
+
+	// Pick up import jobs interrupted by a shutdown or left by a crashed
+	// instance
+	importService.Resume(time.Minute)
 
 	// Controllers layer
 	usersHandler := usersHandler.New(usersService, log)
 	tasksHandler := tasksHandler.New(tasksService, log)
+	importHandler := importHandler.New(importService, log)
+	syncHandler := syncHandler.New(syncService, log)
+	eventsHandler := eventsHandler.New(broker, log)
+	boardHandler := boardHandler.New(tasksService, broker, log)
+	webhookHandler := webhookHandler.New(webhookService, log)
+	graphqlHandler := gql.New(reportService, cfg.GraphQL.MaxDepth, cfg.GraphQL.MaxComplexity, log)
 
-	// Init router
-	r := chi.NewRouter()
-	chi.NewMux()
+	// Idempotency keys are kept for a day, expired ones are purged hourly
+	idempotent := idempotency.New(storage, 24*time.Hour, log)
 
-	r.Use(middleware.RequestID)
-	r.Use(middleware.RealIP)
-	// r.Use(middleware.Logger)
-	r.Use(middleware.Recoverer)
+	purgeCtx, stopPurge := context.WithCancel(context.Background())
+	go idempotent.Purge(purgeCtx, time.Hour)
 
-	r.Route("/users", usersHandler.Register())
-	r.Route("/tasks", tasksHandler.Register())
+	// Deliver events saved in the outbox to webhooks
+	dispatchCtx, stopDispatch := context.WithCancel(context.Background())
+	dispatchDone := make(chan struct{})
+	go func() {
+		defer close(dispatchDone)
+		dispatcher.Run(dispatchCtx)
+	}()
 
-	// Swagger UI docs
-	r.Get("/docs/*", httpSwagger.Handler(
-		httpSwagger.URL(fmt.Sprintf("http://%s:%s/docs/swagger.json", cfg.Server.Host, cfg.Server.Port)),
-	))
+	// Requests are checked against the spec of their version
+	v1Validator, err := openapi.New(v1docs.Spec, log)
+	if err != nil {
+		log.Error("openapi validator initial error", sl.Error(err))
+		return
+	}
+
+	// Requests of every API key and IP are limited per class of routes. The
+	// buckets are shared by the instances if kept in Postgres. Without a
+	// store requests are not limited.
+	var limitStore ratelimit.Store
+	switch cfg.RateLimit.Store {
+	case "memory":
+		limitStore = ratelimit.NewMemoryStore()
+	case "postgres":
+		limitStore = storage
+	}
+
+	var rateLimit func(http.Handler) http.Handler
+	// Unary gRPC calls share the buckets of HTTP requests of the same class
+	var rpcLimiter rpc.RateLimiter
+	purgeLimitsCtx, stopPurgeLimits := context.WithCancel(context.Background())
+	if limitStore != nil {
+		limiter := ratelimit.New(limitStore, []ratelimit.Class{
+			// Fan out to the paid people info service
+			{Name: "external", Limit: ratelimit.Limit(cfg.RateLimit.External), Routes: []ratelimit.Route{
+				{Method: http.MethodPost, Pattern: "/users"},
+				{Method: http.MethodPost, Pattern: "/users/import"},
+			}, Methods: []string{
+				pb.UserService_CreateUser_FullMethodName,
+			}},
+			// Scan the users or tasks tables
+			{Name: "search", Limit: ratelimit.Limit(cfg.RateLimit.Search), Routes: []ratelimit.Route{
+				{Method: http.MethodGet, Pattern: "/users"},
+				{Method: http.MethodGet, Pattern: "/tasks/search"},
+			}, Methods: []string{
+				pb.UserService_ListUsers_FullMethodName,
+				pb.TaskService_SearchTasks_FullMethodName,
+			}},
+			{Name: "default", Limit: ratelimit.Limit(cfg.RateLimit.Default)},
+		}, log)
+
+		go limiter.Purge(purgeLimitsCtx, time.Minute)
+
+		rateLimit = limiter.Handler
+		rpcLimiter = limiter
+	}
+
+	// Init router. A new major version gets its own controllers on top of
+	// the same services and is added to the list; the version it replaces
+	// names it as Successor.
+	v1 := router.Version{
+		Name: "v1",
+		Controllers: router.Controllers{
+			Users:    usersHandler,
+			Import:   importHandler,
+			Tasks:    tasksHandler,
+			Sync:     syncHandler,
+			Webhooks: webhookHandler,
+			Events:   eventsHandler,
+			Board:    boardHandler,
+			GraphQL:  graphqlHandler,
+		},
+		Deprecated: cfg.Versioning.V1Deprecated,
+		Sunset:     cfg.Versioning.V1Sunset,
+		Root:       cfg.Versioning.RootDeprecated,
+		Spec:       v1docs.Spec,
+		Validator:  v1Validator.Handler,
+		RateLimit:  rateLimit,
+	}
+
+	// Every request is logged once served, successful ones sampled
+	accessLog := accesslog.New(log, cfg.AccessLog.SampleRatio)
+
+	r := router.New([]router.Version{v1}, cfg.Language, cfg.Server.TrustedProxies, idempotent.Handler, metrics.Middleware, tracing.Middleware, accessLog.Handler)
+	r.Handle("/metrics", metrics.Handler())
+
+	// Probes: the process is alive, and its dependencies are usable
+	probes := health.New(log)
+	probes.Add("postgres", storage.Ping)
+	probes.Add("migrations", health.Migrations(storage))
+	if cfg.Health.CheckExternalAPI {
+		probes.Add("people_info", externalAPI.Ping)
+	}
 
-	r.Get("/docs/swagger.json", func(w http.ResponseWriter, r *http.Request) {
-		http.ServeFile(w, r, "docs/swagger.json")
-	})
+	r.Get("/healthz", probes.Live)
+	r.Get("/readyz", probes.Ready)
 
 	// Init server
 	srv := http.Server{
@@ -83,6 +220,15 @@ func main() {
 		IdleTimeout:  cfg.Server.Timeout * time.Second,
 	}
 
+	// Streams never become idle, end them for the shutdown not to wait for
+	// its timeout
+	srv.RegisterOnShutdown(eventsHandler.Shutdown)
+	srv.RegisterOnShutdown(boardHandler.Shutdown)
+
+	// gRPC API on its own port
+	grpcServer := rpc.New(usersService, tasksService, broker, cfg.Language, rpcLimiter, log)
+	probes.OnDrain(grpcServer.Drain)
+
 	log.Info("server initialized")
 
 	stop := make(chan os.Signal, 1)
@@ -95,18 +241,67 @@ func main() {
 		}
 	}()
 
-	log.Info("server is running...")
+	go func() {
+		if err := grpcServer.ListenAndServe(cfg.Server.Host + ":" + cfg.GRPC.Port); err != nil {
+			log.Error("gRPC server error", sl.Error(err))
+		}
+	}()
+
+	log.Info("server is running...", slog.String("grpc_port", cfg.GRPC.Port))
 
 	<-stop
 
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-	defer cancel()
+	// Fail readiness first so load balancers stop sending requests, then
+	// drain the requests in flight
+	probes.Drain()
+	log.Info("draining...", slog.Duration("delay", cfg.Health.DrainDelay))
+	time.Sleep(cfg.Health.DrainDelay)
 
-	if err := srv.Shutdown(ctx); err != nil {
-		log.Error("failed to shutdown server", sl.Error(err))
-	}
+	// Both servers drain at once, each within its own timeout, so a slow
+	// one does not cut the calls of the other short
+	const shutdownTimeout = 10 * time.Second
+
+	var shutdown sync.WaitGroup
+	shutdown.Add(2)
+
+	go func() {
+		defer shutdown.Done()
+
+		ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+		defer cancel()
+
+		if err := srv.Shutdown(ctx); err != nil {
+			log.Error("failed to shutdown server", sl.Error(err))
+		}
+	}()
+
+	go func() {
+		defer shutdown.Done()
 
+		ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+		defer cancel()
+
+		if err := grpcServer.Shutdown(ctx); err != nil {
+			log.Error("failed to shutdown gRPC server", sl.Error(err))
+		}
+	}()
+
+	shutdown.Wait()
+
+	stopPurge()
+	stopPurgeLimits()
+	stopDispatch()
+	<-dispatchDone
+	stopCluster()
+	importService.Shutdown()
 	storage.Close()
 
+	ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+
+	if err := shutdownTracing(ctx); err != nil {
+		log.Error("failed to flush traces", sl.Error(err))
+	}
+
 	log.Info("server stopped")
 }
@@ -0,0 +1,85 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// Config is stored in the user config directory, TT_CONFIG overrides its path.
+type Config struct {
+	Server string `json:"server"`
+	APIKey string `json:"api_key,omitempty"`
+	UserID string `json:"user_id,omitempty"`
+}
+
+const defaultServer = "http://localhost:8080"
+
+func configPath() (string, error) {
+	const op = "tt.configPath"
+
+	if path := os.Getenv("TT_CONFIG"); path != "" {
+		return path, nil
+	}
+
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	return filepath.Join(dir, "tt", "config.json"), nil
+}
+
+// loadConfig reads the config file. A missing file gives the defaults.
+func loadConfig() (*Config, error) {
+	const op = "tt.loadConfig"
+
+	cfg := &Config{Server: defaultServer}
+
+	path, err := configPath()
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return cfg, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := json.Unmarshal(data, cfg); err != nil {
+		return nil, fmt.Errorf("%s: %s: %w", op, path, err)
+	}
+
+	return cfg, nil
+}
+
+// saveConfig writes the config file readable by the owner only, as it holds
+// the API key.
+func saveConfig(cfg *Config) error {
+	const op = "tt.saveConfig"
+
+	path, err := configPath()
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	data, err := json.MarshalIndent(cfg, "", "  ")
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := os.WriteFile(path, append(data, '\n'), 0o600); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
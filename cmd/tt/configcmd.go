@@ -0,0 +1,74 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+
+	uuidlib "github.com/google/uuid"
+)
+
+// runConfig prints the config or, when flags are given, changes it.
+func runConfig(_ context.Context, args []string) error {
+	fs := newFlagSet("config", "[-server url] [-api-key key] [-user uuid]")
+	server := fs.String("server", "", "base URL of the REST API")
+	apiKey := fs.String("api-key", "", "API key sent with every request")
+	userID := fs.String("user", "", "UUID of the default user")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+
+	cfg, err := loadConfig()
+	if err != nil {
+		return err
+	}
+
+	changed := false
+	var setErr error
+	fs.Visit(func(f *flag.Flag) {
+		changed = true
+		switch f.Name {
+		case "server":
+			u, err := url.Parse(*server)
+			if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+				setErr = fmt.Errorf("invalid server %q, expected an http or https URL", *server)
+				return
+			}
+			cfg.Server = *server
+		case "api-key":
+			cfg.APIKey = *apiKey
+		case "user":
+			if _, err := uuidlib.Parse(*userID); err != nil {
+				setErr = fmt.Errorf("invalid user %q, expected a UUID", *userID)
+				return
+			}
+			cfg.UserID = *userID
+		}
+	})
+	if setErr != nil {
+		return setErr
+	}
+
+	if changed {
+		return saveConfig(cfg)
+	}
+
+	path, err := configPath()
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(os.Stdout, "config:  %s\nserver:  %s\napi key: %s\nuser:    %s\n", path, cfg.Server, maskKey(cfg.APIKey), cfg.UserID)
+	return nil
+}
+
+// maskKey hides all but the last four characters of an API key.
+func maskKey(key string) string {
+	if len(key) <= 4 {
+		return strings.Repeat("*", len(key))
+	}
+	return strings.Repeat("*", len(key)-4) + key[len(key)-4:]
+}
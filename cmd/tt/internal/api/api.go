@@ -0,0 +1,8 @@
+// Package api is the REST API client used by tt. It is generated from
+// docs/v1/v1_swagger.json, run go generate after regenerating the swagger docs.
+// The OpenAPI 3 document in between is written on every run and not kept
+// in the repository, docs/v1 is the only copy of the spec.
+package api
+
+//go:generate go run ./convert ../../../../docs/v1/v1_swagger.json openapi.json
+//go:generate oapi-codegen -config oapi-codegen.yaml openapi.json
@@ -0,0 +1,52 @@
+// Command convert turns the Swagger 2.0 document produced by swag into an
+// OpenAPI 3 document the client generator understands.
+//
+// Usage: convert <swagger.json> <openapi.json>
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/getkin/kin-openapi/openapi2"
+	"github.com/getkin/kin-openapi/openapi2conv"
+)
+
+func main() {
+	if len(os.Args) != 3 {
+		fmt.Fprintln(os.Stderr, "usage: convert <swagger.json> <openapi.json>")
+		os.Exit(2)
+	}
+
+	if err := convert(os.Args[1], os.Args[2]); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func convert(in, out string) error {
+	const op = "convert"
+
+	data, err := os.ReadFile(in)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	var doc2 openapi2.T
+	if err := json.Unmarshal(data, &doc2); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	doc3, err := openapi2conv.ToV3(&doc2)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	data, err = json.MarshalIndent(doc3, "", "  ")
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return os.WriteFile(out, append(data, '\n'), 0o644)
+}
@@ -0,0 +1,170 @@
+// Command tt tracks time from the terminal using the Time Tracker REST API.
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"net/http"
+	"os"
+	"os/signal"
+	"strings"
+	"time"
+
+	"github.com/Alhanaqtah/effective-mobile-test-task/cmd/tt/internal/api"
+
+	uuidlib "github.com/google/uuid"
+)
+
+const usage = `Usage: tt <command> [flags]
+
+Commands:
+  config   show or change the server, API key and default user
+  start    start a new task, stopping the running one
+  stop     stop the running task
+  status   show the running task
+  report   list tasks of a period as a table, JSON or CSV
+
+Run "tt <command> -h" for the flags of a command.
+`
+
+// apiPath is the prefix of the API version the generated client speaks.
+const apiPath = "/api/v1"
+
+var errUsage = errors.New("invalid usage")
+
+func main() {
+	flag.Usage = func() { fmt.Fprint(os.Stderr, usage) }
+	flag.Parse()
+
+	if flag.NArg() == 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	commands := map[string]func(ctx context.Context, args []string) error{
+		"config": runConfig,
+		"start":  runStart,
+		"stop":   runStop,
+		"status": runStatus,
+		"report": runReport,
+	}
+
+	run, ok := commands[flag.Arg(0)]
+	if !ok {
+		fmt.Fprintf(os.Stderr, "tt: unknown command %q\n\n", flag.Arg(0))
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	if err := run(ctx, flag.Args()[1:]); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return
+		}
+		if errors.Is(err, errUsage) {
+			os.Exit(2)
+		}
+		fmt.Fprintln(os.Stderr, "tt:", err)
+		os.Exit(1)
+	}
+}
+
+// newFlagSet returns a flag set of a command that reports parse errors
+// instead of exiting.
+func newFlagSet(name, args string) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage: tt %s %s\n\n", name, args)
+		fs.PrintDefaults()
+	}
+	return fs
+}
+
+// parseFlags parses args, wrapping parse errors into errUsage as they are
+// already printed by the flag set.
+func parseFlags(fs *flag.FlagSet, args []string) error {
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return err
+		}
+		return errUsage
+	}
+	return nil
+}
+
+// session holds what every command needs to talk to the API.
+type session struct {
+	cfg    *Config
+	client *api.ClientWithResponses
+	userID uuidlib.UUID
+}
+
+// newSession loads the config and creates a client for the user given by
+// flag or, if empty, the default user.
+func newSession(userID string) (*session, error) {
+	cfg, err := loadConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	if userID == "" {
+		userID = cfg.UserID
+	}
+	if userID == "" {
+		return nil, errors.New(`no user given, pass -user or set a default with "tt config -user <uuid>"`)
+	}
+	id, err := uuidlib.Parse(userID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user %q: %w", userID, err)
+	}
+
+	client, err := api.NewClientWithResponses(
+		strings.TrimRight(cfg.Server, "/")+apiPath,
+		api.WithHTTPClient(&http.Client{Timeout: 30 * time.Second}),
+		api.WithRequestEditorFn(func(_ context.Context, req *http.Request) error {
+			if cfg.APIKey != "" {
+				req.Header.Set("Authorization", "Bearer "+cfg.APIKey)
+			}
+			return nil
+		}),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("invalid server %q: %w", cfg.Server, err)
+	}
+
+	return &session{cfg: cfg, client: client, userID: id}, nil
+}
+
+// problemError turns an error response of the API into an error with the
+// problem title, detail and invalid fields.
+func problemError(resp *http.Response, body []byte) error {
+	var p api.ProblemProblem
+	if err := json.Unmarshal(body, &p); err != nil || p.Title == nil {
+		return fmt.Errorf("unexpected response: %s", resp.Status)
+	}
+
+	return problem(p)
+}
+
+// problem formats a problem document as an error.
+func problem(p api.ProblemProblem) error {
+	msg := deref(p.Title)
+	if p.Detail != nil && *p.Detail != "" {
+		msg += ": " + *p.Detail
+	}
+
+	if p.Errors != nil {
+		for _, fe := range *p.Errors {
+			if fe.Field != nil && fe.Detail != nil {
+				msg += fmt.Sprintf("\n  %s: %s", *fe.Field, *fe.Detail)
+			}
+		}
+	}
+
+	return errors.New(msg)
+}
@@ -0,0 +1,243 @@
+package main
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"text/tabwriter"
+	"time"
+
+	"github.com/Alhanaqtah/effective-mobile-test-task/cmd/tt/internal/api"
+)
+
+// Output formats
+const (
+	formatTable = "table"
+	formatJSON  = "json"
+	formatCSV   = "csv"
+)
+
+const dateLayout = "2006-01-02"
+
+// runReport prints the tasks created in a period, the current week by
+// default.
+func runReport(ctx context.Context, args []string) error {
+	fs := newFlagSet("report", "[-user uuid] [-day | -week | -month | -from date -to date] [-format table|json|csv]")
+	userID := fs.String("user", "", "UUID of the user, the default user if empty")
+	day := fs.Bool("day", false, "report today")
+	week := fs.Bool("week", false, "report the current week (default)")
+	month := fs.Bool("month", false, "report the current month")
+	from := fs.String("from", "", "first day of the report, YYYY-MM-DD")
+	to := fs.String("to", "", "last day of the report, YYYY-MM-DD, today if empty")
+	format := fs.String("format", formatTable, "output format: table, json or csv")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+
+	if !validFormat(*format) {
+		return fmt.Errorf("unknown format %q, expected table, json or csv", *format)
+	}
+
+	now := time.Now()
+	start, end, err := period(now, *day, *week, *month, *from, *to)
+	if err != nil {
+		return err
+	}
+
+	s, err := newSession(*userID)
+	if err != nil {
+		return err
+	}
+
+	resp, err := s.client.GetTasksUserIdWorklogsWithResponse(ctx, s.userID, &api.GetTasksUserIdWorklogsParams{
+		StartDate: start.Format(time.RFC3339),
+		EndDate:   end.Format(time.RFC3339),
+	})
+	if err != nil {
+		return err
+	}
+	if resp.JSON200 == nil {
+		return problemError(resp.HTTPResponse, resp.Body)
+	}
+
+	entries := make([]entry, 0, len(*resp.JSON200))
+	for _, task := range *resp.JSON200 {
+		entries = append(entries, newEntry(task, now))
+	}
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].StartedAt.Before(entries[j].StartedAt)
+	})
+
+	return writeEntries(os.Stdout, *format, entries)
+}
+
+// period returns the bounds of the report period in local time. Only one
+// way of choosing the period may be used.
+func period(now time.Time, day, week, month bool, from, to string) (time.Time, time.Time, error) {
+	chosen := 0
+	for _, set := range []bool{day, week, month, from != "" || to != ""} {
+		if set {
+			chosen++
+		}
+	}
+	if chosen > 1 {
+		return time.Time{}, time.Time{}, fmt.Errorf("only one of -day, -week, -month or -from/-to can be given")
+	}
+
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+
+	switch {
+	case day:
+		return today, now, nil
+	case month:
+		return time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location()), now, nil
+	case from != "" || to != "":
+		if from == "" {
+			return time.Time{}, time.Time{}, fmt.Errorf("-to requires -from")
+		}
+
+		start, err := time.ParseInLocation(dateLayout, from, now.Location())
+		if err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("invalid -from %q, expected YYYY-MM-DD", from)
+		}
+
+		end := now
+		if to != "" {
+			last, err := time.ParseInLocation(dateLayout, to, now.Location())
+			if err != nil {
+				return time.Time{}, time.Time{}, fmt.Errorf("invalid -to %q, expected YYYY-MM-DD", to)
+			}
+			// The last day is included as a whole
+			end = last.AddDate(0, 0, 1).Add(-time.Nanosecond)
+		}
+
+		if end.Before(start) {
+			return time.Time{}, time.Time{}, fmt.Errorf("-from is after -to")
+		}
+		return start, end, nil
+	default:
+		// Weeks start on Monday
+		offset := (int(today.Weekday()) + 6) % 7
+		return today.AddDate(0, 0, -offset), now, nil
+	}
+}
+
+// entry is a task as printed by tt.
+type entry struct {
+	ID        string        `json:"id"`
+	Title     string        `json:"title"`
+	StartedAt time.Time     `json:"started_at"`
+	DoneAt    *time.Time    `json:"done_at,omitempty"`
+	Running   bool          `json:"running"`
+	Minutes   float64       `json:"minutes"`
+	Duration  time.Duration `json:"-"`
+}
+
+// newEntry converts a task of the API. A running task is counted up to now,
+// a task that was never started has no duration.
+func newEntry(task api.ModelsTask, now time.Time) entry {
+	e := entry{
+		ID:        deref(task.Id),
+		Title:     deref(task.Title),
+		StartedAt: parseTime(task.CreatedAt),
+	}
+
+	if task.StartedAt != nil {
+		e.StartedAt = parseTime(task.StartedAt)
+	}
+
+	switch {
+	case deref(task.Done) && task.DoneAt != nil:
+		doneAt := parseTime(task.DoneAt)
+		e.DoneAt = &doneAt
+		e.Duration = doneAt.Sub(e.StartedAt)
+	case task.StartedAt != nil && !deref(task.Done):
+		e.Running = true
+		e.Duration = now.Sub(e.StartedAt)
+	}
+
+	e.Duration = e.Duration.Truncate(time.Second)
+	e.Minutes = e.Duration.Minutes()
+
+	return e
+}
+
+func parseTime(s *string) time.Time {
+	t, _ := time.Parse(time.RFC3339Nano, deref(s))
+	return t.Local()
+}
+
+func validFormat(format string) bool {
+	return format == formatTable || format == formatJSON || format == formatCSV
+}
+
+// writeEntries prints entries in the given format. The table ends with the
+// total duration.
+func writeEntries(w io.Writer, format string, entries []entry) error {
+	switch format {
+	case formatJSON:
+		if entries == nil {
+			entries = []entry{}
+		}
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(entries)
+
+	case formatCSV:
+		cw := csv.NewWriter(w)
+		_ = cw.Write([]string{"id", "title", "started_at", "done_at", "running", "minutes"})
+		for _, e := range entries {
+			doneAt := ""
+			if e.DoneAt != nil {
+				doneAt = e.DoneAt.Format(time.RFC3339)
+			}
+			_ = cw.Write([]string{
+				e.ID,
+				e.Title,
+				e.StartedAt.Format(time.RFC3339),
+				doneAt,
+				strconv.FormatBool(e.Running),
+				strconv.FormatFloat(e.Minutes, 'f', 2, 64),
+			})
+		}
+		cw.Flush()
+		return cw.Error()
+
+	case formatTable:
+		tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(tw, "TITLE\tSTARTED\tFINISHED\tDURATION")
+
+		var total time.Duration
+		for _, e := range entries {
+			finished := "-"
+			switch {
+			case e.DoneAt != nil:
+				finished = e.DoneAt.Format("2006-01-02 15:04")
+			case e.Running:
+				finished = "running"
+			}
+
+			fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", e.Title, e.StartedAt.Format("2006-01-02 15:04"), finished, formatDuration(e.Duration))
+			total += e.Duration
+		}
+
+		if len(entries) > 1 {
+			fmt.Fprintf(tw, "\t\tTOTAL\t%s\n", formatDuration(total))
+		}
+		return tw.Flush()
+
+	default:
+		return fmt.Errorf("unknown format %q, expected table, json or csv", format)
+	}
+}
+
+// formatDuration prints a duration as hours, minutes and seconds, e.g. 1:05:09.
+func formatDuration(d time.Duration) string {
+	d = d.Round(time.Second)
+	return fmt.Sprintf("%d:%02d:%02d", int(d.Hours()), int(d.Minutes())%60, int(d.Seconds())%60)
+}
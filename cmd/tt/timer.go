@@ -0,0 +1,202 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/Alhanaqtah/effective-mobile-test-task/cmd/tt/internal/api"
+
+	uuidlib "github.com/google/uuid"
+)
+
+// runStart creates a task and starts it in one atomic batch. The running
+// task, if any, is finished in the same batch so only one timer runs.
+func runStart(ctx context.Context, args []string) error {
+	fs := newFlagSet("start", "[-user uuid] [-description text] <title>")
+	userID := fs.String("user", "", "UUID of the user, the default user if empty")
+	description := fs.String("description", "", "description of the task")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+
+	title := strings.TrimSpace(strings.Join(fs.Args(), " "))
+	if title == "" {
+		fs.Usage()
+		return errUsage
+	}
+
+	s, err := newSession(*userID)
+	if err != nil {
+		return err
+	}
+
+	running, err := s.running(ctx)
+	if err != nil {
+		return err
+	}
+
+	var ops []api.RequestTaskOperation
+	if running != nil {
+		ops = append(ops, api.RequestTaskOperation{
+			Op:      api.Finish,
+			TaskId:  running.Id,
+			Version: running.Version,
+		})
+	}
+
+	create := api.RequestTaskOperation{
+		Op:     api.Create,
+		Ref:    ptr("new"),
+		UserId: ptr(s.userID.String()),
+		Title:  &title,
+	}
+	if *description != "" {
+		create.Description = description
+	}
+
+	ops = append(ops, create, api.RequestTaskOperation{
+		Op:      api.Start,
+		TaskRef: ptr("new"),
+	})
+
+	// The key makes retries of the request by proxies safe
+	resp, err := s.client.PostTasksBatchWithResponse(ctx,
+		&api.PostTasksBatchParams{IdempotencyKey: ptr(uuidlib.NewString())},
+		api.RequestTaskBatch{Mode: ptr(api.Atomic), Operations: ops},
+	)
+	if err != nil {
+		return err
+	}
+	if resp.JSON200 == nil {
+		return problemError(resp.HTTPResponse, resp.Body)
+	}
+
+	results := deref(resp.JSON200.Results)
+	if !deref(resp.JSON200.Committed) {
+		for _, res := range results {
+			if res.Error != nil {
+				return problem(*res.Error)
+			}
+		}
+		return errors.New("the task was not started")
+	}
+
+	now := time.Now()
+	if running != nil {
+		e := newEntry(*running, now)
+		fmt.Fprintf(os.Stdout, "Stopped %q after %s\n", e.Title, formatDuration(e.Duration))
+	}
+	fmt.Fprintf(os.Stdout, "Started %q\n", title)
+
+	return nil
+}
+
+// runStop finishes the running task.
+func runStop(ctx context.Context, args []string) error {
+	fs := newFlagSet("stop", "[-user uuid]")
+	userID := fs.String("user", "", "UUID of the user, the default user if empty")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+
+	s, err := newSession(*userID)
+	if err != nil {
+		return err
+	}
+
+	running, err := s.running(ctx)
+	if err != nil {
+		return err
+	}
+	if running == nil {
+		return errors.New("no task is running")
+	}
+
+	taskID, err := uuidlib.Parse(deref(running.Id))
+	if err != nil {
+		return fmt.Errorf("invalid task id %q: %w", deref(running.Id), err)
+	}
+
+	// If-Match keeps us from finishing a task changed since it was read
+	resp, err := s.client.PostTasksTaskIdFinishWithResponse(ctx, taskID, &api.PostTasksTaskIdFinishParams{
+		IfMatch:        ptr(fmt.Sprintf(`"%d"`, deref(running.Version))),
+		IdempotencyKey: ptr(uuidlib.NewString()),
+	})
+	if err != nil {
+		return err
+	}
+	if resp.JSON200 == nil {
+		return problemError(resp.HTTPResponse, resp.Body)
+	}
+
+	e := newEntry(*resp.JSON200, time.Now())
+	fmt.Fprintf(os.Stdout, "Stopped %q after %s\n", e.Title, formatDuration(e.Duration))
+
+	return nil
+}
+
+// runStatus prints the running task and its elapsed time.
+func runStatus(ctx context.Context, args []string) error {
+	fs := newFlagSet("status", "[-user uuid] [-format table|json|csv]")
+	userID := fs.String("user", "", "UUID of the user, the default user if empty")
+	format := fs.String("format", formatTable, "output format: table, json or csv")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+
+	if !validFormat(*format) {
+		return fmt.Errorf("unknown format %q, expected table, json or csv", *format)
+	}
+
+	s, err := newSession(*userID)
+	if err != nil {
+		return err
+	}
+
+	running, err := s.running(ctx)
+	if err != nil {
+		return err
+	}
+
+	if running == nil {
+		if *format == formatTable {
+			fmt.Fprintln(os.Stdout, "No task is running")
+			return nil
+		}
+		return writeEntries(os.Stdout, *format, nil)
+	}
+
+	return writeEntries(os.Stdout, *format, []entry{newEntry(*running, time.Now())})
+}
+
+// running returns the task the user is working on, nil if there is none.
+func (s *session) running(ctx context.Context) (*api.ModelsTask, error) {
+	resp, err := s.client.GetTasksRunningWithResponse(ctx, &api.GetTasksRunningParams{UserId: []string{s.userID.String()}})
+	if err != nil {
+		return nil, err
+	}
+	if resp.JSON200 == nil {
+		return nil, problemError(resp.HTTPResponse, resp.Body)
+	}
+
+	if len(*resp.JSON200) == 0 {
+		return nil, nil
+	}
+	return &(*resp.JSON200)[0], nil
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
+
+func deref[T any](p *T) T {
+	var zero T
+	if p == nil {
+		return zero
+	}
+	return *p
+}
@@ -0,0 +1,9 @@
+package v1
+
+import _ "embed"
+
+// Spec is the Swagger specification of version v1 of the API, generated by
+// make docs.
+//
+//go:embed v1_swagger.json
+var Spec []byte
@@ -2,18 +2,40 @@ package config
 
 import (
 	"log"
+	"net/netip"
 	"os"
 	"strconv"
+	"strings"
 	"time"
 
+	"github.com/Alhanaqtah/effective-mobile-test-task/internal/lib/i18n"
+
 	"github.com/joho/godotenv"
 )
 
 type Config struct {
 	Env         string
 	ExternalAPI string
+	Language    i18n.Lang
+	*Import
+	*Events
 	*Storage
 	*Server
+	*GRPC
+	*GraphQL
+	*Versioning
+	*Tracing
+	*Health
+	*AccessLog
+	*RateLimit
+}
+
+type Import struct {
+	Concurrency int
+}
+
+type Events struct {
+	BufferSize int
 }
 
 type Storage struct {
@@ -25,9 +47,54 @@ type Storage struct {
 }
 
 type Server struct {
-	Host    string
-	Port    string
-	Timeout time.Duration
+	Host           string
+	Port           string
+	Timeout        time.Duration
+	TrustedProxies []netip.Prefix // Proxies whose X-Forwarded-For and X-Real-IP are honoured
+}
+
+type GRPC struct {
+	Port string
+}
+
+type GraphQL struct {
+	MaxDepth      int
+	MaxComplexity int
+}
+
+type Versioning struct {
+	V1Deprecated   time.Time // Zero while v1 is current
+	V1Sunset       time.Time // Zero if not planned
+	RootDeprecated time.Time // When the aliases of v1 at the root were deprecated, zero serves none
+}
+
+type Health struct {
+	CheckExternalAPI bool          // Whether readiness depends on the people info service
+	DrainDelay       time.Duration // How long readiness fails before the server shuts down
+}
+
+type AccessLog struct {
+	SampleRatio float64 // Share of successful requests logged, failed ones are always logged
+}
+
+type RateLimit struct {
+	Store    string // none, memory or postgres
+	Default  Rate   // Requests of every client to routes of no other class
+	Search   Rate   // Requests of every client scanning users or tasks
+	External Rate   // Requests of every client calling the people info service
+}
+
+// Rate allows Count requests per Period.
+type Rate struct {
+	Count  int
+	Period time.Duration
+}
+
+type Tracing struct {
+	Exporter     string // none, stdout, file or otlp
+	File         string
+	OTLPEndpoint string
+	SampleRatio  float64
 }
 
 func MustLoad() *Config {
@@ -41,9 +108,131 @@ func MustLoad() *Config {
 		log.Panic("Error loading SERVER_TIMEOUT variable")
 	}
 
+	language := i18n.Lang(os.Getenv("DEFAULT_LANGUAGE"))
+	if language == "" {
+		language = i18n.EN
+	}
+	if !i18n.IsSupported(language) {
+		log.Panic("Error loading DEFAULT_LANGUAGE variable")
+	}
+
+	importConcurrency := 4
+	if v := os.Getenv("IMPORT_CONCURRENCY"); v != "" {
+		importConcurrency, err = strconv.Atoi(v)
+		if err != nil || importConcurrency < 1 {
+			log.Panic("Error loading IMPORT_CONCURRENCY variable")
+		}
+	}
+
+	eventsBufferSize := 1000
+	if v := os.Getenv("EVENTS_BUFFER_SIZE"); v != "" {
+		eventsBufferSize, err = strconv.Atoi(v)
+		if err != nil || eventsBufferSize < 1 {
+			log.Panic("Error loading EVENTS_BUFFER_SIZE variable")
+		}
+	}
+
+	graphqlMaxDepth := 10
+	if v := os.Getenv("GRAPHQL_MAX_DEPTH"); v != "" {
+		graphqlMaxDepth, err = strconv.Atoi(v)
+		if err != nil || graphqlMaxDepth < 1 {
+			log.Panic("Error loading GRAPHQL_MAX_DEPTH variable")
+		}
+	}
+
+	graphqlMaxComplexity := 5000
+	if v := os.Getenv("GRAPHQL_MAX_COMPLEXITY"); v != "" {
+		graphqlMaxComplexity, err = strconv.Atoi(v)
+		if err != nil || graphqlMaxComplexity < 1 {
+			log.Panic("Error loading GRAPHQL_MAX_COMPLEXITY variable")
+		}
+	}
+
+	// v1 is current until v2 is released, so neither date is set by default
+	v1Deprecated := mustDate("API_V1_DEPRECATED", "")
+	v1Sunset := mustDate("API_V1_SUNSET", "")
+	if !v1Sunset.IsZero() && (v1Deprecated.IsZero() || v1Sunset.Before(v1Deprecated)) {
+		log.Panic("Error loading API_V1_SUNSET variable")
+	}
+
+	// The API was served at the root until v1 was introduced
+	rootDeprecated := mustDate("API_ROOT_DEPRECATED", "2026-10-18")
+
+	tracingExporter := os.Getenv("TRACING_EXPORTER")
+	if tracingExporter == "" {
+		tracingExporter = "none"
+	}
+	switch tracingExporter {
+	case "none", "stdout", "otlp":
+	case "file":
+		if os.Getenv("TRACING_FILE") == "" {
+			log.Panic("Error loading TRACING_FILE variable")
+		}
+	default:
+		log.Panic("Error loading TRACING_EXPORTER variable")
+	}
+
+	tracingSampleRatio := 1.0
+	if v := os.Getenv("TRACING_SAMPLE_RATIO"); v != "" {
+		tracingSampleRatio, err = strconv.ParseFloat(v, 64)
+		if err != nil || tracingSampleRatio < 0 || tracingSampleRatio > 1 {
+			log.Panic("Error loading TRACING_SAMPLE_RATIO variable")
+		}
+	}
+
+	checkExternalAPI := false
+	if v := os.Getenv("READY_CHECK_EXTERNAL_API"); v != "" {
+		checkExternalAPI, err = strconv.ParseBool(v)
+		if err != nil {
+			log.Panic("Error loading READY_CHECK_EXTERNAL_API variable")
+		}
+	}
+
+	drainDelay := 5
+	if v := os.Getenv("SHUTDOWN_DRAIN_DELAY"); v != "" {
+		drainDelay, err = strconv.Atoi(v)
+		if err != nil || drainDelay < 0 {
+			log.Panic("Error loading SHUTDOWN_DRAIN_DELAY variable")
+		}
+	}
+
+	// Successful requests would flood the logs in prod
+	accessLogSampleRatio := 1.0
+	if os.Getenv("ENV") == "prod" {
+		accessLogSampleRatio = 0.1
+	}
+	if v := os.Getenv("ACCESS_LOG_SAMPLE_RATIO"); v != "" {
+		accessLogSampleRatio, err = strconv.ParseFloat(v, 64)
+		if err != nil || accessLogSampleRatio < 0 || accessLogSampleRatio > 1 {
+			log.Panic("Error loading ACCESS_LOG_SAMPLE_RATIO variable")
+		}
+	}
+
+	rateLimitStore := os.Getenv("RATE_LIMIT_STORE")
+	if rateLimitStore == "" {
+		rateLimitStore = "memory"
+	}
+	switch rateLimitStore {
+	case "none", "memory", "postgres":
+	default:
+		log.Panic("Error loading RATE_LIMIT_STORE variable")
+	}
+
+	grpcPort := os.Getenv("GRPC_PORT")
+	if grpcPort == "" {
+		grpcPort = "50051"
+	}
+
 	return &Config{
 		os.Getenv("ENV"),
 		os.Getenv("EXTERNAL_API_URL"),
+		language,
+		&Import{
+			Concurrency: importConcurrency,
+		},
+		&Events{
+			BufferSize: eventsBufferSize,
+		},
 		&Storage{
 			User:     os.Getenv("POSTGRES_USER"),
 			Password: os.Getenv("POSTGRES_PASSWORD"),
@@ -52,9 +241,115 @@ func MustLoad() *Config {
 			Database: os.Getenv("DATABASE_NAME"),
 		},
 		&Server{
-			Host:    os.Getenv("SERVER_HOST"),
-			Port:    os.Getenv("SERVER_PORT"),
-			Timeout: time.Duration(timeout),
+			Host:           os.Getenv("SERVER_HOST"),
+			Port:           os.Getenv("SERVER_PORT"),
+			Timeout:        time.Duration(timeout),
+			TrustedProxies: mustPrefixes("TRUSTED_PROXIES"),
+		},
+		&GRPC{
+			Port: grpcPort,
+		},
+		&GraphQL{
+			MaxDepth:      graphqlMaxDepth,
+			MaxComplexity: graphqlMaxComplexity,
+		},
+		&Versioning{
+			V1Deprecated:   v1Deprecated,
+			V1Sunset:       v1Sunset,
+			RootDeprecated: rootDeprecated,
+		},
+		&Tracing{
+			Exporter:     tracingExporter,
+			File:         os.Getenv("TRACING_FILE"),
+			OTLPEndpoint: os.Getenv("TRACING_OTLP_ENDPOINT"),
+			SampleRatio:  tracingSampleRatio,
+		},
+		&Health{
+			CheckExternalAPI: checkExternalAPI,
+			DrainDelay:       time.Duration(drainDelay) * time.Second,
+		},
+		&AccessLog{
+			SampleRatio: accessLogSampleRatio,
+		},
+		&RateLimit{
+			Store:    rateLimitStore,
+			Default:  mustRate("RATE_LIMIT_DEFAULT", "600/m"),
+			Search:   mustRate("RATE_LIMIT_SEARCH", "60/m"),
+			External: mustRate("RATE_LIMIT_EXTERNAL", "20/m"),
 		},
 	}
 }
+
+// mustDate reads a YYYY-MM-DD date from the environment variable key,
+// falling back to the fallback date if it is not set. A variable set to an
+// empty value, like an empty fallback, is the zero time.
+func mustDate(key, fallback string) time.Time {
+	v, ok := os.LookupEnv(key)
+	if !ok {
+		v = fallback
+	}
+	if v == "" {
+		return time.Time{}
+	}
+
+	date, err := time.Parse(time.DateOnly, v)
+	if err != nil {
+		log.Panicf("Error loading %s variable", key)
+	}
+
+	return date
+}
+
+// mustPrefixes reads a comma separated list of IP addresses and CIDR
+// prefixes, e.g. 10.0.0.0/8,192.168.1.1, from the environment variable key.
+func mustPrefixes(key string) []netip.Prefix {
+	var prefixes []netip.Prefix
+	for _, v := range strings.Split(os.Getenv(key), ",") {
+		v = strings.TrimSpace(v)
+		if v == "" {
+			continue
+		}
+
+		if !strings.Contains(v, "/") {
+			ip, err := netip.ParseAddr(v)
+			if err != nil {
+				log.Panicf("Error loading %s variable", key)
+			}
+			ip = ip.Unmap()
+			prefixes = append(prefixes, netip.PrefixFrom(ip, ip.BitLen()))
+			continue
+		}
+
+		prefix, err := netip.ParsePrefix(v)
+		if err != nil {
+			log.Panicf("Error loading %s variable", key)
+		}
+		prefixes = append(prefixes, prefix.Masked())
+	}
+
+	return prefixes
+}
+
+// mustRate reads a rate given as <count>/<unit>, where the unit is s, m or
+// h, e.g. 20/m, from the environment variable key.
+func mustRate(key, fallback string) Rate {
+	v := os.Getenv(key)
+	if v == "" {
+		v = fallback
+	}
+
+	periods := map[string]time.Duration{
+		"s": time.Second,
+		"m": time.Minute,
+		"h": time.Hour,
+	}
+
+	count, unit, _ := strings.Cut(v, "/")
+	n, err := strconv.Atoi(count)
+	period, ok := periods[unit]
+	if err != nil || n < 1 || !ok {
+		log.Panicf("Error loading %s variable", key)
+	}
+
+	return Rate{Count: n, Period: period}
+}
@@ -0,0 +1,106 @@
+// Package accesslog logs every request served by the HTTP API with its
+// route, status, size and latency.
+package accesslog
+
+import (
+	"log/slog"
+	"math/rand/v2"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/Alhanaqtah/effective-mobile-test-task/internal/lib/apikey"
+	"github.com/Alhanaqtah/effective-mobile-test-task/internal/lib/logger/sl"
+
+	"github.com/go-chi/chi/middleware"
+	"github.com/go-chi/chi/v5"
+)
+
+type Logger struct {
+	log         *slog.Logger
+	sampleRatio float64
+}
+
+// New returns an access logger writing to log. Successful requests are
+// logged with probability sampleRatio, failed ones always.
+func New(log *slog.Logger, sampleRatio float64) *Logger {
+	return &Logger{
+		log:         log,
+		sampleRatio: sampleRatio,
+	}
+}
+
+// Handler logs requests once they are served. It must run inside the chi
+// router, after the real IP is resolved, so the route pattern and the
+// client address are known, and outside the recoverer, so panics are logged
+// as the 500 it writes.
+func (l *Logger) Handler(next http.Handler) http.Handler {
+	fn := func(w http.ResponseWriter, r *http.Request) {
+		ww := middleware.NewWrapResponseWriter(w, r.ProtoMajor)
+		start := time.Now()
+
+		defer func() {
+			status := ww.Status()
+			switch {
+			case status != 0:
+			case r.Header.Get("Upgrade") != "":
+				// The connection was hijacked by a WebSocket handler
+				status = http.StatusSwitchingProtocols
+			default:
+				// Nothing was written, net/http sends an empty 200
+				status = http.StatusOK
+			}
+
+			level := slog.LevelInfo
+			switch {
+			case status >= http.StatusInternalServerError:
+				level = slog.LevelError
+			case status >= http.StatusBadRequest:
+				level = slog.LevelWarn
+			case l.sampleRatio < 1 && rand.Float64() >= l.sampleRatio:
+				return
+			}
+
+			var route string
+			if rctx := chi.RouteContext(r.Context()); rctx != nil {
+				route = rctx.RoutePattern()
+			}
+
+			attrs := []slog.Attr{
+				slog.String("method", r.Method),
+				slog.String("route", route),
+				slog.Int("status", status),
+				slog.Int("bytes", ww.BytesWritten()),
+				slog.Duration("latency", time.Since(start)),
+				slog.String("req_id", middleware.GetReqID(r.Context())),
+				slog.String("remote_ip", remoteIP(r)),
+				sl.Trace(r.Context()),
+			}
+			if route == "" {
+				// Unmatched, the path shows what was requested
+				attrs = append(attrs, slog.String("path", r.URL.Path))
+			}
+			// The client is known by the digest of its API key, as the rate
+			// limiter counts it
+			if key := apikey.Digest(r); key != "" {
+				attrs = append(attrs, slog.String("principal", "key:"+key))
+			}
+
+			l.log.LogAttrs(r.Context(), level, "request served", attrs...)
+		}()
+
+		next.ServeHTTP(ww, r)
+	}
+
+	return http.HandlerFunc(fn)
+}
+
+// remoteIP returns the client address resolved by realip, which
+// keeps the port of the connection if the request came from no trusted proxy.
+func remoteIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
@@ -0,0 +1,38 @@
+package accesslog_test
+
+import (
+	"bytes"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/Alhanaqtah/effective-mobile-test-task/internal/controller/accesslog"
+	"github.com/Alhanaqtah/effective-mobile-test-task/internal/lib/apikey"
+)
+
+// TestPrincipal checks that requests with an API key are logged with the
+// digest of the key, never the key itself.
+func TestPrincipal(t *testing.T) {
+	var buf bytes.Buffer
+	l := accesslog.New(slog.New(slog.NewTextHandler(&buf, nil)), 1)
+	h := l.Handler(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}))
+
+	r := httptest.NewRequest(http.MethodGet, "/api/v1/users", nil)
+	r.Header.Set("Authorization", "Bearer secret")
+	h.ServeHTTP(httptest.NewRecorder(), r)
+
+	if want := "principal=key:" + apikey.Digest(r); !strings.Contains(buf.String(), want) {
+		t.Errorf("log %q does not contain %q", buf.String(), want)
+	}
+	if strings.Contains(buf.String(), "secret") {
+		t.Errorf("log %q contains the key", buf.String())
+	}
+
+	buf.Reset()
+	h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/api/v1/users", nil))
+	if strings.Contains(buf.String(), "principal") {
+		t.Errorf("log %q has a principal without a key", buf.String())
+	}
+}
@@ -0,0 +1,266 @@
+package apierror
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/Alhanaqtah/effective-mobile-test-task/internal/lib/i18n"
+	"github.com/Alhanaqtah/effective-mobile-test-task/internal/lib/problem"
+	"github.com/Alhanaqtah/effective-mobile-test-task/internal/lib/validation"
+	"github.com/Alhanaqtah/effective-mobile-test-task/internal/repository/externalapi"
+	"github.com/Alhanaqtah/effective-mobile-test-task/internal/service/datasync"
+	"github.com/Alhanaqtah/effective-mobile-test-task/internal/service/report"
+	taskService "github.com/Alhanaqtah/effective-mobile-test-task/internal/service/task"
+	userService "github.com/Alhanaqtah/effective-mobile-test-task/internal/service/user"
+	"github.com/Alhanaqtah/effective-mobile-test-task/internal/service/userimport"
+	"github.com/Alhanaqtah/effective-mobile-test-task/internal/service/webhook"
+
+	"github.com/go-chi/chi/middleware"
+)
+
+// Errors raised by the HTTP layer itself rather than by the services.
+var (
+	ErrIdempotencyKeyReused     = errors.New("idempotency key is reused with a different request")
+	ErrIdempotencyKeyInProgress = errors.New("request with the same idempotency key is in progress")
+	ErrUnsupportedMediaType     = errors.New("unsupported media type")
+	ErrBodyTooLarge             = errors.New("request body is too large")
+	ErrPatchFailed              = errors.New("patch cannot be applied")
+	ErrUnknownMessage           = errors.New("unknown message type")
+	ErrTooManyWatched           = errors.New("too many users to watch")
+	ErrInvalidCursor            = errors.New("invalid cursor")
+	ErrQueryTooDeep             = errors.New("query is too deep")
+	ErrQueryTooComplex          = errors.New("query is too complex")
+	ErrRateLimited              = errors.New("rate limit exceeded")
+)
+
+// Stable machine-readable error codes. Clients match on these, so existing
+// values must never change.
+const (
+	CodeInternal         = "internal_error"
+	CodeValidationFailed = "validation_failed"
+	CodeRequired         = "required"
+	CodeInvalid          = "invalid"
+	CodeMalformedBody    = "malformed_body"
+	CodeTooLong          = "too_long"
+	CodeUnknownField     = "unknown_field"
+
+	CodeUnsupportedMediaType = "unsupported_media_type"
+	CodeBodyTooLarge         = "body_too_large"
+	CodePatchFailed          = "patch_failed"
+	CodeUnknownMessage       = "unknown_message"
+	CodeTooManyWatched       = "too_many_watched"
+	CodeInvalidCursor        = "invalid_cursor"
+	CodeQueryTooDeep         = "query_too_deep"
+	CodeQueryTooComplex      = "query_too_complex"
+	CodeInvalidPageSize      = "invalid_page_size"
+	CodeRateLimited          = "rate_limited"
+
+	CodeIdempotencyKeyReused     = "idempotency_key_reused"
+	CodeIdempotencyKeyInProgress = "idempotency_key_in_progress"
+
+	CodeUserNotFound    = "user_not_found"
+	CodeUserExists      = "user_exists"
+	CodeEmptyBody       = "empty_body"
+	CodeInvalidPassport = "invalid_passport"
+	CodeVersionMismatch = "version_mismatch"
+	CodePassportInvalid = "passport_rejected"
+	CodePeopleInfoError = "people_info_unavailable"
+
+	CodeTaskNotFound     = "task_not_found"
+	CodeInvalidUUID      = "invalid_uuid"
+	CodeInvalidDate      = "invalid_date"
+	CodeInvalidDateRange = "invalid_date_range"
+	CodeEmptyQuery       = "empty_query"
+	CodeEmptyScope       = "empty_scope"
+	CodeTaskExists       = "task_exists"
+	CodeFutureTime       = "future_time"
+	CodeEmptyBatch       = "empty_batch"
+	CodeBatchTooLarge    = "batch_too_large"
+	CodeUnknownOperation = "unknown_operation"
+	CodeUnknownTaskRef   = "unknown_task_ref"
+
+	CodeInvalidSyncToken  = "invalid_sync_token"
+	CodeEmptyPush         = "empty_push"
+	CodePushTooLarge      = "push_too_large"
+	CodeUnknownEntity     = "unknown_entity"
+	CodeOfflineUserCreate = "offline_user_create"
+
+	CodeImportJobNotFound = "import_job_not_found"
+	CodeEmptyImport       = "empty_import"
+	CodeImportTooLarge    = "import_too_large"
+	CodeMalformedImport   = "malformed_import"
+
+	CodeWebhookNotFound  = "webhook_not_found"
+	CodeDeliveryNotFound = "delivery_not_found"
+	CodeInvalidURL       = "invalid_url"
+	CodeInternalURL      = "internal_url"
+	CodeUnknownEventType = "unknown_event_type"
+)
+
+// Entry describes how an error is presented to API clients. Human-readable
+// texts are looked up by Code in the message catalog.
+type Entry struct {
+	Status int
+	Code   string
+}
+
+var internal = Entry{http.StatusInternalServerError, CodeInternal}
+
+// catalog maps errors returned by the service layer to their presentation.
+// Entries are matched with errors.Is in order, so wrapped errors resolve too.
+var catalog = []struct {
+	err   error
+	entry Entry
+}{
+	{validation.ErrRequired, Entry{http.StatusBadRequest, CodeRequired}},
+	{validation.ErrInvalid, Entry{http.StatusBadRequest, CodeInvalid}},
+	{validation.ErrMalformedBody, Entry{http.StatusBadRequest, CodeMalformedBody}},
+	{validation.ErrTooLong, Entry{http.StatusBadRequest, CodeTooLong}},
+	{validation.ErrUnknownField, Entry{http.StatusBadRequest, CodeUnknownField}},
+	{ErrUnsupportedMediaType, Entry{http.StatusUnsupportedMediaType, CodeUnsupportedMediaType}},
+	{ErrBodyTooLarge, Entry{http.StatusRequestEntityTooLarge, CodeBodyTooLarge}},
+	{ErrPatchFailed, Entry{http.StatusUnprocessableEntity, CodePatchFailed}},
+	{ErrUnknownMessage, Entry{http.StatusBadRequest, CodeUnknownMessage}},
+	{ErrTooManyWatched, Entry{http.StatusBadRequest, CodeTooManyWatched}},
+	{ErrInvalidCursor, Entry{http.StatusBadRequest, CodeInvalidCursor}},
+	{ErrQueryTooDeep, Entry{http.StatusBadRequest, CodeQueryTooDeep}},
+	{ErrQueryTooComplex, Entry{http.StatusBadRequest, CodeQueryTooComplex}},
+	{ErrRateLimited, Entry{http.StatusTooManyRequests, CodeRateLimited}},
+	{ErrIdempotencyKeyReused, Entry{http.StatusUnprocessableEntity, CodeIdempotencyKeyReused}},
+	{ErrIdempotencyKeyInProgress, Entry{http.StatusConflict, CodeIdempotencyKeyInProgress}},
+
+	{userService.ErrUserNotFound, Entry{http.StatusNotFound, CodeUserNotFound}},
+	{userService.ErrExists, Entry{http.StatusConflict, CodeUserExists}},
+	{userService.ErrEmptyBody, Entry{http.StatusBadRequest, CodeEmptyBody}},
+	{userService.ErrInvalidPassport, Entry{http.StatusBadRequest, CodeInvalidPassport}},
+	{userService.ErrInvalidUUID, Entry{http.StatusBadRequest, CodeInvalidUUID}},
+	{userService.ErrVersionMismatch, Entry{http.StatusPreconditionFailed, CodeVersionMismatch}},
+	{externalapi.ErrBadRequest, Entry{http.StatusUnprocessableEntity, CodePassportInvalid}},
+	{externalapi.ErrExternalAPIError, Entry{http.StatusBadGateway, CodePeopleInfoError}},
+
+	{taskService.ErrTaskNotFound, Entry{http.StatusNotFound, CodeTaskNotFound}},
+	{taskService.ErrVersionMismatch, Entry{http.StatusPreconditionFailed, CodeVersionMismatch}},
+	{taskService.ErrInvalidUUID, Entry{http.StatusBadRequest, CodeInvalidUUID}},
+	{taskService.ErrInvalidDate, Entry{http.StatusBadRequest, CodeInvalidDate}},
+	{taskService.ErrInvalidDateRange, Entry{http.StatusBadRequest, CodeInvalidDateRange}},
+	{taskService.ErrEmptyQuery, Entry{http.StatusBadRequest, CodeEmptyQuery}},
+	{taskService.ErrEmptyScope, Entry{http.StatusBadRequest, CodeEmptyScope}},
+	{taskService.ErrTaskExists, Entry{http.StatusConflict, CodeTaskExists}},
+	{taskService.ErrUserNotFound, Entry{http.StatusNotFound, CodeUserNotFound}},
+	{taskService.ErrEmptyUpdate, Entry{http.StatusBadRequest, CodeEmptyBody}},
+	{taskService.ErrFutureTime, Entry{http.StatusBadRequest, CodeFutureTime}},
+	{taskService.ErrEmptyBatch, Entry{http.StatusBadRequest, CodeEmptyBatch}},
+	{taskService.ErrBatchTooLarge, Entry{http.StatusRequestEntityTooLarge, CodeBatchTooLarge}},
+	{taskService.ErrUnknownOperation, Entry{http.StatusBadRequest, CodeUnknownOperation}},
+	{taskService.ErrUnknownRef, Entry{http.StatusBadRequest, CodeUnknownTaskRef}},
+
+	{datasync.ErrInvalidToken, Entry{http.StatusBadRequest, CodeInvalidSyncToken}},
+	{datasync.ErrEmptyPush, Entry{http.StatusBadRequest, CodeEmptyPush}},
+	{datasync.ErrPushTooLarge, Entry{http.StatusRequestEntityTooLarge, CodePushTooLarge}},
+	{datasync.ErrUnknownEntity, Entry{http.StatusBadRequest, CodeUnknownEntity}},
+	{datasync.ErrUnknownUserOp, Entry{http.StatusBadRequest, CodeUnknownOperation}},
+	{datasync.ErrOfflineUserCreate, Entry{http.StatusBadRequest, CodeOfflineUserCreate}},
+
+	{userimport.ErrJobNotFound, Entry{http.StatusNotFound, CodeImportJobNotFound}},
+	{userimport.ErrInvalidJobID, Entry{http.StatusBadRequest, CodeInvalidUUID}},
+	{userimport.ErrEmptyImport, Entry{http.StatusBadRequest, CodeEmptyImport}},
+	{userimport.ErrTooManyRows, Entry{http.StatusRequestEntityTooLarge, CodeImportTooLarge}},
+	{userimport.ErrMalformedInput, Entry{http.StatusBadRequest, CodeMalformedImport}},
+	{userimport.ErrUnsupportedFormat, Entry{http.StatusUnsupportedMediaType, CodeUnsupportedMediaType}},
+
+	{report.ErrInvalidID, Entry{http.StatusBadRequest, CodeInvalidUUID}},
+	{report.ErrInvalidPageSize, Entry{http.StatusBadRequest, CodeInvalidPageSize}},
+	{report.ErrInvalidDateRange, Entry{http.StatusBadRequest, CodeInvalidDateRange}},
+	{report.ErrTaskNotFound, Entry{http.StatusNotFound, CodeTaskNotFound}},
+
+	{webhook.ErrWebhookNotFound, Entry{http.StatusNotFound, CodeWebhookNotFound}},
+	{webhook.ErrDeliveryNotFound, Entry{http.StatusNotFound, CodeDeliveryNotFound}},
+	{webhook.ErrInvalidURL, Entry{http.StatusBadRequest, CodeInvalidURL}},
+	{webhook.ErrInternalURL, Entry{http.StatusBadRequest, CodeInternalURL}},
+	{webhook.ErrUnknownEvent, Entry{http.StatusBadRequest, CodeUnknownEventType}},
+}
+
+// Lookup returns the catalog entry for err, falling back to the internal error.
+func Lookup(err error) Entry {
+	for _, c := range catalog {
+		if errors.Is(err, c.err) {
+			return c.entry
+		}
+	}
+	return internal
+}
+
+// Match reports whether err is presented to clients with code, so a client
+// that received code can report it as err.
+func Match(err error, code string) bool {
+	for _, c := range catalog {
+		if c.entry.Code == code && errors.Is(err, c.err) {
+			return true
+		}
+	}
+	return false
+}
+
+// Resolve returns the entry err is presented with and the field errors it
+// carries. Field errors make a validation_failed error only if each of them
+// is a validation error itself; otherwise, e.g. for a field naming a missing
+// resource, the first field error that is not is presented by its own entry.
+func Resolve(err error) (Entry, []*validation.FieldError) {
+	fields := validation.Fields(err)
+	if len(fields) == 0 {
+		return Lookup(err), nil
+	}
+
+	for _, fe := range fields {
+		if entry := Lookup(fe.Err); entry.Status != http.StatusBadRequest {
+			return entry, nil
+		}
+	}
+
+	return Entry{http.StatusBadRequest, CodeValidationFailed}, fields
+}
+
+// Problem builds the problem document for err in the language negotiated for
+// the request. Field validation errors are collected into a single
+// validation_failed problem with per-field details.
+func Problem(r *http.Request, err error) problem.Problem {
+	lang := i18n.FromContext(r.Context())
+
+	entry, fields := Resolve(err)
+	if len(fields) > 0 {
+		p := newProblem(r, lang, entry)
+
+		for _, fe := range fields {
+			entry := Lookup(fe.Err)
+			p.Errors = append(p.Errors, problem.FieldError{
+				Field:  fe.Field,
+				In:     fe.In,
+				Code:   entry.Code,
+				Detail: message(lang, entry.Code).Detail,
+			})
+		}
+
+		return p
+	}
+
+	return newProblem(r, lang, entry)
+}
+
+// Write sends err to the client as an application/problem+json response.
+func Write(w http.ResponseWriter, r *http.Request, err error) {
+	problem.Write(w, Problem(r, err))
+}
+
+func newProblem(r *http.Request, lang i18n.Lang, entry Entry) problem.Problem {
+	msg := message(lang, entry.Code)
+
+	return problem.Problem{
+		Type:      problem.TypeURI(entry.Code),
+		Title:     msg.Title,
+		Status:    entry.Status,
+		Detail:    msg.Detail,
+		Instance:  r.URL.Path,
+		Code:      entry.Code,
+		RequestID: middleware.GetReqID(r.Context()),
+	}
+}
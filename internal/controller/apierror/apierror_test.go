@@ -0,0 +1,80 @@
+package apierror_test
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Alhanaqtah/effective-mobile-test-task/internal/controller/apierror"
+	"github.com/Alhanaqtah/effective-mobile-test-task/internal/lib/i18n"
+	"github.com/Alhanaqtah/effective-mobile-test-task/internal/lib/validation"
+	taskService "github.com/Alhanaqtah/effective-mobile-test-task/internal/service/task"
+)
+
+func TestProblem(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+		wantCode   string
+		wantFields []string
+	}{
+		{
+			name:       "sentinel",
+			err:        fmt.Errorf("op: %w", taskService.ErrTaskNotFound),
+			wantStatus: http.StatusNotFound,
+			wantCode:   apierror.CodeTaskNotFound,
+		},
+		{
+			name:       "validation errors",
+			err:        validation.Errors{validation.For("title", validation.ErrRequired), validation.For("user_id", taskService.ErrInvalidUUID)},
+			wantStatus: http.StatusBadRequest,
+			wantCode:   apierror.CodeValidationFailed,
+			wantFields: []string{"title", "user_id"},
+		},
+		{
+			name:       "field naming a missing resource",
+			err:        fmt.Errorf("op: %w", validation.For("user_id", taskService.ErrUserNotFound)),
+			wantStatus: http.StatusNotFound,
+			wantCode:   apierror.CodeUserNotFound,
+		},
+		{
+			name:       "unknown error",
+			err:        errors.New("boom"),
+			wantStatus: http.StatusInternalServerError,
+			wantCode:   apierror.CodeInternal,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := apierror.Problem(httptest.NewRequest(http.MethodGet, "/", nil), tt.err)
+
+			if p.Status != tt.wantStatus || p.Code != tt.wantCode {
+				t.Errorf("Problem() = %d %s, want %d %s", p.Status, p.Code, tt.wantStatus, tt.wantCode)
+			}
+
+			var fields []string
+			for _, fe := range p.Errors {
+				fields = append(fields, fe.Field)
+			}
+			if fmt.Sprint(fields) != fmt.Sprint(tt.wantFields) {
+				t.Errorf("Problem() fields = %v, want %v", fields, tt.wantFields)
+			}
+		})
+	}
+}
+
+func TestProblemDefaultLanguage(t *testing.T) {
+	i18n.SetDefault(i18n.RU)
+	defer i18n.SetDefault(i18n.EN)
+
+	p := apierror.Problem(httptest.NewRequest(http.MethodGet, "/", nil), taskService.ErrTaskNotFound)
+
+	want := apierror.Translate(i18n.RU, apierror.CodeTaskNotFound)
+	if p.Title != want.Title {
+		t.Errorf("Problem() title = %q, want %q", p.Title, want.Title)
+	}
+}
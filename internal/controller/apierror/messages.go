@@ -0,0 +1,242 @@
+package apierror
+
+import "github.com/Alhanaqtah/effective-mobile-test-task/internal/lib/i18n"
+
+// Message - локализованный текст ошибки
+type Message struct {
+	Title  string
+	Detail string
+}
+
+// messages holds the translations of every error code. Each code must have
+// an English message; other languages fall back to it when missing.
+var messages = map[string]map[i18n.Lang]Message{
+	CodeInternal: {
+		i18n.EN: {"Internal error", "The server failed to process the request."},
+		i18n.RU: {"Внутренняя ошибка", "Сервер не смог обработать запрос."},
+	},
+	CodeValidationFailed: {
+		i18n.EN: {"Validation failed", "One or more request fields are invalid."},
+		i18n.RU: {"Ошибка валидации", "Одно или несколько полей запроса заполнены неверно."},
+	},
+	CodeRequired: {
+		i18n.EN: {"Value is required", "The value is required."},
+		i18n.RU: {"Значение обязательно", "Значение обязательно для заполнения."},
+	},
+	CodeInvalid: {
+		i18n.EN: {"Invalid value", "The value has an invalid format."},
+		i18n.RU: {"Некорректное значение", "Значение имеет неверный формат."},
+	},
+	CodeMalformedBody: {
+		i18n.EN: {"Malformed request body", "The request body is not valid JSON."},
+		i18n.RU: {"Некорректное тело запроса", "Тело запроса не является корректным JSON."},
+	},
+	CodeTooLong: {
+		i18n.EN: {"Value is too long", "The value exceeds the maximum length."},
+		i18n.RU: {"Слишком длинное значение", "Значение превышает максимальную длину."},
+	},
+	CodeUnknownField: {
+		i18n.EN: {"Unknown field", "The field is unknown or cannot be changed."},
+		i18n.RU: {"Неизвестное поле", "Поле неизвестно или не может быть изменено."},
+	},
+	CodeUnsupportedMediaType: {
+		i18n.EN: {"Unsupported media type", "The request body media type is not supported by this endpoint."},
+		i18n.RU: {"Неподдерживаемый тип содержимого", "Тип содержимого тела запроса не поддерживается этим методом."},
+	},
+	CodeBodyTooLarge: {
+		i18n.EN: {"Request body too large", "The request body exceeds the maximum size."},
+		i18n.RU: {"Слишком большое тело запроса", "Тело запроса превышает максимальный размер."},
+	},
+	CodePatchFailed: {
+		i18n.EN: {"Patch cannot be applied", "The patch cannot be applied to the current state of the resource."},
+		i18n.RU: {"Патч не может быть применён", "Патч не может быть применён к текущему состоянию ресурса."},
+	},
+	CodeUnknownMessage: {
+		i18n.EN: {"Unknown message", "The message type must be subscribe or unsubscribe."},
+		i18n.RU: {"Неизвестное сообщение", "Тип сообщения должен быть subscribe или unsubscribe."},
+	},
+	CodeTooManyWatched: {
+		i18n.EN: {"Too many users", "A board can watch at most 100 users."},
+		i18n.RU: {"Слишком много пользователей", "Доска может отслеживать не более 100 пользователей."},
+	},
+	CodeIdempotencyKeyReused: {
+		i18n.EN: {"Idempotency key reused", "The Idempotency-Key was already used for a different request."},
+		i18n.RU: {"Ключ идемпотентности уже использован", "Idempotency-Key уже использован для другого запроса."},
+	},
+	CodeIdempotencyKeyInProgress: {
+		i18n.EN: {"Request in progress", "A request with the same Idempotency-Key is still being processed, retry later."},
+		i18n.RU: {"Запрос обрабатывается", "Запрос с таким же Idempotency-Key ещё обрабатывается, повторите позже."},
+	},
+
+	CodeUserNotFound: {
+		i18n.EN: {"User not found", "The user does not exist."},
+		i18n.RU: {"Пользователь не найден", "Пользователь не существует."},
+	},
+	CodeUserExists: {
+		i18n.EN: {"User already exists", "A user with this passport already exists."},
+		i18n.RU: {"Пользователь уже существует", "Пользователь с такими паспортными данными уже существует."},
+	},
+	CodeEmptyBody: {
+		i18n.EN: {"Request body is empty", "The request body contains no fields to process."},
+		i18n.RU: {"Пустое тело запроса", "Тело запроса не содержит полей для обработки."},
+	},
+	CodeInvalidPassport: {
+		i18n.EN: {"Invalid passport", `The passport must be given as "<serie> <number>", e.g. "1234 567890".`},
+		i18n.RU: {"Некорректный паспорт", `Паспорт должен быть указан в формате "<серия> <номер>", например "1234 567890".`},
+	},
+	CodeVersionMismatch: {
+		i18n.EN: {"Version mismatch", "The resource was modified since the version given in If-Match."},
+		i18n.RU: {"Версия не совпадает", "Ресурс был изменён после версии, указанной в If-Match."},
+	},
+	CodePassportInvalid: {
+		i18n.EN: {"Passport rejected", "The people info service rejected the passport data."},
+		i18n.RU: {"Паспортные данные отклонены", "Сервис информации о людях отклонил паспортные данные."},
+	},
+	CodePeopleInfoError: {
+		i18n.EN: {"People info service unavailable", "The people info service failed to respond."},
+		i18n.RU: {"Сервис информации о людях недоступен", "Сервис информации о людях не ответил на запрос."},
+	},
+
+	CodeTaskNotFound: {
+		i18n.EN: {"Task not found", "The task does not exist."},
+		i18n.RU: {"Задача не найдена", "Задача не существует."},
+	},
+	CodeInvalidUUID: {
+		i18n.EN: {"Invalid UUID", "The value is not a valid UUID."},
+		i18n.RU: {"Некорректный UUID", "Значение не является корректным UUID."},
+	},
+	CodeInvalidDate: {
+		i18n.EN: {"Invalid date", "The value is not an RFC 3339 date."},
+		i18n.RU: {"Некорректная дата", "Значение не является датой в формате RFC 3339."},
+	},
+	CodeInvalidDateRange: {
+		i18n.EN: {"Invalid date range", "The start date is after the end date."},
+		i18n.RU: {"Некорректный диапазон дат", "Дата начала позже даты окончания."},
+	},
+	CodeEmptyQuery: {
+		i18n.EN: {"Search query is empty", "The search query contains no words."},
+		i18n.RU: {"Пустой поисковый запрос", "Поисковый запрос не содержит слов."},
+	},
+	CodeEmptyScope: {
+		i18n.EN: {"No users to search in", "At least one user must be given to search in."},
+		i18n.RU: {"Не указаны пользователи", "Необходимо указать хотя бы одного пользователя для поиска."},
+	},
+	CodeTaskExists: {
+		i18n.EN: {"Task already exists", "A task with the same title already exists."},
+		i18n.RU: {"Задача уже существует", "Задача с таким заголовком уже существует."},
+	},
+	CodeFutureTime: {
+		i18n.EN: {"Time in the future", "The timestamp is ahead of the server clock."},
+		i18n.RU: {"Время в будущем", "Указанное время опережает время сервера."},
+	},
+	CodeEmptyBatch: {
+		i18n.EN: {"Empty batch", "The batch contains no operations."},
+		i18n.RU: {"Пустой пакет", "Пакет не содержит ни одной операции."},
+	},
+	CodeBatchTooLarge: {
+		i18n.EN: {"Batch too large", "The batch contains more operations than allowed."},
+		i18n.RU: {"Слишком большой пакет", "Пакет содержит больше операций, чем допустимо."},
+	},
+	CodeUnknownOperation: {
+		i18n.EN: {"Unknown operation", "The operation must be one of create, update, start, finish or delete."},
+		i18n.RU: {"Неизвестная операция", "Операция должна быть одной из create, update, start, finish или delete."},
+	},
+	CodeUnknownTaskRef: {
+		i18n.EN: {"Unknown task reference", "No task was created earlier in the batch with this reference."},
+		i18n.RU: {"Неизвестная ссылка на задачу", "Ранее в пакете не создавалась задача с такой ссылкой."},
+	},
+	CodeInvalidSyncToken: {
+		i18n.EN: {"Invalid sync token", "The sync token is malformed. Omit it to get a full snapshot."},
+		i18n.RU: {"Некорректный токен синхронизации", "Токен синхронизации повреждён. Чтобы получить полный снимок данных, не передавайте токен."},
+	},
+	CodeEmptyPush: {
+		i18n.EN: {"Empty push", "The push contains no changes."},
+		i18n.RU: {"Нет изменений", "Запрос не содержит ни одного изменения."},
+	},
+	CodePushTooLarge: {
+		i18n.EN: {"Push too large", "The push contains more changes than allowed."},
+		i18n.RU: {"Слишком много изменений", "Запрос содержит больше изменений, чем допустимо."},
+	},
+	CodeUnknownEntity: {
+		i18n.EN: {"Unknown entity", "The entity must be user or task."},
+		i18n.RU: {"Неизвестная сущность", "Сущность должна быть user или task."},
+	},
+	CodeOfflineUserCreate: {
+		i18n.EN: {"User creation is online only", "Users are created with POST /users, which needs the people info service."},
+		i18n.RU: {"Пользователя можно создать только онлайн", "Пользователи создаются через POST /users, которому нужен сервис информации о людях."},
+	},
+	CodeImportJobNotFound: {
+		i18n.EN: {"Import job not found", "No import job exists with the given id."},
+		i18n.RU: {"Задача импорта не найдена", "Задача импорта с указанным идентификатором не существует."},
+	},
+	CodeEmptyImport: {
+		i18n.EN: {"Empty import", "The import contains no passports."},
+		i18n.RU: {"Пустой импорт", "Импорт не содержит ни одного паспорта."},
+	},
+	CodeImportTooLarge: {
+		i18n.EN: {"Import too large", "The import contains more passports than allowed in one job."},
+		i18n.RU: {"Слишком большой импорт", "Импорт содержит больше паспортов, чем допустимо в одной задаче."},
+	},
+	CodeMalformedImport: {
+		i18n.EN: {"Malformed import", "The import file cannot be parsed."},
+		i18n.RU: {"Некорректный файл импорта", "Не удалось разобрать файл импорта."},
+	},
+	CodeInvalidCursor: {
+		i18n.EN: {"Invalid cursor", "The cursor is not one returned by a previous page."},
+		i18n.RU: {"Некорректный курсор", "Курсор не был получен с предыдущей страницы."},
+	},
+	CodeQueryTooDeep: {
+		i18n.EN: {"Query too deep", "The query nests fields deeper than allowed."},
+		i18n.RU: {"Слишком глубокий запрос", "Вложенность полей запроса превышает допустимую."},
+	},
+	CodeQueryTooComplex: {
+		i18n.EN: {"Query too complex", "The query may return more data than allowed. Request fewer fields or smaller pages."},
+		i18n.RU: {"Слишком сложный запрос", "Запрос может вернуть больше данных, чем допустимо. Запросите меньше полей или страницы меньшего размера."},
+	},
+	CodeRateLimited: {
+		i18n.EN: {"Too many requests", "The rate limit of this kind of request is exceeded. Retry after the number of seconds in the Retry-After header."},
+		i18n.RU: {"Слишком много запросов", "Превышено ограничение частоты запросов этого вида. Повторите запрос через число секунд из заголовка Retry-After."},
+	},
+	CodeInvalidPageSize: {
+		i18n.EN: {"Invalid page size", "The page size must be between 0 and 100."},
+		i18n.RU: {"Некорректный размер страницы", "Размер страницы должен быть от 0 до 100."},
+	},
+	CodeWebhookNotFound: {
+		i18n.EN: {"Webhook not found", "No webhook exists with the given id."},
+		i18n.RU: {"Вебхук не найден", "Вебхук с указанным идентификатором не существует."},
+	},
+	CodeDeliveryNotFound: {
+		i18n.EN: {"Delivery not found", "The webhook has no delivery with the given id."},
+		i18n.RU: {"Доставка не найдена", "У вебхука нет доставки с указанным идентификатором."},
+	},
+	CodeInvalidURL: {
+		i18n.EN: {"Invalid URL", "The value must be an absolute http or https URL."},
+		i18n.RU: {"Некорректный URL", "Значение должно быть абсолютным URL со схемой http или https."},
+	},
+	CodeInternalURL: {
+		i18n.EN: {"Internal URL", "The URL must not point to a loopback, private or link-local address."},
+		i18n.RU: {"Внутренний URL", "URL не должен указывать на локальный, частный или link-local адрес."},
+	},
+	CodeUnknownEventType: {
+		i18n.EN: {"Unknown event type", "The event type is not supported."},
+		i18n.RU: {"Неизвестный тип события", "Указанный тип события не поддерживается."},
+	},
+}
+
+// Translate returns the message for code in lang, for transports that do
+// not use problem documents.
+func Translate(lang i18n.Lang, code string) Message {
+	return message(lang, code)
+}
+
+func message(lang i18n.Lang, code string) Message {
+	translations, ok := messages[code]
+	if !ok {
+		translations = messages[CodeInternal]
+	}
+
+	if msg, ok := translations[lang]; ok {
+		return msg
+	}
+	return translations[i18n.EN]
+}
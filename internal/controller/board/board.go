@@ -0,0 +1,317 @@
+package board
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/Alhanaqtah/effective-mobile-test-task/internal/controller/apierror"
+	"github.com/Alhanaqtah/effective-mobile-test-task/internal/events"
+	"github.com/Alhanaqtah/effective-mobile-test-task/internal/lib/logger/sl"
+	"github.com/Alhanaqtah/effective-mobile-test-task/internal/lib/problem"
+	"github.com/Alhanaqtah/effective-mobile-test-task/internal/lib/validation"
+	"github.com/Alhanaqtah/effective-mobile-test-task/internal/models"
+	"github.com/Alhanaqtah/effective-mobile-test-task/internal/timers"
+
+	"github.com/go-chi/chi/middleware"
+	"github.com/go-chi/chi/v5"
+	"github.com/gorilla/websocket"
+)
+
+const (
+	// tickInterval is how often the board is pushed even without changes.
+	tickInterval = time.Second
+
+	writeWait      = 10 * time.Second
+	pongWait       = 60 * time.Second
+	pingInterval   = pongWait * 9 / 10
+	maxMessageSize = 4096
+
+	// maxWatched limits the number of users one connection can watch.
+	maxWatched = 100
+)
+
+// Client message types.
+const (
+	messageSubscribe   = "subscribe"
+	messageUnsubscribe = "unsubscribe"
+)
+
+// Server message types.
+const (
+	messageBoard = "board"
+	messageError = "error"
+)
+
+type TaskService interface {
+	RunningTasks(ctx context.Context, userUUIDs []string) (map[string]models.Task, error)
+}
+
+type Broker interface {
+	Subscribe(lastEventID string, filter events.Filter) (*events.Subscription, []models.Event, bool)
+}
+
+// ClientMessage is sent by the client to change the set of watched users.
+type ClientMessage struct {
+	Type    string   `json:"type"`     // subscribe or unsubscribe
+	UserIDs []string `json:"user_ids"` // Users to add to or remove from the board
+}
+
+// ServerMessage is pushed to the client every tick and on every change.
+type ServerMessage struct {
+	Type  string           `json:"type"`            // board or error
+	Time  time.Time        `json:"time"`            // Server time the board was built at
+	Users []UserState      `json:"users,omitempty"` // Watched users ordered by id
+	Error *problem.Problem `json:"error,omitempty"` // Why the last client message was rejected
+}
+
+// UserState is what a single user is working on.
+type UserState struct {
+	UserID  string       `json:"user_id"`
+	Task    *models.Task `json:"task"`    // Running task, null if the user is idle
+	Elapsed float64      `json:"elapsed"` // Seconds since the task was started
+}
+
+type Handler struct {
+	tasks    TaskService
+	broker   Broker
+	upgrader websocket.Upgrader
+	// ctx is cancelled on shutdown to close boards, whose hijacked
+	// connections a graceful shutdown of the server does not track.
+	ctx    context.Context
+	cancel context.CancelFunc
+	log    *slog.Logger
+}
+
+func New(tasks TaskService, broker Broker, log *slog.Logger) *Handler {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	return &Handler{
+		tasks:  tasks,
+		broker: broker,
+		ctx:    ctx,
+		cancel: cancel,
+		log:    log,
+	}
+}
+
+// Shutdown closes the open boards. It is meant to be registered with
+// http.Server.RegisterOnShutdown; clients reconnect to another instance.
+func (h *Handler) Shutdown() {
+	h.cancel()
+}
+
+func (h *Handler) Register() func(r chi.Router) {
+	return func(r chi.Router) {
+		r.Get("/", h.serve)
+	}
+}
+
+// @Summary Доска "кто над чем работает"
+// @Description WebSocket-соединение. Клиент отправляет сообщения {"type": "subscribe", "user_ids": [...]} и {"type": "unsubscribe", "user_ids": [...]}, чтобы выбрать пользователей. Сервер раз в секунду и при каждом запуске, завершении или изменении задачи присылает сообщение {"type": "board"} с текущей задачей каждого пользователя и прошедшим временем в секундах. Отклонённые сообщения клиента приводят к сообщению {"type": "error"} с описанием ошибки
+// @Tags events
+// @Success 101 "Соединение переключено на протокол WebSocket"
+// @Failure 400 {object} problem.Problem "Запрос не является запросом WebSocket"
+// @Router /board [get]
+func (h *Handler) serve(w http.ResponseWriter, r *http.Request) {
+	const op = "controller.board.serve"
+
+	log := h.log.With(
+		slog.String("op", op),
+		slog.String("req_id", middleware.GetReqID(r.Context())),
+		sl.Trace(r.Context()),
+	)
+
+	conn, err := h.upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		// The upgrader has already replied to the client.
+		log.Error("failed to upgrade connection", sl.Error(err))
+		return
+	}
+	defer conn.Close()
+
+	log.Debug("board connected")
+
+	b := &board{
+		Handler: h,
+		r:       r,
+		conn:    conn,
+		log:     log,
+		timers:  timers.New(h.tasks),
+	}
+	b.run()
+
+	log.Debug("board disconnected")
+}
+
+// board is the state of a single connection.
+type board struct {
+	*Handler
+
+	r    *http.Request
+	conn *websocket.Conn
+	log  *slog.Logger
+
+	timers *timers.Set
+}
+
+func (b *board) run() {
+	ctx, cancel := context.WithCancel(b.r.Context())
+	defer cancel()
+
+	messages := make(chan incoming)
+	go b.read(ctx, cancel, messages)
+
+	sub, _, _ := b.broker.Subscribe("", events.Filter{Types: timers.Events})
+	defer func() { sub.Close() }()
+
+	tick := time.NewTicker(tickInterval)
+	defer tick.Stop()
+
+	ping := time.NewTicker(pingInterval)
+	defer ping.Stop()
+
+	for {
+		var err error
+
+		select {
+		case <-ctx.Done():
+			return
+
+		case <-b.Handler.ctx.Done():
+			b.log.Debug("server is shutting down, closing board")
+			msg := websocket.FormatCloseMessage(websocket.CloseGoingAway, "server is shutting down")
+			b.conn.WriteControl(websocket.CloseMessage, msg, time.Now().Add(writeWait))
+			return
+
+		case in := <-messages:
+			err = b.handle(ctx, in)
+
+		case event, ok := <-sub.C:
+			if !ok {
+				// Dropped for being too slow: catch up from the database.
+				sub, _, _ = b.broker.Subscribe("", events.Filter{Types: timers.Events})
+				if err = b.timers.RefreshAll(ctx); err == nil {
+					err = b.send(b.state())
+				}
+				break
+			}
+			if !b.timers.Watches(event.UserID) {
+				continue
+			}
+			// Push the change right away rather than on the next tick
+			if err = b.timers.Refresh(ctx, []string{event.UserID}); err == nil {
+				err = b.send(b.state())
+			}
+
+		case <-tick.C:
+			err = b.send(b.state())
+
+		case <-ping.C:
+			err = b.conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(writeWait))
+		}
+
+		if err != nil {
+			b.log.Debug("closing board", sl.Error(err))
+			return
+		}
+	}
+}
+
+// incoming is a client message or the reason it could not be decoded.
+type incoming struct {
+	msg ClientMessage
+	err error
+}
+
+// read passes client messages to run until the connection fails.
+func (b *board) read(ctx context.Context, cancel context.CancelFunc, messages chan<- incoming) {
+	defer cancel()
+
+	b.conn.SetReadLimit(maxMessageSize)
+	b.conn.SetReadDeadline(time.Now().Add(pongWait))
+	b.conn.SetPongHandler(func(string) error {
+		return b.conn.SetReadDeadline(time.Now().Add(pongWait))
+	})
+
+	for {
+		var in incoming
+
+		if err := b.conn.ReadJSON(&in.msg); err != nil {
+			var syntaxErr *json.SyntaxError
+			var typeErr *json.UnmarshalTypeError
+			if !errors.As(err, &syntaxErr) && !errors.As(err, &typeErr) && !errors.Is(err, io.ErrUnexpectedEOF) {
+				b.log.Debug("failed to read message", sl.Error(err))
+				return
+			}
+			in.err = validation.ErrMalformedBody
+		}
+
+		select {
+		case messages <- in:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// handle applies a client message and pushes the updated board, or an error
+// message if it is rejected. Only failures to talk to the client are returned.
+func (b *board) handle(ctx context.Context, in incoming) error {
+	err := in.err
+	if err == nil {
+		err = b.apply(ctx, in.msg)
+	}
+	if err != nil {
+		p := apierror.Problem(b.r, err)
+		return b.send(ServerMessage{Type: messageError, Time: time.Now(), Error: &p})
+	}
+
+	return b.send(b.state())
+}
+
+func (b *board) apply(ctx context.Context, msg ClientMessage) error {
+	switch msg.Type {
+	case messageSubscribe:
+		var added []string
+		for _, id := range msg.UserIDs {
+			if !b.timers.Watches(id) && !slices.Contains(added, id) {
+				added = append(added, id)
+			}
+		}
+		if b.timers.Len()+len(added) > maxWatched {
+			return validation.Field("user_ids", validation.InBody, apierror.ErrTooManyWatched)
+		}
+		return b.timers.Refresh(ctx, added)
+
+	case messageUnsubscribe:
+		b.timers.Remove(msg.UserIDs)
+		return nil
+
+	default:
+		return validation.Field("type", validation.InBody, apierror.ErrUnknownMessage)
+	}
+}
+
+func (b *board) state() ServerMessage {
+	now := time.Now()
+
+	timers := b.timers.Timers(now)
+
+	users := make([]UserState, 0, len(timers))
+	for _, timer := range timers {
+		users = append(users, UserState{UserID: timer.UserID, Task: timer.Task, Elapsed: timer.Elapsed.Seconds()})
+	}
+
+	return ServerMessage{Type: messageBoard, Time: now, Users: users}
+}
+
+func (b *board) send(msg ServerMessage) error {
+	b.conn.SetWriteDeadline(time.Now().Add(writeWait))
+	return b.conn.WriteJSON(msg)
+}
@@ -0,0 +1,92 @@
+package board
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Alhanaqtah/effective-mobile-test-task/internal/events"
+	"github.com/Alhanaqtah/effective-mobile-test-task/internal/models"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/gorilla/websocket"
+)
+
+type fakeTasks struct {
+	mu      sync.Mutex
+	running map[string]models.Task
+}
+
+func (f *fakeTasks) RunningTasks(_ context.Context, userUUIDs []string) (map[string]models.Task, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	running := make(map[string]models.Task)
+	for _, id := range userUUIDs {
+		if task, ok := f.running[id]; ok {
+			running[id] = task
+		}
+	}
+	return running, nil
+}
+
+func (f *fakeTasks) start(task models.Task) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.running[task.UserID] = task
+}
+
+// TestBoardPushesChanges checks that a task event is pushed as soon as it is
+// received instead of on the next tick.
+func TestBoardPushesChanges(t *testing.T) {
+	const userID = "5f0c6a4e-2a8e-4a52-9a51-6a4b6f7f3c11"
+
+	tasks := &fakeTasks{running: map[string]models.Task{}}
+	broker := events.New(16)
+	h := New(tasks, broker, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	defer h.Shutdown()
+
+	r := chi.NewRouter()
+	r.Route("/board", h.Register())
+	srv := httptest.NewServer(r)
+	defer srv.Close()
+
+	conn, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(srv.URL, "http")+"/board", nil)
+	if err != nil {
+		t.Fatalf("dial: %v", err)
+	}
+	defer conn.Close()
+
+	read := func() ServerMessage {
+		t.Helper()
+
+		var msg ServerMessage
+		conn.SetReadDeadline(time.Now().Add(tickInterval / 2))
+		if err := conn.ReadJSON(&msg); err != nil {
+			t.Fatalf("read: %v", err)
+		}
+		return msg
+	}
+
+	if err := conn.WriteJSON(ClientMessage{Type: messageSubscribe, UserIDs: []string{userID}}); err != nil {
+		t.Fatalf("subscribe: %v", err)
+	}
+	if msg := read(); len(msg.Users) != 1 || msg.Users[0].Task != nil {
+		t.Fatalf("board = %+v, want an idle user", msg)
+	}
+
+	startedAt := time.Now()
+	tasks.start(models.Task{ID: "task", UserID: userID, StartedAt: &startedAt})
+	broker.Publish(models.Event{Type: models.EventTaskStarted, UserID: userID, Time: startedAt})
+
+	// The board is read well before the first tick is due
+	if msg := read(); len(msg.Users) != 1 || msg.Users[0].Task == nil || msg.Users[0].Task.ID != "task" {
+		t.Fatalf("board = %+v, want the started task", msg)
+	}
+}
@@ -0,0 +1,167 @@
+package datasync
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+
+	"github.com/Alhanaqtah/effective-mobile-test-task/internal/controller/apierror"
+	taskHandler "github.com/Alhanaqtah/effective-mobile-test-task/internal/controller/task"
+	userHandler "github.com/Alhanaqtah/effective-mobile-test-task/internal/controller/user"
+	"github.com/Alhanaqtah/effective-mobile-test-task/internal/lib/logger/sl"
+	"github.com/Alhanaqtah/effective-mobile-test-task/internal/lib/request"
+	"github.com/Alhanaqtah/effective-mobile-test-task/internal/lib/response"
+	"github.com/Alhanaqtah/effective-mobile-test-task/internal/lib/validation"
+	"github.com/Alhanaqtah/effective-mobile-test-task/internal/models"
+	service "github.com/Alhanaqtah/effective-mobile-test-task/internal/service/datasync"
+
+	"github.com/go-chi/chi/middleware"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+)
+
+type Service interface {
+	Pull(ctx context.Context, token string) (*models.ChangeSet, error)
+	Push(ctx context.Context, changes []models.SyncChange) ([]service.ChangeResult, error)
+}
+
+type Handler struct {
+	service Service
+	log     *slog.Logger
+}
+
+func New(service Service, log *slog.Logger) *Handler {
+	return &Handler{
+		service: service,
+		log:     log,
+	}
+}
+
+func (h *Handler) Register() func(r chi.Router) {
+	return func(r chi.Router) {
+		r.Get("/", h.pull)
+		r.Post("/", h.push)
+	}
+}
+
+// @Summary Получить изменения
+// @Description Возвращает пользователей, задачи и интервалы учёта времени, созданные, изменённые или удалённые после позиции, заданной токеном. Без токена возвращается полный снимок данных. Если has_more равно true, следующую порцию нужно запросить с полученным токеном. Если delayed равно true, часть изменений задержана незавершённой транзакцией в PostgreSQL и появится в ленте после её завершения, их нужно запросить позже с полученным токеном
+// @Tags sync
+// @Produce json
+// @Param since query string false "Токен из предыдущего ответа синхронизации"
+// @Success 200 {object} models.ChangeSet "Изменения и токен для следующего запроса"
+// @Failure 400 {object} problem.Problem "Некорректный токен"
+// @Failure 500 {object} problem.Problem "Внутренняя ошибка"
+// @Router /sync [get]
+func (h *Handler) pull(w http.ResponseWriter, r *http.Request) {
+	const op = "controller.datasync.pull"
+
+	log := h.log.With(
+		slog.String("op", op),
+		slog.String("req_id", middleware.GetReqID(r.Context())),
+		sl.Trace(r.Context()),
+	)
+
+	token := r.URL.Query().Get("since")
+
+	changes, err := h.service.Pull(r.Context(), token)
+	if err != nil {
+		apierror.Write(w, r, validation.Locate(err, validation.InQuery))
+		return
+	}
+
+	log.Debug("changes pulled successfully", slog.Bool("has_more", changes.HasMore))
+
+	render.JSON(w, r, changes)
+}
+
+// @Summary Отправить изменения
+// @Description Применяет изменения, сделанные клиентом без связи с сервером, в порядке их выполнения. Каждое изменение содержит версию сущности, на которой оно основано; если версия на сервере отличается, изменение не применяется и возвращается конфликт с текущей версией сущности на сервере
+// @Tags sync
+// @Accept json
+// @Produce json
+// @Param SyncPush body request.SyncPush true "Изменения клиента"
+// @Param Idempotency-Key header string false "Ключ идемпотентности для безопасного повтора запроса"
+// @Success 200 {object} response.SyncPush "Результаты изменений"
+// @Failure 400 {object} problem.Problem "Некорректный запрос"
+// @Failure 409 {object} problem.Problem "Запрос с тем же ключом идемпотентности ещё обрабатывается"
+// @Failure 413 {object} problem.Problem "Слишком много изменений"
+// @Failure 422 {object} problem.Problem "Ключ идемпотентности использован для другого запроса"
+// @Failure 500 {object} problem.Problem "Внутренняя ошибка"
+// @Router /sync [post]
+func (h *Handler) push(w http.ResponseWriter, r *http.Request) {
+	const op = "controller.datasync.push"
+
+	log := h.log.With(
+		slog.String("op", op),
+		slog.String("req_id", middleware.GetReqID(r.Context())),
+		sl.Trace(r.Context()),
+	)
+
+	var req request.SyncPush
+	if err := render.DecodeJSON(r.Body, &req); err != nil {
+		log.Error("failed to decode request body", sl.Error(err))
+		apierror.Write(w, r, validation.ErrMalformedBody)
+		return
+	}
+
+	changes := make([]models.SyncChange, len(req.Changes))
+	for i, c := range req.Changes {
+		change, err := syncChange(c)
+		if err != nil {
+			log.Error("failed to decode change", slog.Int("index", i), sl.Error(err))
+			apierror.Write(w, r, err)
+			return
+		}
+		changes[i] = change
+	}
+
+	results, err := h.service.Push(r.Context(), changes)
+	if err != nil {
+		apierror.Write(w, r, validation.Locate(err, validation.InBody))
+		return
+	}
+
+	resp := response.SyncPush{Results: make([]response.SyncChangeResult, len(results))}
+
+	for i, res := range results {
+		result := response.SyncChangeResult{
+			Index:  i,
+			Status: res.Status,
+			User:   res.User,
+			Task:   res.Task,
+		}
+		if res.Err != nil {
+			p := apierror.Problem(r, validation.Locate(res.Err, validation.InBody))
+			result.Error = &p
+		}
+		resp.Results[i] = result
+	}
+
+	log.Debug("changes pushed successfully", slog.Int("changes", len(changes)))
+
+	render.JSON(w, r, resp)
+}
+
+func syncChange(c request.SyncChange) (models.SyncChange, error) {
+	change := models.SyncChange{Entity: c.Entity}
+
+	switch {
+	case c.Entity == models.EntityTask && c.Task != nil:
+		change.Task = taskHandler.Operation(*c.Task)
+	case c.Entity == models.EntityUser && c.User != nil:
+		change.Op = c.User.Op
+		change.ID = c.User.ID
+		change.Version = c.User.Version
+
+		if len(c.User.Patch) > 0 {
+			update, err := userHandler.DecodeMergePatch(c.User.Patch)
+			if err != nil {
+				return change, err
+			}
+			change.User = update
+		}
+	}
+
+	return change, nil
+}
@@ -0,0 +1,187 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/Alhanaqtah/effective-mobile-test-task/internal/controller/apierror"
+	"github.com/Alhanaqtah/effective-mobile-test-task/internal/events"
+	"github.com/Alhanaqtah/effective-mobile-test-task/internal/lib/logger/sl"
+	"github.com/Alhanaqtah/effective-mobile-test-task/internal/lib/validation"
+	"github.com/Alhanaqtah/effective-mobile-test-task/internal/models"
+
+	"github.com/go-chi/chi/middleware"
+	"github.com/go-chi/chi/v5"
+	uuidlib "github.com/google/uuid"
+)
+
+// heartbeatInterval keeps idle connections open through proxies.
+const heartbeatInterval = 15 * time.Second
+
+// resetEvent tells the client that events were missed and it has to reload
+// its state, e.g. through GET /sync.
+const resetEvent = "reset"
+
+var eventTypes = []string{
+	models.EventTaskCreated,
+	models.EventTaskUpdated,
+	models.EventTaskStarted,
+	models.EventTaskFinished,
+	models.EventTaskDeleted,
+	models.EventUserCreated,
+	models.EventUserUpdated,
+	models.EventUserDeleted,
+}
+
+type Broker interface {
+	Subscribe(lastEventID string, filter events.Filter) (*events.Subscription, []models.Event, bool)
+}
+
+type Handler struct {
+	broker Broker
+	// ctx is cancelled on shutdown to end streams, which would otherwise
+	// keep a graceful shutdown of the server waiting forever.
+	ctx    context.Context
+	cancel context.CancelFunc
+	log    *slog.Logger
+}
+
+func New(broker Broker, log *slog.Logger) *Handler {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	return &Handler{
+		broker: broker,
+		ctx:    ctx,
+		cancel: cancel,
+		log:    log,
+	}
+}
+
+// Shutdown ends the open streams. It is meant to be registered with
+// http.Server.RegisterOnShutdown; clients reconnect to another instance.
+func (h *Handler) Shutdown() {
+	h.cancel()
+}
+
+func (h *Handler) Register() func(r chi.Router) {
+	return func(r chi.Router) {
+		r.Get("/", h.stream)
+	}
+}
+
+// @Summary Поток событий
+// @Description Поток Server-Sent Events об изменениях задач и пользователей: запуск и завершение задач, создание, изменение и удаление задач и пользователей. Приостановки задач в трекере нет, таймер останавливается завершением задачи. Поле id каждого события можно передать в заголовке Last-Event-ID при переподключении, чтобы получить пропущенные события из ограниченного буфера. Если пропущенные события уже недоступны, первым приходит событие reset, после которого клиенту нужно перезагрузить состояние. Поток содержит изменения, сделанные через любой экземпляр сервиса; идентификаторы событий действуют только на том экземпляре, который их выдал, поэтому при переподключении к другому экземпляру приходит reset. Если экземпляр терял соединение с базой данных и мог пропустить события, поток закрывается, и после переподключения приходит reset. События фильтруются по пользователям и типам; команд в модели данных нет, поэтому для событий команды нужно передать user_id всех её участников. При остановке сервера поток закрывается, и клиенту нужно переподключиться
+// @Tags events
+// @Produce text/event-stream
+// @Param user_id query []string false "UUID пользователей, события которых нужно получать" collectionFormat(multi)
+// @Param type query []string false "Типы событий" collectionFormat(multi) Enums(task.created, task.updated, task.started, task.finished, task.deleted, user.created, user.updated, user.deleted)
+// @Param Last-Event-ID header string false "Идентификатор последнего полученного события"
+// @Success 200 {object} models.Event "Поток событий"
+// @Failure 400 {object} problem.Problem "Некорректные параметры запроса"
+// @Router /events [get]
+func (h *Handler) stream(w http.ResponseWriter, r *http.Request) {
+	const op = "controller.events.stream"
+
+	log := h.log.With(
+		slog.String("op", op),
+		slog.String("req_id", middleware.GetReqID(r.Context())),
+		sl.Trace(r.Context()),
+	)
+
+	filter := events.Filter{UserIDs: map[string]bool{}, Types: map[string]bool{}}
+
+	var errs validation.Errors
+	for _, id := range r.URL.Query()["user_id"] {
+		if _, err := uuidlib.Parse(id); err != nil {
+			errs = append(errs, validation.Field("user_id", validation.InQuery, validation.ErrInvalid))
+			break
+		}
+		filter.UserIDs[id] = true
+	}
+	for _, t := range r.URL.Query()["type"] {
+		if !slices.Contains(eventTypes, t) {
+			errs = append(errs, validation.Field("type", validation.InQuery, validation.ErrInvalid))
+			break
+		}
+		filter.Types[t] = true
+	}
+	if err := errs.Err(); err != nil {
+		log.Error("invalid stream parameters", sl.Error(err))
+		apierror.Write(w, r, err)
+		return
+	}
+
+	rc := http.NewResponseController(w)
+	// The stream outlives the server write timeout.
+	if err := rc.SetWriteDeadline(time.Time{}); err != nil {
+		log.Error("failed to disable write deadline", sl.Error(err))
+	}
+
+	sub, replay, resumed := h.broker.Subscribe(r.Header.Get("Last-Event-ID"), filter)
+	defer sub.Close()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+
+	log.Debug("client subscribed", slog.Bool("resumed", resumed), slog.Int("replay", len(replay)))
+
+	if !resumed {
+		fmt.Fprintf(w, "event: %s\ndata: {}\n\n", resetEvent)
+	}
+	for _, event := range replay {
+		if err := writeEvent(w, event); err != nil {
+			return
+		}
+	}
+	if err := rc.Flush(); err != nil {
+		return
+	}
+
+	heartbeat := time.NewTicker(heartbeatInterval)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			log.Debug("client disconnected")
+			return
+		case <-h.ctx.Done():
+			log.Debug("server is shutting down, closing stream")
+			return
+		case event, ok := <-sub.C:
+			if !ok {
+				log.Debug("client is too slow, closing stream")
+				return
+			}
+			if err := writeEvent(w, event); err != nil {
+				return
+			}
+		case <-heartbeat.C:
+			if _, err := fmt.Fprint(w, ": heartbeat\n\n"); err != nil {
+				return
+			}
+		}
+
+		if err := rc.Flush(); err != nil {
+			return
+		}
+	}
+}
+
+func writeEvent(w http.ResponseWriter, event models.Event) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintf(w, "id: %s\nevent: %s\ndata: %s\n\n", event.ID, event.Type, data)
+	return err
+}
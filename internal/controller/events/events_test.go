@@ -0,0 +1,255 @@
+package events
+
+import (
+	"bufio"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Alhanaqtah/effective-mobile-test-task/internal/events"
+	"github.com/Alhanaqtah/effective-mobile-test-task/internal/models"
+
+	"github.com/go-chi/chi/v5"
+)
+
+const (
+	userA = "0b6f3a2c-6f1e-4c1a-9d0e-0a4f3b1e7c01"
+	userB = "0b6f3a2c-6f1e-4c1a-9d0e-0a4f3b1e7c02"
+)
+
+// message is an event read from the stream.
+type message struct {
+	id, event, data string
+}
+
+type stream struct {
+	t *testing.T
+	r *bufio.Reader
+}
+
+// newServer serves the stream of the broker, ended when the test does.
+func newServer(t *testing.T, broker *events.Broker) *httptest.Server {
+	t.Helper()
+
+	h := New(broker, slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+	r := chi.NewRouter()
+	r.Route("/events", h.Register())
+	srv := httptest.NewServer(r)
+	t.Cleanup(func() {
+		h.Shutdown()
+		srv.Close()
+	})
+
+	return srv
+}
+
+// open connects to the stream. Once it returns, the client is subscribed.
+func open(t *testing.T, srv *httptest.Server, query, lastEventID string) *stream {
+	t.Helper()
+
+	req, err := http.NewRequest(http.MethodGet, srv.URL+"/events?"+query, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if lastEventID != "" {
+		req.Header.Set("Last-Event-ID", lastEventID)
+	}
+
+	resp, err := srv.Client().Do(req)
+	if err != nil {
+		t.Fatalf("connect: %v", err)
+	}
+	t.Cleanup(func() { resp.Body.Close() })
+
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("status = %d, want %d", resp.StatusCode, http.StatusOK)
+	}
+
+	return &stream{t: t, r: bufio.NewReader(resp.Body)}
+}
+
+// next reads the next event, skipping heartbeats.
+func (s *stream) next() message {
+	s.t.Helper()
+
+	type result struct {
+		msg message
+		err error
+	}
+	c := make(chan result, 1)
+
+	go func() {
+		var msg message
+		for {
+			line, err := s.r.ReadString('\n')
+			if err != nil {
+				c <- result{err: err}
+				return
+			}
+
+			line = strings.TrimSuffix(line, "\n")
+			switch {
+			case line == "":
+				if msg != (message{}) {
+					c <- result{msg: msg}
+					return
+				}
+			case strings.HasPrefix(line, ":"):
+			default:
+				field, value, _ := strings.Cut(line, ": ")
+				switch field {
+				case "id":
+					msg.id = value
+				case "event":
+					msg.event = value
+				case "data":
+					msg.data = value
+				}
+			}
+		}
+	}()
+
+	select {
+	case r := <-c:
+		if r.err != nil {
+			s.t.Fatalf("read event: %v", r.err)
+		}
+		return r.msg
+	case <-time.After(5 * time.Second):
+		s.t.Fatal("no event received")
+		return message{}
+	}
+}
+
+// publish publishes the events and returns the ids the broker gave them.
+func publish(t *testing.T, broker *events.Broker, evts ...models.Event) []string {
+	t.Helper()
+
+	sub, _, _ := broker.Subscribe("", events.Filter{})
+	defer sub.Close()
+
+	ids := make([]string, 0, len(evts))
+	for _, e := range evts {
+		broker.Publish(e)
+		ids = append(ids, (<-sub.C).ID)
+	}
+	return ids
+}
+
+func event(eventType, userID string) models.Event {
+	return models.Event{Type: eventType, UserID: userID, Time: time.Now()}
+}
+
+func TestStreamFilter(t *testing.T) {
+	broker := events.New(16)
+	srv := newServer(t, broker)
+
+	s := open(t, srv, "user_id="+userA+"&type="+models.EventTaskStarted+"&type="+models.EventTaskFinished, "")
+
+	ids := publish(t, broker,
+		event(models.EventTaskStarted, userB),
+		event(models.EventTaskStarted, userA),
+		event(models.EventTaskCreated, userA),
+		event(models.EventUserUpdated, userA),
+		event(models.EventTaskFinished, userA),
+	)
+
+	for _, want := range []message{
+		{id: ids[1], event: models.EventTaskStarted},
+		{id: ids[4], event: models.EventTaskFinished},
+	} {
+		got := s.next()
+		if got.id != want.id || got.event != want.event {
+			t.Fatalf("received %s %s, want %s %s", got.event, got.id, want.event, want.id)
+		}
+		if !strings.Contains(got.data, userA) {
+			t.Errorf("event data %s is not of user %s", got.data, userA)
+		}
+	}
+}
+
+func TestStreamResume(t *testing.T) {
+	broker := events.New(16)
+	srv := newServer(t, broker)
+
+	ids := publish(t, broker,
+		event(models.EventTaskStarted, userA),
+		event(models.EventTaskStarted, userB),
+		event(models.EventTaskFinished, userA),
+	)
+
+	// Missed events matching the filter are replayed without a reset
+	s := open(t, srv, "user_id="+userA, ids[0])
+	if got := s.next(); got.id != ids[2] || got.event != models.EventTaskFinished {
+		t.Fatalf("received %s %s, want the missed %s %s", got.event, got.id, models.EventTaskFinished, ids[2])
+	}
+
+	// and the stream goes on with live events
+	live := publish(t, broker, event(models.EventTaskDeleted, userA))
+	if got := s.next(); got.id != live[0] {
+		t.Fatalf("received %s %s, want the live event %s", got.event, got.id, live[0])
+	}
+}
+
+func TestStreamReset(t *testing.T) {
+	broker := events.New(2)
+	srv := newServer(t, broker)
+
+	ids := publish(t, broker,
+		event(models.EventTaskStarted, userA),
+		event(models.EventTaskFinished, userA),
+		event(models.EventTaskStarted, userA),
+		event(models.EventTaskFinished, userA),
+	)
+
+	tests := []struct {
+		name        string
+		lastEventID string
+		replay      []string
+	}{
+		// The event after ids[0] was dropped from the buffer
+		{"evicted", ids[0], ids[2:]},
+		{"another instance", "other." + strings.Split(ids[0], ".")[1], nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := open(t, srv, "", tt.lastEventID)
+
+			if got := s.next(); got.event != resetEvent || got.id != "" {
+				t.Fatalf("received %s %s, want %s first", got.event, got.id, resetEvent)
+			}
+			for _, id := range tt.replay {
+				if got := s.next(); got.id != id {
+					t.Fatalf("received %s %s, want the buffered event %s", got.event, got.id, id)
+				}
+			}
+
+			// A reset is only sent once, live events follow
+			live := publish(t, broker, event(models.EventUserCreated, userA))
+			if got := s.next(); got.id != live[0] {
+				t.Fatalf("received %s %s, want the live event %s", got.event, got.id, live[0])
+			}
+		})
+	}
+}
+
+func TestStreamInvalidFilter(t *testing.T) {
+	srv := newServer(t, events.New(16))
+
+	for _, query := range []string{"user_id=42", "type=task.paused"} {
+		resp, err := srv.Client().Get(srv.URL + "/events?" + query)
+		if err != nil {
+			t.Fatalf("connect: %v", err)
+		}
+		resp.Body.Close()
+
+		if resp.StatusCode != http.StatusBadRequest {
+			t.Errorf("%s: status = %d, want %d", query, resp.StatusCode, http.StatusBadRequest)
+		}
+	}
+}
@@ -0,0 +1,133 @@
+package gql
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/graphql-go/graphql/language/ast"
+)
+
+// listSizes estimates how many items list fields return when the query
+// does not limit them with a first argument.
+var listSizes = map[string]int{
+	"users":       defaultPageSize,
+	"tasks":       defaultPageSize,
+	"timeEntries": defaultPageSize,
+}
+
+// analyze returns the depth of the operation and its complexity: every
+// field costs one, and the cost of the selections of a list field is
+// multiplied by the number of items it may return. Variables missing from
+// variables take their default values. The document must already be
+// validated, so fragments are known and acyclic.
+func analyze(doc *ast.Document, operationName string, variables map[string]any) (depth, complexity int) {
+	fragments := make(map[string]*ast.FragmentDefinition)
+	var operation *ast.OperationDefinition
+
+	for _, def := range doc.Definitions {
+		switch def := def.(type) {
+		case *ast.FragmentDefinition:
+			fragments[def.Name.Value] = def
+		case *ast.OperationDefinition:
+			if operation == nil && (operationName == "" || def.Name != nil && def.Name.Value == operationName) {
+				operation = def
+			}
+		}
+	}
+
+	if operation == nil {
+		return 0, 0
+	}
+
+	values := make(map[string]ast.Value, len(operation.VariableDefinitions))
+	for _, def := range operation.VariableDefinitions {
+		if def.DefaultValue != nil {
+			values[def.Variable.Name.Value] = def.DefaultValue
+		}
+	}
+
+	a := analyzer{fragments: fragments, variables: variables, defaults: values}
+	return a.selections(operation.SelectionSet, 0)
+}
+
+type analyzer struct {
+	fragments map[string]*ast.FragmentDefinition
+	variables map[string]any
+	defaults  map[string]ast.Value
+}
+
+func (a analyzer) selections(set *ast.SelectionSet, depth int) (maxDepth, cost int) {
+	if set == nil {
+		return depth, 0
+	}
+
+	maxDepth = depth
+
+	for _, sel := range set.Selections {
+		var d, c int
+
+		switch sel := sel.(type) {
+		case *ast.Field:
+			// Introspection is bounded by the size of the schema.
+			if strings.HasPrefix(sel.Name.Value, "__") {
+				continue
+			}
+			d, c = a.selections(sel.SelectionSet, depth+1)
+			c = 1 + a.listSize(sel)*c
+
+		case *ast.InlineFragment:
+			d, c = a.selections(sel.SelectionSet, depth)
+
+		case *ast.FragmentSpread:
+			if fragment, ok := a.fragments[sel.Name.Value]; ok {
+				d, c = a.selections(fragment.SelectionSet, depth)
+			}
+		}
+
+		maxDepth = max(maxDepth, d)
+		cost += c
+	}
+
+	return maxDepth, cost
+}
+
+func (a analyzer) listSize(f *ast.Field) int {
+	size, ok := listSizes[f.Name.Value]
+	if !ok {
+		return 1
+	}
+
+	for _, arg := range f.Arguments {
+		if arg.Name.Value != "first" {
+			continue
+		}
+
+		if n, ok := a.intValue(arg.Value); ok {
+			size = n
+		}
+	}
+
+	return max(size, 0)
+}
+
+// intValue returns the value of an integer literal or variable.
+func (a analyzer) intValue(value ast.Value) (int, bool) {
+	switch v := value.(type) {
+	case *ast.IntValue:
+		n, err := strconv.Atoi(v.Value)
+		return n, err == nil
+
+	case *ast.Variable:
+		name := v.Name.Value
+		if given, ok := a.variables[name]; ok {
+			// Variables are decoded from JSON
+			n, ok := given.(float64)
+			return int(n), ok
+		}
+		if def, ok := a.defaults[name]; ok {
+			return a.intValue(def)
+		}
+	}
+
+	return 0, false
+}
@@ -0,0 +1,102 @@
+package gql
+
+import (
+	"testing"
+
+	"github.com/graphql-go/graphql/language/parser"
+)
+
+func TestAnalyze(t *testing.T) {
+	tests := []struct {
+		name           string
+		query          string
+		operation      string
+		variables      map[string]any
+		depth, complex int
+	}{
+		{
+			name:    "scalar fields",
+			query:   `{ user(id: "x") { id name } }`,
+			depth:   2,
+			complex: 3,
+		},
+		{
+			name:    "default page size",
+			query:   `{ users { edges { node { id } } } }`,
+			depth:   4,
+			complex: 1 + defaultPageSize*3,
+		},
+		{
+			name:    "literal first",
+			query:   `{ users(first: 5) { edges { node { id } } } }`,
+			depth:   4,
+			complex: 1 + 5*3,
+		},
+		{
+			name:      "variable first",
+			query:     `query($n: Int) { users(first: $n) { edges { node { id } } } }`,
+			variables: map[string]any{"n": float64(2)},
+			depth:     4,
+			complex:   1 + 2*3,
+		},
+		{
+			name:    "default of a variable",
+			query:   `query($n: Int = 100) { users(first: $n) { edges { node { id } } } }`,
+			depth:   4,
+			complex: 1 + 100*3,
+		},
+		{
+			name:      "variable overrides its default",
+			query:     `query($n: Int = 100) { users(first: $n) { edges { node { id } } } }`,
+			variables: map[string]any{"n": float64(1)},
+			depth:     4,
+			complex:   1 + 1*3,
+		},
+		{
+			name: "nested lists multiply",
+			query: `query($n: Int = 10) {
+				users(first: $n) { edges { node { timeEntries(from: "2026-01-01T00:00:00Z", to: "2026-02-01T00:00:00Z", first: 3) { totalCount } } } }
+			}`,
+			depth:   5,
+			complex: 1 + 10*(1+1+1+3*1),
+		},
+		{
+			name:    "fragments",
+			query:   `{ user(id: "x") { ...names } } fragment names on User { name surname }`,
+			depth:   2,
+			complex: 3,
+		},
+		{
+			name:    "introspection is free",
+			query:   `{ __schema { types { name } } user(id: "x") { id } }`,
+			depth:   2,
+			complex: 2,
+		},
+		{
+			name:      "named operation",
+			query:     `query a { user(id: "x") { id } } query b { users(first: 1) { edges { cursor } } }`,
+			operation: "b",
+			depth:     3,
+			complex:   1 + 1*2,
+		},
+		{
+			name:      "unknown operation",
+			query:     `query a { user(id: "x") { id } }`,
+			operation: "b",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc, err := parser.Parse(parser.ParseParams{Source: tt.query})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			depth, complexity := analyze(doc, tt.operation, tt.variables)
+			if depth != tt.depth || complexity != tt.complex {
+				t.Errorf("got depth %d and complexity %d, want %d and %d", depth, complexity, tt.depth, tt.complex)
+			}
+		})
+	}
+}
@@ -0,0 +1,204 @@
+// Package gql serves read-only reporting queries over GraphQL.
+package gql
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/Alhanaqtah/effective-mobile-test-task/internal/controller/apierror"
+	"github.com/Alhanaqtah/effective-mobile-test-task/internal/lib/i18n"
+	"github.com/Alhanaqtah/effective-mobile-test-task/internal/lib/logger/sl"
+	"github.com/Alhanaqtah/effective-mobile-test-task/internal/lib/request"
+	"github.com/Alhanaqtah/effective-mobile-test-task/internal/lib/validation"
+	"github.com/Alhanaqtah/effective-mobile-test-task/internal/models"
+	"github.com/Alhanaqtah/effective-mobile-test-task/internal/service/report"
+
+	"github.com/go-chi/chi/middleware"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/gqlerrors"
+	"github.com/graphql-go/graphql/language/parser"
+	"github.com/graphql-go/graphql/language/source"
+)
+
+// maxBodySize limits the size of a query document with its variables.
+const maxBodySize = 64 << 10
+
+type Service interface {
+	Users(ctx context.Context, limit, offset int, filter string) ([]models.User, bool, error)
+	UsersByIDs(ctx context.Context, userIDs []string) (map[string]models.User, error)
+	Task(ctx context.Context, taskID string) (*models.Task, error)
+	TasksOfUsers(ctx context.Context, userIDs []string, from, to time.Time, limit, offset int) (map[string]report.TaskPage, error)
+	TotalDurations(ctx context.Context, userIDs []string, from, to time.Time) (map[string]float64, error)
+}
+
+type Handler struct {
+	service       Service
+	schema        graphql.Schema
+	maxDepth      int
+	maxComplexity int
+	log           *slog.Logger
+}
+
+// New returns the GraphQL handler. Queries nested deeper than maxDepth or
+// costing more than maxComplexity are rejected before they touch the DB.
+func New(service Service, maxDepth, maxComplexity int, log *slog.Logger) *Handler {
+	schema, err := newSchema(service)
+	if err != nil {
+		panic("gql: invalid schema: " + err.Error())
+	}
+
+	return &Handler{
+		service:       service,
+		schema:        schema,
+		maxDepth:      maxDepth,
+		maxComplexity: maxComplexity,
+		log:           log,
+	}
+}
+
+func (h *Handler) Register() func(r chi.Router) {
+	return func(r chi.Router) {
+		r.Get("/", h.serve)
+		r.Post("/", h.serve)
+	}
+}
+
+// @Summary GraphQL API для отчётов
+// @Description Выполняет запрос GraphQL к пользователям, задачам, интервалам учёта времени и суммарной продолжительности работы. Списки возвращаются постранично с курсорами (first, after). Запросы с вложенностью или сложностью выше допустимой отклоняются с кодом query_too_deep или query_too_complex в extensions.code ошибки. Запрос можно передать методом GET в параметрах query, operationName и variables
+// @Tags graphql
+// @Accept json
+// @Produce json
+// @Param GraphQL body request.GraphQL true "Запрос GraphQL"
+// @Success 200 {object} object "Результат запроса: data и errors"
+// @Failure 400 {object} problem.Problem "Некорректное тело запроса"
+// @Router /graphql [post]
+func (h *Handler) serve(w http.ResponseWriter, r *http.Request) {
+	const op = "controller.gql.serve"
+
+	log := h.log.With(
+		slog.String("op", op),
+		slog.String("req_id", middleware.GetReqID(r.Context())),
+		sl.Trace(r.Context()),
+	)
+
+	req, err := decodeRequest(w, r)
+	if err != nil {
+		log.Error("failed to decode request", sl.Error(err))
+		apierror.Write(w, r, err)
+		return
+	}
+
+	ctx := context.WithValue(r.Context(), loadersKey{}, newLoaders(h.service))
+
+	result := h.execute(ctx, req)
+
+	for i, e := range result.Errors {
+		result.Errors[i] = h.formatError(ctx, e)
+	}
+
+	render.JSON(w, r, result)
+}
+
+func (h *Handler) execute(ctx context.Context, req request.GraphQL) *graphql.Result {
+	doc, err := parser.Parse(parser.ParseParams{Source: source.NewSource(&source.Source{Body: []byte(req.Query), Name: "GraphQL request"})})
+	if err != nil {
+		return &graphql.Result{Errors: gqlerrors.FormatErrors(err)}
+	}
+
+	if v := graphql.ValidateDocument(&h.schema, doc, nil); !v.IsValid {
+		return &graphql.Result{Errors: v.Errors}
+	}
+
+	depth, complexity := analyze(doc, req.OperationName, req.Variables)
+	if depth > h.maxDepth {
+		return &graphql.Result{Errors: gqlerrors.FormatErrors(apierror.ErrQueryTooDeep)}
+	}
+	if complexity > h.maxComplexity {
+		return &graphql.Result{Errors: gqlerrors.FormatErrors(apierror.ErrQueryTooComplex)}
+	}
+
+	return graphql.Execute(graphql.ExecuteParams{
+		Schema:        h.schema,
+		AST:           doc,
+		OperationName: req.OperationName,
+		Args:          req.Variables,
+		Context:       ctx,
+	})
+}
+
+// formatError replaces the message of errors raised by the services with
+// the catalog one and adds its code, like problem documents do. Other
+// errors are GraphQL syntax or validation errors and are kept.
+func (h *Handler) formatError(ctx context.Context, e gqlerrors.FormattedError) gqlerrors.FormattedError {
+	err := originalError(e)
+	if err == nil {
+		return e
+	}
+
+	entry := apierror.Lookup(err)
+	if entry.Code == apierror.CodeInternal {
+		h.log.Error("failed to resolve field", slog.Any("path", e.Path), sl.Error(err))
+	}
+
+	lang := i18n.FromContext(ctx)
+
+	extensions := map[string]any{"code": entry.Code}
+
+	if fields := validation.Fields(err); len(fields) > 0 {
+		entry.Code = apierror.Lookup(fields[0].Err).Code
+		extensions["code"] = entry.Code
+		extensions["field"] = fields[0].Field
+	}
+
+	e.Message = apierror.Translate(lang, entry.Code).Detail
+	e.Extensions = extensions
+
+	return e
+}
+
+// originalError digs the error returned by a resolver out of the wrappers
+// added by the executor. It returns nil for errors of the query itself.
+func originalError(e gqlerrors.FormattedError) error {
+	var err error = e
+	for {
+		switch wrapped := err.(type) {
+		case gqlerrors.FormattedError:
+			err = wrapped.OriginalError()
+		case *gqlerrors.Error:
+			err = wrapped.OriginalError
+		default:
+			return err
+		}
+		if err == nil {
+			return nil
+		}
+	}
+}
+
+func decodeRequest(w http.ResponseWriter, r *http.Request) (request.GraphQL, error) {
+	var req request.GraphQL
+
+	if r.Method == http.MethodGet {
+		q := r.URL.Query()
+		req.Query = q.Get("query")
+		req.OperationName = q.Get("operationName")
+		if v := q.Get("variables"); v != "" {
+			if err := json.Unmarshal([]byte(v), &req.Variables); err != nil {
+				return req, validation.Field("variables", validation.InQuery, validation.ErrMalformedBody)
+			}
+		}
+	} else if err := render.DecodeJSON(http.MaxBytesReader(w, r.Body, maxBodySize), &req); err != nil {
+		return req, validation.ErrMalformedBody
+	}
+
+	if req.Query == "" {
+		return req, validation.Field("query", validation.InBody, validation.ErrRequired)
+	}
+
+	return req, nil
+}
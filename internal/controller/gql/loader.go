@@ -0,0 +1,64 @@
+package gql
+
+import (
+	"context"
+	"sync"
+)
+
+// loader batches loads of the same kind made while resolving one level of
+// a query. Load only records the key; the returned thunk is called by the
+// executor after every field of the level has been resolved, so the first
+// thunk fetches all recorded keys with a single call. Results are cached
+// for the rest of the request.
+type loader[K comparable, V any] struct {
+	fetch func(ctx context.Context, keys []K) (map[K]V, error)
+
+	mu      sync.Mutex
+	open    *batch[K, V]
+	batches map[K]*batch[K, V]
+}
+
+type batch[K comparable, V any] struct {
+	once   sync.Once
+	keys   []K
+	values map[K]V
+	err    error
+}
+
+func newLoader[K comparable, V any](fetch func(ctx context.Context, keys []K) (map[K]V, error)) *loader[K, V] {
+	return &loader[K, V]{
+		fetch:   fetch,
+		batches: make(map[K]*batch[K, V]),
+	}
+}
+
+// Load returns a thunk yielding the value for key and whether it exists.
+func (l *loader[K, V]) Load(ctx context.Context, key K) func() (V, bool, error) {
+	l.mu.Lock()
+	b, ok := l.batches[key]
+	if !ok {
+		if l.open == nil {
+			l.open = &batch[K, V]{}
+		}
+		b = l.open
+		b.keys = append(b.keys, key)
+		l.batches[key] = b
+	}
+	l.mu.Unlock()
+
+	return func() (V, bool, error) {
+		b.once.Do(func() {
+			l.mu.Lock()
+			if l.open == b {
+				l.open = nil
+			}
+			keys := b.keys
+			l.mu.Unlock()
+
+			b.values, b.err = l.fetch(ctx, keys)
+		})
+
+		v, ok := b.values[key]
+		return v, ok, b.err
+	}
+}
@@ -0,0 +1,105 @@
+package gql
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestLoaderBatchesKeys(t *testing.T) {
+	var calls [][]string
+	l := newLoader(func(_ context.Context, keys []string) (map[string]int, error) {
+		calls = append(calls, slices.Clone(keys))
+		values := make(map[string]int)
+		for _, key := range keys {
+			if key != "missing" {
+				values[key] = len(key)
+			}
+		}
+		return values, nil
+	})
+
+	ctx := context.Background()
+	a := l.Load(ctx, "a")
+	bb := l.Load(ctx, "bb")
+	again := l.Load(ctx, "a")
+	missing := l.Load(ctx, "missing")
+
+	if v, ok, err := bb(); v != 2 || !ok || err != nil {
+		t.Errorf("bb = %d, %v, %v", v, ok, err)
+	}
+	if v, ok, err := a(); v != 1 || !ok || err != nil {
+		t.Errorf("a = %d, %v, %v", v, ok, err)
+	}
+	if v, ok, _ := again(); v != 1 || !ok {
+		t.Errorf("a again = %d, %v", v, ok)
+	}
+	if _, ok, _ := missing(); ok {
+		t.Error("missing key is found")
+	}
+
+	// Loaded keys are cached, new ones start a new batch.
+	l.Load(ctx, "a")()
+	l.Load(ctx, "ccc")()
+
+	want := [][]string{{"a", "bb", "missing"}, {"ccc"}}
+	if !slices.EqualFunc(calls, want, slices.Equal) {
+		t.Errorf("fetched %v, want %v", calls, want)
+	}
+}
+
+func TestLoaderError(t *testing.T) {
+	errFetch := errors.New("fetch failed")
+	l := newLoader(func(context.Context, []string) (map[string]int, error) {
+		return nil, errFetch
+	})
+
+	a := l.Load(context.Background(), "a")
+	b := l.Load(context.Background(), "b")
+
+	if _, _, err := a(); !errors.Is(err, errFetch) {
+		t.Errorf("a: got error %v", err)
+	}
+	if _, _, err := b(); !errors.Is(err, errFetch) {
+		t.Errorf("b: got error %v", err)
+	}
+}
+
+func TestLoadByRangeGroupsKeys(t *testing.T) {
+	jan := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	feb := time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)
+
+	keys := []rangeKey{
+		{userID: "u1", from: jan, to: feb, first: 10},
+		{userID: "u2", from: jan, to: feb, first: 10},
+		{userID: "u1", from: jan, to: feb, first: 10, offset: 10},
+	}
+
+	var groups []rangeKey
+	values, err := loadByRange(context.Background(), keys, func(_ context.Context, userIDs []string, r rangeKey) (map[string]string, error) {
+		if r.userID != "" {
+			t.Errorf("fetch got user %q", r.userID)
+		}
+		groups = append(groups, r)
+
+		byUser := make(map[string]string)
+		for _, id := range userIDs {
+			byUser[id] = id + "@" + string(rune('0'+r.offset/10))
+		}
+		return byUser, nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(groups) != 2 {
+		t.Errorf("fetched %d groups, want 2", len(groups))
+	}
+	for key, want := range map[rangeKey]string{keys[0]: "u1@0", keys[1]: "u2@0", keys[2]: "u1@1"} {
+		if got := values[key]; got != want {
+			t.Errorf("value of %+v = %q, want %q", key, got, want)
+		}
+	}
+}
@@ -0,0 +1,428 @@
+package gql
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Alhanaqtah/effective-mobile-test-task/internal/controller/apierror"
+	"github.com/Alhanaqtah/effective-mobile-test-task/internal/lib/validation"
+	"github.com/Alhanaqtah/effective-mobile-test-task/internal/models"
+	"github.com/Alhanaqtah/effective-mobile-test-task/internal/service/report"
+
+	"github.com/google/uuid"
+	"github.com/graphql-go/graphql"
+)
+
+// defaultPageSize is the number of items a connection returns when the
+// query does not ask for a number.
+const defaultPageSize = 20
+
+// rangeKey identifies data of a user within a date range, or a page of it
+// if first is set.
+type rangeKey struct {
+	userID        string
+	from, to      time.Time
+	first, offset int
+}
+
+// loaders are created for every request, so cached values never outlive it.
+type loaders struct {
+	users     *loader[string, models.User]
+	tasks     *loader[rangeKey, report.TaskPage]
+	durations *loader[rangeKey, float64]
+}
+
+type loadersKey struct{}
+
+func newLoaders(service Service) *loaders {
+	return &loaders{
+		users: newLoader(service.UsersByIDs),
+		tasks: newLoader(func(ctx context.Context, keys []rangeKey) (map[rangeKey]report.TaskPage, error) {
+			return loadByRange(ctx, keys, func(ctx context.Context, userIDs []string, r rangeKey) (map[string]report.TaskPage, error) {
+				return service.TasksOfUsers(ctx, userIDs, r.from, r.to, r.first, r.offset)
+			})
+		}),
+		durations: newLoader(func(ctx context.Context, keys []rangeKey) (map[rangeKey]float64, error) {
+			return loadByRange(ctx, keys, func(ctx context.Context, userIDs []string, r rangeKey) (map[string]float64, error) {
+				return service.TotalDurations(ctx, userIDs, r.from, r.to)
+			})
+		}),
+	}
+}
+
+// loadByRange fetches keys sharing a date range and page together, so
+// a query asking for the same range of many users costs one call. fetch
+// gets the shared part of the keys, without a user.
+func loadByRange[V any](ctx context.Context, keys []rangeKey, fetch func(ctx context.Context, userIDs []string, r rangeKey) (map[string]V, error)) (map[rangeKey]V, error) {
+	users := make(map[rangeKey][]string)
+	for _, key := range keys {
+		r := key
+		r.userID = ""
+		users[r] = append(users[r], key.userID)
+	}
+
+	values := make(map[rangeKey]V, len(keys))
+	for r, userIDs := range users {
+		byUser, err := fetch(ctx, userIDs, r)
+		if err != nil {
+			return nil, err
+		}
+		for _, id := range userIDs {
+			key := r
+			key.userID = id
+			values[key] = byUser[id]
+		}
+	}
+
+	return values, nil
+}
+
+func loadersFrom(ctx context.Context) *loaders {
+	return ctx.Value(loadersKey{}).(*loaders)
+}
+
+// connection is a page of a list with Relay style cursors.
+type connection[T any] struct {
+	Edges      []edge[T]
+	PageInfo   pageInfo
+	TotalCount int
+}
+
+type edge[T any] struct {
+	Cursor string
+	Node   T
+}
+
+type pageInfo struct {
+	HasNextPage bool
+	EndCursor   *string
+}
+
+func newConnection[T any](nodes []T, offset int, hasNext bool, total int) connection[T] {
+	c := connection[T]{
+		Edges:      make([]edge[T], len(nodes)),
+		PageInfo:   pageInfo{HasNextPage: hasNext},
+		TotalCount: total,
+	}
+
+	for i, node := range nodes {
+		c.Edges[i] = edge[T]{Cursor: encodeCursor(offset + i + 1), Node: node}
+	}
+	if len(c.Edges) > 0 {
+		c.PageInfo.EndCursor = &c.Edges[len(c.Edges)-1].Cursor
+	}
+
+	return c
+}
+
+// Cursors are opaque to clients; they hold the number of items before the
+// next page.
+func encodeCursor(offset int) string {
+	return base64.RawURLEncoding.EncodeToString([]byte("o:" + strconv.Itoa(offset)))
+}
+
+func decodeCursor(cursor string) (int, error) {
+	if cursor == "" {
+		return 0, nil
+	}
+
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return 0, validation.Field("after", validation.InQuery, apierror.ErrInvalidCursor)
+	}
+
+	offset, err := strconv.Atoi(strings.TrimPrefix(string(raw), "o:"))
+	if err != nil || !strings.HasPrefix(string(raw), "o:") || offset < 0 {
+		return 0, validation.Field("after", validation.InQuery, apierror.ErrInvalidCursor)
+	}
+
+	return offset, nil
+}
+
+// page reads the first and after arguments of a connection field.
+func page(p graphql.ResolveParams) (first, offset int, err error) {
+	first, _ = p.Args["first"].(int)
+	if first < 0 || first > report.MaxPageSize {
+		return 0, 0, validation.Field("first", validation.InQuery, report.ErrInvalidPageSize)
+	}
+
+	after, _ := p.Args["after"].(string)
+	offset, err = decodeCursor(after)
+	return first, offset, err
+}
+
+func newSchema(service Service) (graphql.Schema, error) {
+	pageInfoType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "PageInfo",
+		Fields: graphql.Fields{
+			"hasNextPage": &graphql.Field{Type: graphql.NewNonNull(graphql.Boolean), Resolve: field(func(p pageInfo) any { return p.HasNextPage })},
+			"endCursor":   &graphql.Field{Type: graphql.String, Resolve: field(func(p pageInfo) any { return p.EndCursor })},
+		},
+	})
+
+	timeEntryType := graphql.NewObject(graphql.ObjectConfig{
+		Name:        "TimeEntry",
+		Description: "Interval of work on a task, from its last start to its completion.",
+		Fields: graphql.Fields{
+			"id":        &graphql.Field{Type: graphql.NewNonNull(graphql.ID), Resolve: field(func(e models.TimeEntry) any { return e.ID })},
+			"taskId":    &graphql.Field{Type: graphql.NewNonNull(graphql.ID), Resolve: field(func(e models.TimeEntry) any { return e.TaskID })},
+			"userId":    &graphql.Field{Type: graphql.NewNonNull(graphql.ID), Resolve: field(func(e models.TimeEntry) any { return e.UserID })},
+			"startedAt": &graphql.Field{Type: graphql.NewNonNull(graphql.DateTime), Resolve: field(func(e models.TimeEntry) any { return e.StartedAt })},
+			"endedAt":   &graphql.Field{Type: graphql.DateTime, Resolve: field(func(e models.TimeEntry) any { return e.EndedAt })},
+			"duration": &graphql.Field{
+				Type:        graphql.Float,
+				Description: "Length of the interval in minutes, null until the task is finished.",
+				Resolve:     field(func(e models.TimeEntry) any { return e.Duration }),
+			},
+		},
+	})
+
+	// User and Task refer to each other, so their fields are added later.
+	userType := graphql.NewObject(graphql.ObjectConfig{Name: "User", Fields: graphql.Fields{}})
+
+	taskType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Task",
+		Fields: graphql.Fields{
+			"id":          &graphql.Field{Type: graphql.NewNonNull(graphql.ID), Resolve: field(func(t models.Task) any { return t.ID })},
+			"userId":      &graphql.Field{Type: graphql.NewNonNull(graphql.ID), Resolve: field(func(t models.Task) any { return t.UserID })},
+			"title":       &graphql.Field{Type: graphql.NewNonNull(graphql.String), Resolve: field(func(t models.Task) any { return t.Title })},
+			"description": &graphql.Field{Type: graphql.NewNonNull(graphql.String), Resolve: field(func(t models.Task) any { return t.Description })},
+			"done":        &graphql.Field{Type: graphql.NewNonNull(graphql.Boolean), Resolve: field(func(t models.Task) any { return t.Done })},
+			"createdAt":   &graphql.Field{Type: graphql.NewNonNull(graphql.DateTime), Resolve: field(func(t models.Task) any { return t.CreatedAt })},
+			"startedAt":   &graphql.Field{Type: graphql.DateTime, Resolve: field(func(t models.Task) any { return t.StartedAt })},
+			"doneAt":      &graphql.Field{Type: graphql.DateTime, Resolve: field(func(t models.Task) any { return t.DoneAt })},
+			"duration": &graphql.Field{
+				Type:        graphql.Float,
+				Description: "Minutes spent on the task so far.",
+				Resolve:     field(func(t models.Task) any { return t.Duration }),
+			},
+			"version":   &graphql.Field{Type: graphql.NewNonNull(graphql.Int), Resolve: field(func(t models.Task) any { return t.Version })},
+			"timeEntry": &graphql.Field{Type: graphql.NewNonNull(timeEntryType), Resolve: field(func(t models.Task) any { return models.TimeEntryOf(t) })},
+			"user": &graphql.Field{
+				Type: userType,
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					return loadUser(p.Context, p.Source.(models.Task).UserID), nil
+				},
+			},
+		},
+	})
+
+	taskConnectionType := connectionType[models.Task]("Task", taskType, pageInfoType, true)
+	timeEntryConnectionType := connectionType[models.TimeEntry]("TimeEntry", timeEntryType, pageInfoType, true)
+	userConnectionType := connectionType[models.User]("User", userType, pageInfoType, false)
+
+	rangeArgs := graphql.FieldConfigArgument{
+		"from": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.DateTime), Description: "Start of the range of task creation times."},
+		"to":   &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.DateTime), Description: "End of the range of task creation times."},
+	}
+
+	pageArgs := func(args graphql.FieldConfigArgument) graphql.FieldConfigArgument {
+		paged := graphql.FieldConfigArgument{
+			"first": &graphql.ArgumentConfig{Type: graphql.Int, DefaultValue: defaultPageSize},
+			"after": &graphql.ArgumentConfig{Type: graphql.String},
+		}
+		for name, arg := range args {
+			paged[name] = arg
+		}
+		return paged
+	}
+
+	userFields := graphql.Fields{
+		"id":             &graphql.Field{Type: graphql.NewNonNull(graphql.ID), Resolve: field(func(u models.User) any { return u.ID })},
+		"name":           &graphql.Field{Type: graphql.NewNonNull(graphql.String), Resolve: field(func(u models.User) any { return u.Name })},
+		"surname":        &graphql.Field{Type: graphql.NewNonNull(graphql.String), Resolve: field(func(u models.User) any { return u.Surname })},
+		"patronymic":     &graphql.Field{Type: graphql.NewNonNull(graphql.String), Resolve: field(func(u models.User) any { return u.Patronymic })},
+		"address":        &graphql.Field{Type: graphql.NewNonNull(graphql.String), Resolve: field(func(u models.User) any { return u.Address })},
+		"passportSerie":  &graphql.Field{Type: graphql.NewNonNull(graphql.Int), Resolve: field(func(u models.User) any { return u.PassportSerie })},
+		"passportNumber": &graphql.Field{Type: graphql.NewNonNull(graphql.Int), Resolve: field(func(u models.User) any { return u.PassportNumber })},
+		"version":        &graphql.Field{Type: graphql.NewNonNull(graphql.Int), Resolve: field(func(u models.User) any { return u.Version })},
+		"tasks": &graphql.Field{
+			Type:        graphql.NewNonNull(taskConnectionType),
+			Description: "Tasks created within the range, finished ones first.",
+			Args:        pageArgs(rangeArgs),
+			Resolve: func(p graphql.ResolveParams) (any, error) {
+				key, err := pageOf(p)
+				if err != nil {
+					return nil, err
+				}
+
+				load := loadersFrom(p.Context).tasks.Load(p.Context, key)
+
+				return func() (any, error) {
+					page, _, err := load()
+					if err != nil {
+						return nil, err
+					}
+
+					return newConnection(page.Tasks, key.offset, key.offset+len(page.Tasks) < page.Total, page.Total), nil
+				}, nil
+			},
+		},
+		"timeEntries": &graphql.Field{
+			Type:        graphql.NewNonNull(timeEntryConnectionType),
+			Description: "Time entries of tasks created within the range, in the order of tasks.",
+			Args:        pageArgs(rangeArgs),
+			Resolve: func(p graphql.ResolveParams) (any, error) {
+				key, err := pageOf(p)
+				if err != nil {
+					return nil, err
+				}
+
+				load := loadersFrom(p.Context).tasks.Load(p.Context, key)
+
+				return func() (any, error) {
+					page, _, err := load()
+					if err != nil {
+						return nil, err
+					}
+
+					entries := make([]models.TimeEntry, len(page.Tasks))
+					for i, task := range page.Tasks {
+						entries[i] = models.TimeEntryOf(task)
+					}
+					return newConnection(entries, key.offset, key.offset+len(entries) < page.Total, page.Total), nil
+				}, nil
+			},
+		},
+		"totalDuration": &graphql.Field{
+			Type:        graphql.NewNonNull(graphql.Float),
+			Description: "Minutes spent on tasks created within the range, running tasks included.",
+			Args:        rangeArgs,
+			Resolve: func(p graphql.ResolveParams) (any, error) {
+				load := loadersFrom(p.Context).durations.Load(p.Context, rangeOf(p))
+
+				return func() (any, error) {
+					minutes, _, err := load()
+					return minutes, err
+				}, nil
+			},
+		},
+	}
+	for name, f := range userFields {
+		userType.AddFieldConfig(name, f)
+	}
+
+	query := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Query",
+		Fields: graphql.Fields{
+			"user": &graphql.Field{
+				Type: userType,
+				Args: graphql.FieldConfigArgument{
+					"id": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.ID)},
+				},
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					id, _ := p.Args["id"].(string)
+					if _, err := uuid.Parse(id); err != nil {
+						return nil, validation.Field("id", validation.InQuery, report.ErrInvalidID)
+					}
+					return loadUser(p.Context, id), nil
+				},
+			},
+			"users": &graphql.Field{
+				Type: graphql.NewNonNull(userConnectionType),
+				Args: pageArgs(graphql.FieldConfigArgument{
+					"filter": &graphql.ArgumentConfig{Type: graphql.String, DefaultValue: "", Description: "Part of any user field."},
+				}),
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					first, offset, err := page(p)
+					if err != nil {
+						return nil, err
+					}
+
+					filter, _ := p.Args["filter"].(string)
+
+					users, hasNext, err := service.Users(p.Context, first, offset, filter)
+					if err != nil {
+						return nil, err
+					}
+
+					return newConnection(users, offset, hasNext, 0), nil
+				},
+			},
+			"task": &graphql.Field{
+				Type: taskType,
+				Args: graphql.FieldConfigArgument{
+					"id": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.ID)},
+				},
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					id, _ := p.Args["id"].(string)
+
+					task, err := service.Task(p.Context, id)
+					if errors.Is(err, report.ErrTaskNotFound) {
+						return nil, nil
+					}
+					if err != nil {
+						return nil, err
+					}
+
+					return *task, nil
+				},
+			},
+		},
+	})
+
+	return graphql.NewSchema(graphql.SchemaConfig{Query: query})
+}
+
+// connectionType builds the connection and edge types of a node type.
+func connectionType[T any](name string, nodeType, pageInfoType *graphql.Object, withTotal bool) *graphql.Object {
+	edgeType := graphql.NewObject(graphql.ObjectConfig{
+		Name: name + "Edge",
+		Fields: graphql.Fields{
+			"cursor": &graphql.Field{Type: graphql.NewNonNull(graphql.String), Resolve: field(func(e edge[T]) any { return e.Cursor })},
+			"node":   &graphql.Field{Type: graphql.NewNonNull(nodeType), Resolve: field(func(e edge[T]) any { return e.Node })},
+		},
+	})
+
+	fields := graphql.Fields{
+		"edges":    &graphql.Field{Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(edgeType))), Resolve: field(func(c connection[T]) any { return c.Edges })},
+		"pageInfo": &graphql.Field{Type: graphql.NewNonNull(pageInfoType), Resolve: field(func(c connection[T]) any { return c.PageInfo })},
+	}
+	if withTotal {
+		fields["totalCount"] = &graphql.Field{Type: graphql.NewNonNull(graphql.Int), Resolve: field(func(c connection[T]) any { return c.TotalCount })}
+	}
+
+	return graphql.NewObject(graphql.ObjectConfig{Name: name + "Connection", Fields: fields})
+}
+
+// field resolves a field from the source value without touching the DB.
+func field[T any](get func(T) any) graphql.FieldResolveFn {
+	return func(p graphql.ResolveParams) (any, error) {
+		return get(p.Source.(T)), nil
+	}
+}
+
+// loadUser returns a thunk of the user, nil if there is no such user.
+func loadUser(ctx context.Context, userID string) func() (any, error) {
+	load := loadersFrom(ctx).users.Load(ctx, userID)
+
+	return func() (any, error) {
+		user, ok, err := load()
+		if err != nil || !ok {
+			return nil, err
+		}
+		return user, nil
+	}
+}
+
+func rangeOf(p graphql.ResolveParams) rangeKey {
+	from, _ := p.Args["from"].(time.Time)
+	to, _ := p.Args["to"].(time.Time)
+	return rangeKey{userID: p.Source.(models.User).ID, from: from, to: to}
+}
+
+// pageOf returns the key of the page of a connection field within a range.
+func pageOf(p graphql.ResolveParams) (rangeKey, error) {
+	first, offset, err := page(p)
+	if err != nil {
+		return rangeKey{}, err
+	}
+
+	key := rangeOf(p)
+	key.first, key.offset = first, offset
+	return key, nil
+}
@@ -0,0 +1,281 @@
+package idempotency
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/Alhanaqtah/effective-mobile-test-task/internal/controller/apierror"
+	"github.com/Alhanaqtah/effective-mobile-test-task/internal/lib/apikey"
+	"github.com/Alhanaqtah/effective-mobile-test-task/internal/lib/logger/sl"
+	"github.com/Alhanaqtah/effective-mobile-test-task/internal/lib/validation"
+	"github.com/Alhanaqtah/effective-mobile-test-task/internal/models"
+
+	"github.com/go-chi/chi/middleware"
+	"github.com/go-chi/chi/v5"
+)
+
+const (
+	HeaderKey      = "Idempotency-Key"
+	HeaderReplayed = "Idempotent-Replayed"
+
+	maxKeyLength = 255
+
+	// maxBodySize bounds the request bodies read to fingerprint them. It is
+	// the largest body an endpoint accepts, an import file.
+	maxBodySize = 4 << 20
+
+	// lockTimeout is how long a key stays claimed by a request that stopped
+	// renewing it, e.g. because the instance serving it crashed. A request
+	// renews its key every lockRenewal while it is processed, so a retry
+	// never runs alongside it however long it takes.
+	lockTimeout = time.Minute
+	lockRenewal = lockTimeout / 4
+)
+
+// replayedHeaders are the response headers stored with the body and sent
+// again when the response is replayed.
+var replayedHeaders = []string{"Content-Type", "ETag", "Location"}
+
+type Storage interface {
+	ReserveIdempotencyKey(ctx context.Context, key, fingerprint string, ttl, lockTimeout time.Duration) (*models.IdempotencyRecord, bool, error)
+	RenewIdempotencyKey(ctx context.Context, key string, lockTimeout time.Duration) error
+	SaveIdempotentResponse(ctx context.Context, key string, statusCode int, header map[string]string, body []byte) error
+	ReleaseIdempotencyKey(ctx context.Context, key string) error
+	DeleteExpiredIdempotencyKeys(ctx context.Context) (int64, error)
+}
+
+// Middleware makes POST requests carrying an Idempotency-Key header safe to
+// retry. The first request with a key is processed and its response stored
+// for ttl; repeating the same request replays the stored response, while
+// reusing the key for a different request is rejected. Keys are scoped to
+// the API key of the client and the route, so clients cannot collide.
+//
+// The router wraps every POST route that changes data with it, not only user
+// creation and the timer: sync pushes, webhook registrations, imports and
+// batches are retried by clients just the same.
+type Middleware struct {
+	storage Storage
+	ttl     time.Duration
+	log     *slog.Logger
+}
+
+func New(storage Storage, ttl time.Duration, log *slog.Logger) *Middleware {
+	return &Middleware{
+		storage: storage,
+		ttl:     ttl,
+		log:     log,
+	}
+}
+
+func (m *Middleware) Handler(next http.Handler) http.Handler {
+	fn := func(w http.ResponseWriter, r *http.Request) {
+		key := r.Header.Get(HeaderKey)
+		if r.Method != http.MethodPost || key == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		const op = "controller.idempotency.Handler"
+
+		log := m.log.With(
+			slog.String("op", op),
+			slog.String("req_id", middleware.GetReqID(r.Context())),
+			sl.Trace(r.Context()),
+			slog.String("idempotency_key", key),
+		)
+
+		if len(key) > maxKeyLength {
+			log.Error("idempotency key is too long")
+			apierror.Write(w, r, validation.Field(HeaderKey, validation.InHeader, validation.ErrInvalid))
+			return
+		}
+
+		body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxBodySize))
+		if err != nil {
+			log.Error("failed to read request body", sl.Error(err))
+
+			var tooLarge *http.MaxBytesError
+			if errors.As(err, &tooLarge) {
+				apierror.Write(w, r, apierror.ErrBodyTooLarge)
+				return
+			}
+			apierror.Write(w, r, validation.ErrMalformedBody)
+			return
+		}
+		r.Body = io.NopCloser(bytes.NewReader(body))
+
+		fp := fingerprint(r, body)
+		key = scope(r, key)
+
+		record, reserved, err := m.storage.ReserveIdempotencyKey(r.Context(), key, fp, m.ttl, lockTimeout)
+		if err != nil {
+			log.Error("failed to reserve idempotency key", sl.Error(err))
+			apierror.Write(w, r, err)
+			return
+		}
+
+		if !reserved {
+			m.replay(w, r, log, record, fp)
+			return
+		}
+
+		log.Debug("idempotency key reserved")
+
+		completed := false
+		defer func() {
+			if completed {
+				return
+			}
+			// The handler failed or panicked, let the client retry with the same key.
+			if err := m.storage.ReleaseIdempotencyKey(context.WithoutCancel(r.Context()), key); err != nil {
+				log.Error("failed to release idempotency key", sl.Error(err))
+			}
+		}()
+
+		defer m.renew(r.Context(), log, key)()
+
+		var buf bytes.Buffer
+
+		ww := middleware.NewWrapResponseWriter(w, r.ProtoMajor)
+		ww.Tee(&buf)
+
+		next.ServeHTTP(ww, r)
+
+		status := ww.Status()
+		if status == 0 {
+			status = http.StatusOK
+		}
+
+		if status >= http.StatusInternalServerError {
+			log.Debug("request failed, idempotency key released", slog.Int("status", status))
+			return
+		}
+
+		header := make(map[string]string, len(replayedHeaders))
+		for _, name := range replayedHeaders {
+			if v := ww.Header().Get(name); v != "" {
+				header[name] = v
+			}
+		}
+
+		err = m.storage.SaveIdempotentResponse(context.WithoutCancel(r.Context()), key, status, header, buf.Bytes())
+		if err != nil {
+			log.Error("failed to save idempotent response", sl.Error(err))
+			return
+		}
+
+		completed = true
+	}
+	return http.HandlerFunc(fn)
+}
+
+func (m *Middleware) replay(w http.ResponseWriter, r *http.Request, log *slog.Logger, record *models.IdempotencyRecord, fp string) {
+	if record.Fingerprint != fp {
+		log.Error("idempotency key reused with a different request")
+		apierror.Write(w, r, apierror.ErrIdempotencyKeyReused)
+		return
+	}
+
+	if !record.Completed {
+		log.Debug("request with the same idempotency key is in progress")
+		w.Header().Set("Retry-After", strconv.Itoa(1))
+		apierror.Write(w, r, apierror.ErrIdempotencyKeyInProgress)
+		return
+	}
+
+	log.Debug("replaying stored response", slog.Int("status", record.StatusCode))
+
+	for name, v := range record.Header {
+		w.Header().Set(name, v)
+	}
+	w.Header().Set(HeaderReplayed, "true")
+	w.WriteHeader(record.StatusCode)
+	w.Write(record.Body)
+}
+
+// renew keeps key locked until the returned function is called. The handler
+// goes on when the client disconnects, so the lock outlives the request.
+func (m *Middleware) renew(ctx context.Context, log *slog.Logger, key string) (stop func()) {
+	ctx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+
+		ticker := time.NewTicker(lockRenewal)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if err := m.storage.RenewIdempotencyKey(ctx, key, lockTimeout); err != nil {
+					log.Error("failed to renew idempotency key", sl.Error(err))
+				}
+			}
+		}
+	}()
+
+	return func() {
+		cancel()
+		<-done
+	}
+}
+
+// Purge periodically removes expired idempotency records until ctx is done.
+func (m *Middleware) Purge(ctx context.Context, interval time.Duration) {
+	const op = "controller.idempotency.Purge"
+
+	log := m.log.With(slog.String("op", op))
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			n, err := m.storage.DeleteExpiredIdempotencyKeys(ctx)
+			if err != nil {
+				log.Error("failed to delete expired idempotency keys", sl.Error(err))
+				continue
+			}
+			log.Debug("expired idempotency keys deleted", slog.Int64("count", n))
+		}
+	}
+}
+
+// scope prefixes key with the client and the route of r. Requests without an
+// API key share the anonymous scope of their route.
+func scope(r *http.Request, key string) string {
+	return apikey.Digest(r) + " " + r.Method + " " + route(r) + " " + key
+}
+
+// fingerprint identifies a request by its method, path and body, so that the
+// same key cannot be replayed against another endpoint.
+func fingerprint(r *http.Request, body []byte) string {
+	h := sha256.New()
+	h.Write([]byte(r.Method + " " + route(r) + "\n"))
+	h.Write(body)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// route returns the path of r below the API version it is served under, so
+// a route and its alias at the root share their keys. It is the path left to
+// route once the version is mounted, or the whole path at the root.
+func route(r *http.Request) string {
+	if rctx := chi.RouteContext(r.Context()); rctx != nil && rctx.RoutePath != "" {
+		return rctx.RoutePath
+	}
+	return r.URL.Path
+}
@@ -0,0 +1,140 @@
+package idempotency_test
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Alhanaqtah/effective-mobile-test-task/internal/controller/idempotency"
+	"github.com/Alhanaqtah/effective-mobile-test-task/internal/models"
+)
+
+type memoryStorage struct {
+	mu      sync.Mutex
+	records map[string]*models.IdempotencyRecord
+}
+
+func (s *memoryStorage) ReserveIdempotencyKey(_ context.Context, key, fingerprint string, _, _ time.Duration) (*models.IdempotencyRecord, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if record, ok := s.records[key]; ok {
+		return record, false, nil
+	}
+	s.records[key] = &models.IdempotencyRecord{Key: key, Fingerprint: fingerprint}
+	return s.records[key], true, nil
+}
+
+func (s *memoryStorage) RenewIdempotencyKey(context.Context, string, time.Duration) error {
+	return nil
+}
+
+func (s *memoryStorage) SaveIdempotentResponse(_ context.Context, key string, statusCode int, header map[string]string, body []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	record := s.records[key]
+	record.Completed = true
+	record.StatusCode = statusCode
+	record.Header = header
+	record.Body = body
+	return nil
+}
+
+func (s *memoryStorage) ReleaseIdempotencyKey(_ context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.records, key)
+	return nil
+}
+
+func (s *memoryStorage) DeleteExpiredIdempotencyKeys(context.Context) (int64, error) {
+	return 0, nil
+}
+
+func newHandler(calls *int) http.Handler {
+	m := idempotency.New(&memoryStorage{records: make(map[string]*models.IdempotencyRecord)}, time.Hour, slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+	return m.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*calls++
+		io.Copy(io.Discard, r.Body)
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("ETag", `"1"`)
+		w.Header().Set("Location", "/v1/tasks/1")
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"id":1}`))
+	}))
+}
+
+func post(h http.Handler, key, token, body string) *httptest.ResponseRecorder {
+	r := httptest.NewRequest(http.MethodPost, "/v1/tasks", strings.NewReader(body))
+	r.Header.Set(idempotency.HeaderKey, key)
+	if token != "" {
+		r.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+	return w
+}
+
+func TestReplay(t *testing.T) {
+	var calls int
+	h := newHandler(&calls)
+
+	post(h, "k", "", `{}`)
+	w := post(h, "k", "", `{}`)
+
+	if calls != 1 {
+		t.Fatalf("handler called %d times, want 1", calls)
+	}
+	if w.Code != http.StatusCreated || w.Body.String() != `{"id":1}` {
+		t.Errorf("replay = %d %s, want 201 {\"id\":1}", w.Code, w.Body)
+	}
+	for name, want := range map[string]string{
+		"Content-Type":             "application/json",
+		"ETag":                     `"1"`,
+		"Location":                 "/v1/tasks/1",
+		idempotency.HeaderReplayed: "true",
+	} {
+		if got := w.Header().Get(name); got != want {
+			t.Errorf("replay header %s = %q, want %q", name, got, want)
+		}
+	}
+}
+
+func TestScopedPerClient(t *testing.T) {
+	var calls int
+	h := newHandler(&calls)
+
+	post(h, "k", "first", `{}`)
+	w := post(h, "k", "second", `{"other":true}`)
+
+	if calls != 2 {
+		t.Errorf("handler called %d times, want 2", calls)
+	}
+	if w.Code != http.StatusCreated || w.Header().Get(idempotency.HeaderReplayed) != "" {
+		t.Errorf("second client got %d replayed=%q, want a fresh 201", w.Code, w.Header().Get(idempotency.HeaderReplayed))
+	}
+}
+
+func TestBodyTooLarge(t *testing.T) {
+	var calls int
+	h := newHandler(&calls)
+
+	w := post(h, "k", "", strings.Repeat("a", 4<<20+1))
+
+	if calls != 0 {
+		t.Errorf("handler called %d times, want 0", calls)
+	}
+	if w.Code != http.StatusRequestEntityTooLarge {
+		t.Errorf("status = %d, want %d", w.Code, http.StatusRequestEntityTooLarge)
+	}
+}
@@ -0,0 +1,369 @@
+// Package openapi validates requests against the Swagger specification of
+// the API before they reach the controllers, so handlers receive path, query
+// and body values that already match the documented schema.
+package openapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"mime"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/Alhanaqtah/effective-mobile-test-task/internal/controller/apierror"
+	"github.com/Alhanaqtah/effective-mobile-test-task/internal/lib/logger/sl"
+	"github.com/Alhanaqtah/effective-mobile-test-task/internal/lib/validation"
+
+	"github.com/getkin/kin-openapi/openapi2"
+	"github.com/getkin/kin-openapi/openapi2conv"
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/getkin/kin-openapi/routers"
+	"github.com/getkin/kin-openapi/routers/legacy"
+	"github.com/go-chi/chi/middleware"
+	"github.com/go-chi/chi/v5"
+	uuidlib "github.com/google/uuid"
+)
+
+// bodyMediaType is the only media type of request bodies validated against
+// the spec. Patch documents and import files have their own structure,
+// which their handlers check.
+const bodyMediaType = "application/json"
+
+// maxBodySize limits the size of the bodies read to validate them, which
+// covers a batch of the largest size.
+const maxBodySize = 1 << 20
+
+func init() {
+	openapi3.DefineStringFormatCallback("uuid", func(s string) error {
+		_, err := uuidlib.Parse(s)
+		return err
+	})
+}
+
+// Validator rejects requests that do not match the specification with a
+// validation_failed problem listing every offending field. Requests to
+// routes missing from the specification are passed through unchanged.
+type Validator struct {
+	router routers.Router
+	log    *slog.Logger
+}
+
+// New returns a validator of requests against spec, a Swagger 2.0 document
+// as generated by make docs.
+//
+// Swag cannot declare additionalProperties: false, so objects of request
+// bodies that list their properties are treated as closed and unknown fields
+// are rejected. Objects with additionalProperties or without properties,
+// e.g. GraphQL variables or sync patches, stay open.
+func New(spec []byte, log *slog.Logger) (*Validator, error) {
+	const op = "controller.openapi.New"
+
+	var doc2 openapi2.T
+	if err := json.Unmarshal(spec, &doc2); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	doc, err := openapi2conv.ToV3(&doc2)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	// Routes are matched on the path relative to the version, which is
+	// also served at the root, so the base path is dropped.
+	doc.Servers = nil
+	for _, item := range doc.Paths.Map() {
+		for _, operation := range item.Operations() {
+			if operation.RequestBody == nil || operation.RequestBody.Value == nil {
+				continue
+			}
+			if media := operation.RequestBody.Value.Content.Get(bodyMediaType); media != nil && media.Schema != nil {
+				closeSchema(media.Schema.Value, map[*openapi3.Schema]bool{})
+			}
+		}
+	}
+
+	router, err := legacy.NewRouter(doc)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return &Validator{
+		router: router,
+		log:    log,
+	}, nil
+}
+
+func closeSchema(schema *openapi3.Schema, seen map[*openapi3.Schema]bool) {
+	if schema == nil || seen[schema] {
+		return
+	}
+	seen[schema] = true
+
+	if len(schema.Properties) > 0 && schema.AdditionalProperties.Has == nil && schema.AdditionalProperties.Schema == nil {
+		closed := false
+		schema.AdditionalProperties.Has = &closed
+	}
+
+	for _, property := range schema.Properties {
+		closeSchema(property.Value, seen)
+	}
+	if schema.Items != nil {
+		closeSchema(schema.Items.Value, seen)
+	}
+	for _, refs := range []openapi3.SchemaRefs{schema.AllOf, schema.AnyOf, schema.OneOf} {
+		for _, ref := range refs {
+			closeSchema(ref.Value, seen)
+		}
+	}
+}
+
+func (v *Validator) Handler(next http.Handler) http.Handler {
+	fn := func(w http.ResponseWriter, r *http.Request) {
+		route, pathParams, err := v.router.FindRoute(relative(r))
+		if err != nil {
+			// Not documented, e.g. the docs themselves; routing decides
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		const op = "controller.openapi.Handler"
+
+		log := v.log.With(
+			slog.String("op", op),
+			slog.String("req_id", middleware.GetReqID(r.Context())),
+			sl.Trace(r.Context()),
+		)
+
+		// The request as validated: the body is read once for the validator
+		// and once more for the handler
+		req := r
+		exclude := excludesBody(r, route)
+		if !exclude {
+			body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxBodySize))
+			if err != nil {
+				log.Debug("failed to read request body", sl.Error(err))
+
+				var tooLarge *http.MaxBytesError
+				if errors.As(err, &tooLarge) {
+					apierror.Write(w, r, apierror.ErrBodyTooLarge)
+					return
+				}
+				apierror.Write(w, r, validation.ErrMalformedBody)
+				return
+			}
+			r.Body = io.NopCloser(bytes.NewReader(body))
+
+			req = r.Clone(r.Context())
+			req.Body = io.NopCloser(bytes.NewReader(body))
+			// Handlers decode a body without a content type as JSON
+			if req.Header.Get("Content-Type") == "" {
+				req.Header.Set("Content-Type", bodyMediaType)
+			}
+		}
+
+		input := &openapi3filter.RequestValidationInput{
+			Request:    req,
+			PathParams: pathParams,
+			Route:      route,
+			Options: &openapi3filter.Options{
+				MultiError:          true,
+				SkipSettingDefaults: true,
+				ExcludeRequestBody:  exclude,
+				AuthenticationFunc:  openapi3filter.NoopAuthenticationFunc,
+			},
+		}
+
+		if err := openapi3filter.ValidateRequest(r.Context(), input); err != nil {
+			log.Debug("request does not match the spec", slog.String("error", err.Error()))
+			apierror.Write(w, r, convert(err))
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	}
+
+	return http.HandlerFunc(fn)
+}
+
+// relative returns r with the path relative to the version, which the
+// router of the version is mounted under.
+func relative(r *http.Request) *http.Request {
+	rctx := chi.RouteContext(r.Context())
+	if rctx == nil || rctx.RoutePath == "" {
+		return r
+	}
+
+	u := *r.URL
+	u.Path, u.RawPath = rctx.RoutePath, ""
+	rel := *r
+	rel.URL = &u
+	return &rel
+}
+
+// excludesBody reports whether the body of r is left to its handler: bodies
+// of routes that take none and documented bodies of media types other than
+// JSON, e.g. patch documents or import files. Bodies without a content type
+// are validated as JSON, and bodies of undocumented media types are validated
+// too, so they are rejected.
+func excludesBody(r *http.Request, route *routers.Route) bool {
+	body := route.Operation.RequestBody
+	if body == nil || body.Value == nil {
+		return true
+	}
+
+	contentType := r.Header.Get("Content-Type")
+	if contentType == "" {
+		return false
+	}
+
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil || mediaType == bodyMediaType {
+		return false
+	}
+
+	return body.Value.Content.Get(mediaType) != nil
+}
+
+// convert translates an error of openapi3filter into the errors the API
+// reports to clients.
+func convert(err error) error {
+	var errs validation.Errors
+
+	for _, e := range unpack(err) {
+		var reqErr *openapi3filter.RequestError
+		if !errors.As(e, &reqErr) {
+			return validation.ErrMalformedBody
+		}
+
+		if reqErr.Parameter != nil {
+			errs = append(errs, validation.Field(reqErr.Parameter.Name, reqErr.Parameter.In, parameterError(reqErr.Err)))
+			continue
+		}
+
+		// A body that cannot be checked has no fields to report
+		fields, err := bodyErrors(reqErr)
+		if err != nil {
+			return err
+		}
+		errs = append(errs, fields...)
+	}
+
+	if err := errs.Err(); err != nil {
+		return err
+	}
+
+	return validation.ErrMalformedBody
+}
+
+// unpack flattens the multi errors openapi3filter returns with MultiError.
+// Only multi errors themselves are flattened: a RequestError unwraps to the
+// errors of its value, which must stay attached to it.
+func unpack(err error) []error {
+	me, ok := err.(openapi3.MultiError)
+	if !ok {
+		return []error{err}
+	}
+
+	var errs []error
+	for _, e := range me {
+		errs = append(errs, unpack(e)...)
+	}
+	return errs
+}
+
+func parameterError(err error) error {
+	if errors.Is(err, openapi3filter.ErrInvalidRequired) || errors.Is(err, openapi3filter.ErrInvalidEmptyValue) {
+		return validation.ErrRequired
+	}
+
+	var schemaErr *openapi3.SchemaError
+	if errors.As(err, &schemaErr) {
+		return schemaError(schemaErr)
+	}
+
+	return validation.ErrInvalid
+}
+
+// bodyErrors returns the field errors of a request body, or the error of the
+// whole body if it could not be checked.
+func bodyErrors(reqErr *openapi3filter.RequestError) (validation.Errors, error) {
+	if reqErr.Err == nil {
+		// The route does not accept the content type of the body
+		if strings.HasPrefix(reqErr.Reason, "header Content-Type") {
+			return nil, apierror.ErrUnsupportedMediaType
+		}
+		return nil, validation.ErrMalformedBody
+	}
+
+	if errors.Is(reqErr.Err, openapi3filter.ErrInvalidRequired) {
+		return nil, validation.ErrMalformedBody
+	}
+
+	var schemaErrs []*openapi3.SchemaError
+	for _, e := range unpack(reqErr.Err) {
+		var schemaErr *openapi3.SchemaError
+		if !errors.As(e, &schemaErr) {
+			return nil, validation.ErrMalformedBody
+		}
+		schemaErrs = append(schemaErrs, schemaErr)
+	}
+
+	var errs validation.Errors
+	seen := map[string]bool{}
+	for _, schemaErr := range schemaErrs {
+		for _, field := range bodyFields(schemaErr) {
+			if seen[field] {
+				continue
+			}
+			seen[field] = true
+			errs = append(errs, validation.Field(field, validation.InBody, schemaError(schemaErr)))
+		}
+	}
+
+	return errs, nil
+}
+
+// bodyFields returns the dotted paths of the body fields schemaErr is about.
+// An unknown field is reported on its parent object, which lists every
+// unknown field, so they are found by comparing with the schema.
+func bodyFields(schemaErr *openapi3.SchemaError) []string {
+	path := strings.Join(schemaErr.JSONPointer(), ".")
+
+	if schemaErr.SchemaField != "properties" {
+		return []string{path}
+	}
+
+	object, _ := schemaErr.Value.(map[string]any)
+
+	var fields []string
+	for key := range object {
+		if _, ok := schemaErr.Schema.Properties[key]; ok {
+			continue
+		}
+		if path != "" {
+			key = path + "." + key
+		}
+		fields = append(fields, key)
+	}
+	sort.Strings(fields)
+
+	return fields
+}
+
+func schemaError(err *openapi3.SchemaError) error {
+	switch err.SchemaField {
+	case "required":
+		return validation.ErrRequired
+	case "properties", "readOnly":
+		return validation.ErrUnknownField
+	case "maxLength", "maxItems":
+		return validation.ErrTooLong
+	}
+	return validation.ErrInvalid
+}
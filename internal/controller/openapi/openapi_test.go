@@ -0,0 +1,68 @@
+package openapi_test
+
+import (
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	v1docs "github.com/Alhanaqtah/effective-mobile-test-task/docs/v1"
+	"github.com/Alhanaqtah/effective-mobile-test-task/internal/controller/openapi"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// TestBody checks that JSON bodies are validated with or without a content
+// type, that oversized ones are rejected before they are read whole and that
+// the handler still receives the body.
+func TestBody(t *testing.T) {
+	log := slog.New(slog.NewTextHandler(io.Discard, nil))
+	v, err := openapi.New(v1docs.Spec, log)
+	if err != nil {
+		t.Fatalf("new validator: %v", err)
+	}
+
+	r := chi.NewRouter()
+	r.Route("/api/v1", func(r chi.Router) {
+		r.Use(v.Handler)
+		r.Post("/users", func(w http.ResponseWriter, r *http.Request) {
+			_, _ = io.Copy(w, r.Body)
+		})
+	})
+
+	const valid = `{"passportNumber":"1234 567890"}`
+
+	tests := []struct {
+		name        string
+		contentType string
+		body        string
+		status      int
+	}{
+		{"json", "application/json", valid, http.StatusOK},
+		{"no content type", "", valid, http.StatusOK},
+		{"unknown field", "application/json", `{"passportNumber":"1234 567890","name":"Ivan"}`, http.StatusBadRequest},
+		{"unknown field without content type", "", `{"passportNumber":"1234 567890","name":"Ivan"}`, http.StatusBadRequest},
+		{"empty without content type", "", "", http.StatusBadRequest},
+		{"undocumented media type", "text/plain", valid, http.StatusUnsupportedMediaType},
+		{"too large", "application/json", `{"passportNumber":"` + strings.Repeat("1", 1<<20) + `"}`, http.StatusRequestEntityTooLarge},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/users", strings.NewReader(tt.body))
+			if tt.contentType != "" {
+				req.Header.Set("Content-Type", tt.contentType)
+			}
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			if w.Code != tt.status {
+				t.Fatalf("status = %d, want %d: %s", w.Code, tt.status, w.Body)
+			}
+			if tt.status == http.StatusOK && w.Body.String() != tt.body {
+				t.Errorf("handler read %q, want %q", w.Body, tt.body)
+			}
+		})
+	}
+}
@@ -0,0 +1,86 @@
+package ratelimit
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+)
+
+type bucket struct {
+	tokens    float64
+	updatedAt time.Time
+	fullAt    time.Time
+}
+
+// MemoryStore keeps buckets in the memory of the instance, so every
+// instance of the service limits the requests it serves on its own.
+type MemoryStore struct {
+	mu      sync.Mutex
+	buckets map[string]*bucket
+	now     func() time.Time
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		buckets: make(map[string]*bucket),
+		now:     time.Now,
+	}
+}
+
+func (s *MemoryStore) TakeRateLimitToken(_ context.Context, key string, rate float64, burst int) (bool, float64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+
+	b, ok := s.buckets[key]
+	if !ok {
+		b = &bucket{tokens: float64(burst), updatedAt: now}
+		s.buckets[key] = b
+	}
+
+	b.tokens = math.Min(float64(burst), b.tokens+rate*now.Sub(b.updatedAt).Seconds())
+	b.updatedAt = now
+
+	taken := b.tokens >= 1
+	if taken {
+		b.tokens--
+	}
+	b.fullAt = now.Add(time.Duration((float64(burst) - b.tokens) / rate * float64(time.Second)))
+
+	return taken, b.tokens, nil
+}
+
+func (s *MemoryStore) ReturnRateLimitToken(_ context.Context, key string, rate float64, burst int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	b, ok := s.buckets[key]
+	if !ok {
+		// Purged as full already
+		return nil
+	}
+
+	b.tokens = math.Min(float64(burst), b.tokens+1)
+	b.fullAt = b.updatedAt.Add(time.Duration((float64(burst) - b.tokens) / rate * float64(time.Second)))
+
+	return nil
+}
+
+func (s *MemoryStore) DeleteFullRateLimitBuckets(_ context.Context) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+
+	var n int64
+	for key, b := range s.buckets {
+		if !b.fullAt.After(now) {
+			delete(s.buckets, key)
+			n++
+		}
+	}
+
+	return n, nil
+}
@@ -0,0 +1,111 @@
+package ratelimit
+
+import (
+	"context"
+	"math"
+	"testing"
+	"time"
+)
+
+// clock is a manual clock for the memory store.
+type clock struct {
+	now time.Time
+}
+
+func (c *clock) Now() time.Time {
+	return c.now
+}
+
+func (c *clock) Advance(d time.Duration) {
+	c.now = c.now.Add(d)
+}
+
+func TestMemoryStoreRefill(t *testing.T) {
+	c := &clock{now: time.Date(2026, time.October, 19, 0, 0, 0, 0, time.UTC)}
+	s := NewMemoryStore()
+	s.now = c.Now
+
+	// 3 requests per 3 seconds: a token is added every second
+	const rate, burst = 1.0, 3
+
+	steps := []struct {
+		name      string
+		advance   time.Duration
+		taken     bool
+		remaining float64
+	}{
+		{"a missing bucket is full", 0, true, 2},
+		{"burst", 0, true, 1},
+		{"last token", 0, true, 0},
+		{"empty", 0, false, 0},
+		{"half a token", 500 * time.Millisecond, false, 0.5},
+		{"a failed take keeps the refill", time.Second, true, 0.5},
+		{"refill stops at the burst", time.Hour, true, 2},
+	}
+	for _, step := range steps {
+		c.Advance(step.advance)
+
+		taken, remaining, err := s.TakeRateLimitToken(context.Background(), "key", rate, burst)
+		if err != nil {
+			t.Fatalf("%s: %v", step.name, err)
+		}
+		if taken != step.taken || math.Abs(remaining-step.remaining) > 1e-9 {
+			t.Fatalf("%s: taken %v with %v left, want %v with %v left", step.name, taken, remaining, step.taken, step.remaining)
+		}
+	}
+
+	// The bucket holds 2 tokens and is full in a second
+	c.Advance(999 * time.Millisecond)
+	if n, _ := s.DeleteFullRateLimitBuckets(context.Background()); n != 0 {
+		t.Fatalf("deleted %d buckets before they refilled", n)
+	}
+	c.Advance(time.Millisecond)
+	if n, _ := s.DeleteFullRateLimitBuckets(context.Background()); n != 1 {
+		t.Fatalf("deleted %d buckets once refilled, want 1", n)
+	}
+}
+
+func TestMemoryStoreKeys(t *testing.T) {
+	s := NewMemoryStore()
+
+	for _, key := range []string{"a", "b"} {
+		taken, _, err := s.TakeRateLimitToken(context.Background(), key, 1, 1)
+		if err != nil || !taken {
+			t.Fatalf("take from %q: %v, %v", key, taken, err)
+		}
+	}
+
+	if taken, _, _ := s.TakeRateLimitToken(context.Background(), "a", 1, 1); taken {
+		t.Fatal("took a second token from a bucket of one")
+	}
+}
+
+func TestMemoryStoreReturn(t *testing.T) {
+	c := &clock{now: time.Date(2026, time.October, 19, 0, 0, 0, 0, time.UTC)}
+	s := NewMemoryStore()
+	s.now = c.Now
+
+	ctx := context.Background()
+	for range 2 {
+		s.TakeRateLimitToken(ctx, "key", 1, 2)
+	}
+
+	if err := s.ReturnRateLimitToken(ctx, "key", 1, 2); err != nil {
+		t.Fatalf("return: %v", err)
+	}
+	taken, remaining, _ := s.TakeRateLimitToken(ctx, "key", 1, 2)
+	if !taken || remaining != 0 {
+		t.Fatalf("take after a return: taken %v with %v left, want true with 0 left", taken, remaining)
+	}
+
+	// Returns do not fill a bucket over its burst
+	for range 3 {
+		s.ReturnRateLimitToken(ctx, "key", 1, 2)
+	}
+	if n, _ := s.DeleteFullRateLimitBuckets(ctx); n != 1 {
+		t.Fatalf("deleted %d buckets once refilled by returns, want 1", n)
+	}
+	if err := s.ReturnRateLimitToken(ctx, "key", 1, 2); err != nil {
+		t.Fatalf("return to a purged bucket: %v", err)
+	}
+}
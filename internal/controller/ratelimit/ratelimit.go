@@ -0,0 +1,323 @@
+// Package ratelimit limits the rate of requests of every client with token
+// buckets. Routes are grouped into classes with their own limits, so
+// expensive requests run out of tokens without blocking cheap ones.
+package ratelimit
+
+import (
+	"context"
+	"log/slog"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Alhanaqtah/effective-mobile-test-task/internal/controller/apierror"
+	"github.com/Alhanaqtah/effective-mobile-test-task/internal/lib/apikey"
+	"github.com/Alhanaqtah/effective-mobile-test-task/internal/lib/logger/sl"
+
+	"github.com/go-chi/chi/middleware"
+	"github.com/go-chi/chi/v5"
+)
+
+const (
+	HeaderLimit     = "RateLimit-Limit"
+	HeaderRemaining = "RateLimit-Remaining"
+	HeaderReset     = "RateLimit-Reset"
+	HeaderPolicy    = "RateLimit-Policy"
+)
+
+// Limit allows Count requests per Period, all of which may be sent at once.
+type Limit struct {
+	Count  int
+	Period time.Duration
+}
+
+// rate returns the tokens added to a bucket per second.
+func (l Limit) rate() float64 {
+	return float64(l.Count) / l.Period.Seconds()
+}
+
+// Route is a chi route pattern relative to the version, e.g. "/users/{uuid}".
+type Route struct {
+	Method  string
+	Pattern string
+}
+
+// Class is a group of routes and gRPC methods sharing a limit. A class
+// without routes and methods matches every request.
+type Class struct {
+	Name   string
+	Limit  Limit
+	Routes []Route
+	// Methods are full names of gRPC methods, e.g.
+	// "/timetracker.v1.UserService/CreateUser"
+	Methods []string
+}
+
+type Store interface {
+	// TakeRateLimitToken takes a token from the bucket key, which holds up to
+	// burst tokens and gains rate tokens per second. It reports whether a
+	// token was taken and returns the tokens left in the bucket.
+	TakeRateLimitToken(ctx context.Context, key string, rate float64, burst int) (bool, float64, error)
+	// ReturnRateLimitToken puts back a token taken from the bucket key.
+	ReturnRateLimitToken(ctx context.Context, key string, rate float64, burst int) error
+	// DeleteFullRateLimitBuckets deletes the buckets that have refilled, which
+	// are the same as missing ones.
+	DeleteFullRateLimitBuckets(ctx context.Context) (int64, error)
+}
+
+type class struct {
+	Class
+	routes  *chi.Mux
+	methods map[string]bool
+}
+
+// any reports whether the class matches every request.
+func (c class) any() bool {
+	return c.routes == nil && c.methods == nil
+}
+
+// Limiter counts every request against the client IP and, if the request
+// carries an API key, against the key, in the buckets of the class of its
+// route or gRPC method. A request is rejected if either bucket is empty and
+// then uses up no token of the other. API keys are not verified, so keyed
+// requests stay limited by their IP: otherwise a client would bypass the
+// limits by sending a new key with every request.
+type Limiter struct {
+	store   Store
+	classes []class
+	log     *slog.Logger
+}
+
+// New returns a limiter of requests in classes, matched in order. Requests
+// matching none of them are not limited.
+func New(store Store, classes []Class, log *slog.Logger) *Limiter {
+	l := &Limiter{
+		store: store,
+		log:   log,
+	}
+
+	noop := http.HandlerFunc(func(http.ResponseWriter, *http.Request) {})
+	for _, c := range classes {
+		var routes *chi.Mux
+		if len(c.Routes) > 0 {
+			routes = chi.NewRouter()
+			for _, route := range c.Routes {
+				routes.Method(route.Method, route.Pattern, noop)
+			}
+		}
+
+		var methods map[string]bool
+		if len(c.Methods) > 0 {
+			methods = make(map[string]bool, len(c.Methods))
+			for _, method := range c.Methods {
+				methods[method] = true
+			}
+		}
+
+		l.classes = append(l.classes, class{Class: c, routes: routes, methods: methods})
+	}
+
+	return l
+}
+
+// Handler limits the requests to the routes of a version. It must be used
+// on the router of the version, so the path relative to the version is
+// known before routing.
+func (l *Limiter) Handler(next http.Handler) http.Handler {
+	fn := func(w http.ResponseWriter, r *http.Request) {
+		c, ok := l.match(r)
+		if !ok {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		const op = "controller.ratelimit.Handler"
+
+		log := l.log.With(
+			slog.String("op", op),
+			slog.String("req_id", middleware.GetReqID(r.Context())),
+			sl.Trace(r.Context()),
+			slog.String("class", c.Name),
+		)
+
+		keys := clientKeys(clientIP(r), apikey.Digest(r))
+
+		taken, remaining, err := l.take(r.Context(), c, keys)
+		if err != nil {
+			// Failing open: an outage of the store must not take the API down
+			log.Error("failed to take rate limit token", sl.Error(err))
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		rate := c.Limit.rate()
+		w.Header().Set(HeaderLimit, strconv.Itoa(c.Limit.Count))
+		w.Header().Set(HeaderRemaining, strconv.Itoa(int(math.Floor(remaining))))
+		w.Header().Set(HeaderReset, seconds((float64(c.Limit.Count)-remaining)/rate))
+		w.Header().Set(HeaderPolicy, strconv.Itoa(c.Limit.Count)+";w="+seconds(c.Limit.Period.Seconds()))
+
+		if !taken {
+			log.Info("rate limit exceeded", slog.Any("keys", keys))
+			w.Header().Set("Retry-After", seconds((1-remaining)/rate))
+			apierror.Write(w, r, apierror.ErrRateLimited)
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	}
+
+	return http.HandlerFunc(fn)
+}
+
+// Call limits a gRPC call of method by the client at ip, with the digest
+// of its API key or "". It returns apierror.ErrRateLimited if the client is
+// over the limit of the class of the method.
+func (l *Limiter) Call(ctx context.Context, method, ip, key string) error {
+	c, ok := l.matchMethod(method)
+	if !ok {
+		return nil
+	}
+
+	const op = "controller.ratelimit.Call"
+
+	log := l.log.With(
+		slog.String("op", op),
+		slog.String("method", method),
+		sl.Trace(ctx),
+		slog.String("class", c.Name),
+	)
+
+	keys := clientKeys(ip, key)
+
+	taken, _, err := l.take(ctx, c, keys)
+	if err != nil {
+		// Failing open, as for HTTP requests
+		log.Error("failed to take rate limit token", sl.Error(err))
+		return nil
+	}
+
+	if !taken {
+		log.Info("rate limit exceeded", slog.Any("keys", keys))
+		return apierror.ErrRateLimited
+	}
+
+	return nil
+}
+
+// take takes a token from the bucket of every key in class c. It reports
+// whether all of them had one and returns the fewest tokens left. If one of
+// them is empty, the tokens taken from the others are put back, so a
+// rejected request is not counted.
+func (l *Limiter) take(ctx context.Context, c class, keys []string) (bool, float64, error) {
+	remaining := float64(c.Limit.Count)
+	for i, key := range keys {
+		ok, tokens, err := l.store.TakeRateLimitToken(ctx, c.Name+":"+key, c.Limit.rate(), c.Limit.Count)
+		if err != nil {
+			return false, 0, err
+		}
+		if !ok {
+			l.putBack(ctx, c, keys[:i])
+			return false, tokens, nil
+		}
+		remaining = math.Min(remaining, tokens)
+	}
+
+	return true, remaining, nil
+}
+
+// putBack returns the tokens taken from the buckets of keys in class c.
+func (l *Limiter) putBack(ctx context.Context, c class, keys []string) {
+	const op = "controller.ratelimit.putBack"
+
+	for _, key := range keys {
+		if err := l.store.ReturnRateLimitToken(ctx, c.Name+":"+key, c.Limit.rate(), c.Limit.Count); err != nil {
+			l.log.Error("failed to return rate limit token", slog.String("op", op), sl.Trace(ctx), sl.Error(err))
+		}
+	}
+}
+
+// Purge deletes refilled buckets every interval until ctx is done.
+func (l *Limiter) Purge(ctx context.Context, interval time.Duration) {
+	const op = "controller.ratelimit.Purge"
+
+	log := l.log.With(slog.String("op", op))
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			n, err := l.store.DeleteFullRateLimitBuckets(ctx)
+			if err != nil {
+				log.Error("failed to purge rate limit buckets", sl.Error(err))
+				continue
+			}
+			if n > 0 {
+				log.Debug("purged rate limit buckets", slog.Int64("count", n))
+			}
+		}
+	}
+}
+
+// match returns the class of the route requested by r.
+func (l *Limiter) match(r *http.Request) (class, bool) {
+	path := r.URL.Path
+	if rctx := chi.RouteContext(r.Context()); rctx != nil && rctx.RoutePath != "" {
+		path = rctx.RoutePath
+	}
+	// chi serves /users/ and /users alike
+	if path != "/" {
+		path = strings.TrimSuffix(path, "/")
+	}
+
+	for _, c := range l.classes {
+		if c.any() || c.routes != nil && c.routes.Match(chi.NewRouteContext(), r.Method, path) {
+			return c, true
+		}
+	}
+
+	return class{}, false
+}
+
+// matchMethod returns the class of the gRPC method.
+func (l *Limiter) matchMethod(method string) (class, bool) {
+	for _, c := range l.classes {
+		if c.any() || c.methods[method] {
+			return c, true
+		}
+	}
+
+	return class{}, false
+}
+
+// clientKeys returns the keys of the buckets of a client at ip with the
+// digest of its API key or "".
+func clientKeys(ip, key string) []string {
+	keys := []string{"ip:" + ip}
+	if key != "" {
+		keys = append(keys, "key:"+key)
+	}
+	return keys
+}
+
+// clientIP returns the client address resolved by realip, which is the
+// address of the connection unless it comes from a trusted proxy.
+func clientIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+// seconds formats a delay as whole seconds, rounded up so clients waiting
+// for it find a token.
+func seconds(s float64) string {
+	return strconv.Itoa(int(math.Ceil(math.Max(s, 0))))
+}
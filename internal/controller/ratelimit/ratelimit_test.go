@@ -0,0 +1,193 @@
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestHandlerHeaders(t *testing.T) {
+	c := &clock{now: time.Date(2026, time.October, 19, 0, 0, 0, 0, time.UTC)}
+	store := NewMemoryStore()
+	store.now = c.Now
+
+	log := slog.New(slog.NewTextHandler(io.Discard, nil))
+	// 2 requests per minute: a token every 30 seconds
+	l := New(store, []Class{
+		{Name: "default", Limit: Limit{Count: 2, Period: time.Minute}},
+	}, log)
+	h := l.Handler(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}))
+
+	steps := []struct {
+		name       string
+		advance    time.Duration
+		addr       string
+		status     int
+		remaining  string
+		reset      string
+		retryAfter string
+	}{
+		{"first", 0, "203.0.113.7:5000", http.StatusOK, "1", "30", ""},
+		{"last token", 0, "203.0.113.7:5000", http.StatusOK, "0", "60", ""},
+		{"empty", 0, "203.0.113.7:5000", http.StatusTooManyRequests, "0", "60", "30"},
+		{"half a token", 15 * time.Second, "203.0.113.7:5001", http.StatusTooManyRequests, "0", "45", "15"},
+		{"another client", 0, "203.0.113.8:5000", http.StatusOK, "1", "30", ""},
+		{"refilled", 15 * time.Second, "203.0.113.7:5000", http.StatusOK, "0", "60", ""},
+	}
+	for _, step := range steps {
+		c.Advance(step.advance)
+
+		r := httptest.NewRequest(http.MethodGet, "/users", nil)
+		r.RemoteAddr = step.addr
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, r)
+
+		if w.Code != step.status {
+			t.Fatalf("%s: status = %d, want %d", step.name, w.Code, step.status)
+		}
+
+		want := map[string]string{
+			HeaderLimit:     "2",
+			HeaderRemaining: step.remaining,
+			HeaderReset:     step.reset,
+			HeaderPolicy:    "2;w=60",
+			"Retry-After":   step.retryAfter,
+		}
+		for header, v := range want {
+			if got := w.Header().Get(header); got != v {
+				t.Errorf("%s: %s = %q, want %q", step.name, header, got, v)
+			}
+		}
+	}
+}
+
+func TestHandlerKey(t *testing.T) {
+	log := slog.New(slog.NewTextHandler(io.Discard, nil))
+	l := New(NewMemoryStore(), []Class{
+		{Name: "default", Limit: Limit{Count: 1, Period: time.Hour}},
+	}, log)
+	h := l.Handler(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}))
+
+	// The key is limited whatever address it is sent from
+	for i, addr := range []string{"203.0.113.7:5000", "203.0.113.8:5000"} {
+		r := httptest.NewRequest(http.MethodGet, "/users", nil)
+		r.RemoteAddr = addr
+		r.Header.Set("Authorization", "Bearer secret")
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, r)
+
+		want := http.StatusOK
+		if i > 0 {
+			want = http.StatusTooManyRequests
+		}
+		if w.Code != want {
+			t.Fatalf("request from %s: status = %d, want %d", addr, w.Code, want)
+		}
+	}
+}
+
+// TestHandlerKeyAndIP checks that a request rejected by the bucket of its
+// address or of its key uses up no token of the other.
+func TestHandlerKeyAndIP(t *testing.T) {
+	log := slog.New(slog.NewTextHandler(io.Discard, nil))
+	l := New(NewMemoryStore(), []Class{
+		{Name: "default", Limit: Limit{Count: 1, Period: time.Hour}},
+	}, log)
+	h := l.Handler(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}))
+
+	steps := []struct {
+		name   string
+		addr   string
+		key    string
+		status int
+	}{
+		{"first key", "203.0.113.7:5000", "first", http.StatusOK},
+		{"address is empty", "203.0.113.7:5000", "second", http.StatusTooManyRequests},
+		{"key rejected by the address is full", "203.0.113.8:5000", "second", http.StatusOK},
+		{"key is empty", "203.0.113.9:5000", "first", http.StatusTooManyRequests},
+		{"address rejected by the key is full", "203.0.113.9:5000", "", http.StatusOK},
+	}
+	for _, step := range steps {
+		r := httptest.NewRequest(http.MethodGet, "/users", nil)
+		r.RemoteAddr = step.addr
+		if step.key != "" {
+			r.Header.Set("Authorization", "Bearer "+step.key)
+		}
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, r)
+
+		if w.Code != step.status {
+			t.Fatalf("%s: status = %d, want %d", step.name, w.Code, step.status)
+		}
+	}
+}
+
+type failingStore struct{}
+
+func (failingStore) TakeRateLimitToken(context.Context, string, float64, int) (bool, float64, error) {
+	return false, 0, errors.New("store is down")
+}
+
+func (failingStore) ReturnRateLimitToken(context.Context, string, float64, int) error {
+	return errors.New("store is down")
+}
+
+func (failingStore) DeleteFullRateLimitBuckets(context.Context) (int64, error) {
+	return 0, errors.New("store is down")
+}
+
+func TestHandlerFailsOpen(t *testing.T) {
+	log := slog.New(slog.NewTextHandler(io.Discard, nil))
+	l := New(failingStore{}, []Class{
+		{Name: "default", Limit: Limit{Count: 1, Period: time.Hour}},
+	}, log)
+	h := l.Handler(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/users", nil))
+
+	if w.Code != http.StatusNoContent {
+		t.Errorf("status = %d, want %d", w.Code, http.StatusNoContent)
+	}
+	if got := w.Header().Get(HeaderLimit); got != "" {
+		t.Errorf("%s = %q on a request that was not counted", HeaderLimit, got)
+	}
+}
+
+func TestMatch(t *testing.T) {
+	log := slog.New(slog.NewTextHandler(io.Discard, nil))
+	l := New(nil, []Class{
+		{Name: "external", Routes: []Route{{Method: http.MethodPost, Pattern: "/users"}}, Methods: []string{"/svc/Create"}},
+		{Name: "search", Routes: []Route{{Method: http.MethodGet, Pattern: "/users"}}},
+	}, log)
+
+	tests := []struct {
+		method, path string
+		want         string
+	}{
+		{http.MethodPost, "/users", "external"},
+		{http.MethodPost, "/users/", "external"},
+		{http.MethodGet, "/users", "search"},
+		{http.MethodGet, "/users/42", ""},
+	}
+	for _, tt := range tests {
+		c, _ := l.match(httptest.NewRequest(tt.method, tt.path, nil))
+		if c.Name != tt.want {
+			t.Errorf("%s %s: class %q, want %q", tt.method, tt.path, c.Name, tt.want)
+		}
+	}
+
+	if c, _ := l.matchMethod("/svc/Create"); c.Name != "external" {
+		t.Errorf("method class %q, want external", c.Name)
+	}
+	if _, ok := l.matchMethod("/svc/Get"); ok {
+		t.Error("a method of no class is limited")
+	}
+}
@@ -0,0 +1,82 @@
+// Package realip resolves the address of the client of a request sent
+// through reverse proxies. Proxy headers are honoured only on connections
+// from trusted proxies, anyone else could forge them to pass for another
+// client.
+package realip
+
+import (
+	"net"
+	"net/http"
+	"net/netip"
+	"strings"
+)
+
+// New returns a middleware that replaces the remote address of requests
+// from the trusted proxies with the client address they forwarded: the
+// nearest untrusted address of X-Forwarded-For, or X-Real-IP without it.
+// Requests from other addresses keep the address of the connection, as do
+// all requests if no proxy is trusted.
+func New(trusted []netip.Prefix) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		fn := func(w http.ResponseWriter, r *http.Request) {
+			if ip, ok := forwarded(r, trusted); ok {
+				r.RemoteAddr = ip.String()
+			}
+
+			next.ServeHTTP(w, r)
+		}
+
+		return http.HandlerFunc(fn)
+	}
+}
+
+// forwarded returns the client address forwarded by the proxy r came from,
+// if it is trusted.
+func forwarded(r *http.Request, trusted []netip.Prefix) (netip.Addr, bool) {
+	peer, ok := parse(r.RemoteAddr)
+	if !ok || !contains(trusted, peer) {
+		return netip.Addr{}, false
+	}
+
+	// Every proxy appends the address it was connected from, so the list is
+	// walked from the end until an address not of a trusted proxy
+	var hops []string
+	for _, v := range r.Header.Values("X-Forwarded-For") {
+		hops = append(hops, strings.Split(v, ",")...)
+	}
+	for i := len(hops) - 1; i >= 0; i-- {
+		ip, ok := parse(strings.TrimSpace(hops[i]))
+		if !ok {
+			// A malformed hop cannot be walked past
+			return netip.Addr{}, false
+		}
+		if i == 0 || !contains(trusted, ip) {
+			return ip, true
+		}
+	}
+
+	return parse(r.Header.Get("X-Real-IP"))
+}
+
+// parse returns the IP of addr, given with or without a port.
+func parse(addr string) (netip.Addr, bool) {
+	if host, _, err := net.SplitHostPort(addr); err == nil {
+		addr = host
+	}
+
+	ip, err := netip.ParseAddr(addr)
+	if err != nil {
+		return netip.Addr{}, false
+	}
+
+	return ip.Unmap(), true
+}
+
+func contains(prefixes []netip.Prefix, ip netip.Addr) bool {
+	for _, p := range prefixes {
+		if p.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,65 @@
+package realip_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"testing"
+
+	"github.com/Alhanaqtah/effective-mobile-test-task/internal/controller/realip"
+)
+
+func TestNew(t *testing.T) {
+	trusted := []netip.Prefix{
+		netip.MustParsePrefix("10.0.0.0/8"),
+		netip.MustParsePrefix("192.168.1.1/32"),
+	}
+
+	tests := []struct {
+		name         string
+		remoteAddr   string
+		forwardedFor []string
+		realIP       string
+		want         string
+		trustNothing bool
+	}{
+		{name: "direct", remoteAddr: "203.0.113.7:5000", want: "203.0.113.7:5000"},
+		{name: "forged by a client", remoteAddr: "203.0.113.7:5000", forwardedFor: []string{"198.51.100.1"}, realIP: "198.51.100.2", want: "203.0.113.7:5000"},
+		{name: "no trusted proxies", remoteAddr: "10.0.0.1:5000", forwardedFor: []string{"198.51.100.1"}, want: "10.0.0.1:5000", trustNothing: true},
+		{name: "forwarded", remoteAddr: "10.0.0.1:5000", forwardedFor: []string{"198.51.100.1"}, want: "198.51.100.1"},
+		{name: "chain of proxies", remoteAddr: "10.0.0.1:5000", forwardedFor: []string{"198.51.100.1, 192.168.1.1", "10.0.0.2"}, want: "198.51.100.1"},
+		{name: "forged hop before the proxy", remoteAddr: "10.0.0.1:5000", forwardedFor: []string{"198.51.100.9, 198.51.100.1"}, want: "198.51.100.1"},
+		{name: "only proxies", remoteAddr: "10.0.0.1:5000", forwardedFor: []string{"10.0.0.3, 10.0.0.2"}, want: "10.0.0.3"},
+		{name: "malformed hop", remoteAddr: "10.0.0.1:5000", forwardedFor: []string{"198.51.100.1, unknown"}, want: "10.0.0.1:5000"},
+		{name: "real ip", remoteAddr: "10.0.0.1:5000", realIP: "198.51.100.2", want: "198.51.100.2"},
+		{name: "forwarded for wins over real ip", remoteAddr: "10.0.0.1:5000", forwardedFor: []string{"198.51.100.1"}, realIP: "198.51.100.2", want: "198.51.100.1"},
+		{name: "mapped proxy address", remoteAddr: "[::ffff:10.0.0.1]:5000", forwardedFor: []string{"198.51.100.1"}, want: "198.51.100.1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			prefixes := trusted
+			if tt.trustNothing {
+				prefixes = nil
+			}
+
+			var got string
+			h := realip.New(prefixes)(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+				got = r.RemoteAddr
+			}))
+
+			r := httptest.NewRequest(http.MethodGet, "/", nil)
+			r.RemoteAddr = tt.remoteAddr
+			for _, v := range tt.forwardedFor {
+				r.Header.Add("X-Forwarded-For", v)
+			}
+			if tt.realIP != "" {
+				r.Header.Set("X-Real-IP", tt.realIP)
+			}
+			h.ServeHTTP(httptest.NewRecorder(), r)
+
+			if got != tt.want {
+				t.Errorf("RemoteAddr = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
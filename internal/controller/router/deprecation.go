@@ -0,0 +1,38 @@
+package router
+
+import (
+	"net/http"
+	"strconv"
+)
+
+// deprecation announces that v is deprecated on every response: Deprecation
+// (RFC 9745) carries the deprecation date, Sunset (RFC 8594) the date the
+// version stops being served and Link the version replacing it.
+func deprecation(v Version) func(next http.Handler) http.Handler {
+	deprecated := "@" + strconv.FormatInt(v.Deprecated.Unix(), 10)
+
+	var sunset string
+	if !v.Sunset.IsZero() {
+		sunset = v.Sunset.UTC().Format(http.TimeFormat)
+	}
+
+	var link string
+	if v.Successor != "" {
+		link = `<` + Version{Name: v.Successor}.Path() + `>; rel="successor-version"`
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			h := w.Header()
+			h.Set("Deprecation", deprecated)
+			if sunset != "" {
+				h.Set("Sunset", sunset)
+			}
+			if link != "" {
+				h.Add("Link", link)
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,153 @@
+// Package router assembles the HTTP API from the controllers.
+//
+// Every major version of the API is served under /api/<version> by its own
+// set of controllers. Controllers of a new version are added next to the
+// existing ones and share the service layer with them, so a version keeps
+// its behaviour until it is removed after its sunset date.
+//
+// The routes the API had at the root before it was versioned, /users, /tasks
+// and /docs, stay served as deprecated aliases of v1. Routes added since
+// never had another address and are only served under their version.
+package router
+
+import (
+	"net/http"
+	"net/netip"
+	"time"
+
+	"github.com/Alhanaqtah/effective-mobile-test-task/internal/controller/realip"
+	"github.com/Alhanaqtah/effective-mobile-test-task/internal/lib/i18n"
+
+	"github.com/go-chi/chi/middleware"
+	"github.com/go-chi/chi/v5"
+	httpSwagger "github.com/swaggo/http-swagger/v2"
+)
+
+// Registrar is a controller that registers its routes on a sub-router.
+type Registrar interface {
+	Register() func(r chi.Router)
+}
+
+// Controllers of a version of the HTTP API. Nil controllers are not
+// mounted, so a version may serve a part of the API.
+type Controllers struct {
+	Users    Registrar
+	Import   Registrar
+	Tasks    Registrar
+	Sync     Registrar
+	Webhooks Registrar
+	Events   Registrar
+	Board    Registrar
+	GraphQL  Registrar
+}
+
+// Version is a major version of the HTTP API.
+type Version struct {
+	Name        string // Path segment of the version, e.g. "v1"
+	Controllers Controllers
+	Deprecated  time.Time // When the version was deprecated, zero while it is current
+	Sunset      time.Time // When the version stops being served, zero if not planned
+	Successor   string    // Name of the version replacing a deprecated one
+	// Root serves the users, tasks and docs of the version at the root as
+	// well, as deprecated aliases naming the version as their successor. It
+	// is when the aliases were deprecated, zero serves none.
+	Root time.Time
+	Spec []byte // Swagger specification served under /docs, none if nil
+	// Validator checks requests against Spec before they reach the
+	// controllers, nil leaves them unchecked
+	Validator func(http.Handler) http.Handler
+	// RateLimit limits the requests of every client, nil leaves them
+	// unlimited
+	RateLimit func(http.Handler) http.Handler
+}
+
+// Path returns the prefix the version is served under.
+func (v Version) Path() string {
+	return "/api/" + v.Name
+}
+
+// New returns the router of the HTTP API. The client address is taken from
+// the proxy headers of requests from trusted proxies only. Idempotent wraps
+// the routes that change data, nil leaves them unwrapped. Observers wrap
+// every request outside the recoverer, so they see the response written for
+// a panic.
+func New(versions []Version, language i18n.Lang, trusted []netip.Prefix, idempotent func(http.Handler) http.Handler, observers ...func(http.Handler) http.Handler) *chi.Mux {
+	r := chi.NewRouter()
+
+	r.Use(middleware.RequestID)
+	r.Use(realip.New(trusted))
+	r.Use(observers...)
+	r.Use(middleware.Recoverer)
+	r.Use(i18n.Middleware(language))
+
+	for _, v := range versions {
+		r.Route(v.Path(), func(r chi.Router) {
+			serveVersion(r, v, idempotent)
+		})
+
+		if !v.Root.IsZero() {
+			alias := v
+			alias.Deprecated = v.Root
+			alias.Successor = v.Name
+			alias.Controllers = Controllers{Users: v.Controllers.Users, Tasks: v.Controllers.Tasks}
+			r.Group(func(r chi.Router) {
+				serveVersion(r, alias, idempotent)
+			})
+		}
+	}
+
+	return r
+}
+
+func serveVersion(r chi.Router, v Version, idempotent func(http.Handler) http.Handler) {
+	if !v.Deprecated.IsZero() {
+		r.Use(deprecation(v))
+	}
+	// Over the limit requests are rejected before they are validated
+	if v.RateLimit != nil {
+		r.Use(v.RateLimit)
+	}
+	if v.Validator != nil {
+		r.Use(v.Validator)
+	}
+	mountVersion(r, v.Controllers, idempotent)
+	if v.Spec != nil {
+		mountDocs(r, v)
+	}
+}
+
+func mountVersion(r chi.Router, c Controllers, idempotent func(http.Handler) http.Handler) {
+	r.Group(func(r chi.Router) {
+		if idempotent != nil {
+			r.Use(idempotent)
+		}
+
+		mount(r, "/users/import", c.Import)
+		mount(r, "/users", c.Users)
+		mount(r, "/tasks", c.Tasks)
+		mount(r, "/sync", c.Sync)
+		mount(r, "/webhooks", c.Webhooks)
+	})
+
+	mount(r, "/events", c.Events)
+	mount(r, "/board", c.Board)
+	mount(r, "/graphql", c.GraphQL)
+}
+
+func mount(r chi.Router, pattern string, c Registrar) {
+	if c != nil {
+		r.Route(pattern, c.Register())
+	}
+}
+
+// mountDocs serves the specification of the version and the Swagger UI
+// reading it.
+func mountDocs(r chi.Router, v Version) {
+	r.Get("/docs/swagger.json", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(v.Spec)
+	})
+	r.Get("/docs/*", httpSwagger.Handler(
+		httpSwagger.URL(v.Path()+"/docs/swagger.json"),
+	))
+}
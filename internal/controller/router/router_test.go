@@ -0,0 +1,266 @@
+package router_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	v1docs "github.com/Alhanaqtah/effective-mobile-test-task/docs/v1"
+	"github.com/Alhanaqtah/effective-mobile-test-task/internal/controller/board"
+	"github.com/Alhanaqtah/effective-mobile-test-task/internal/controller/datasync"
+	"github.com/Alhanaqtah/effective-mobile-test-task/internal/controller/events"
+	"github.com/Alhanaqtah/effective-mobile-test-task/internal/controller/gql"
+	"github.com/Alhanaqtah/effective-mobile-test-task/internal/controller/idempotency"
+	"github.com/Alhanaqtah/effective-mobile-test-task/internal/controller/openapi"
+	"github.com/Alhanaqtah/effective-mobile-test-task/internal/controller/router"
+	"github.com/Alhanaqtah/effective-mobile-test-task/internal/controller/task"
+	"github.com/Alhanaqtah/effective-mobile-test-task/internal/controller/user"
+	"github.com/Alhanaqtah/effective-mobile-test-task/internal/controller/userimport"
+	"github.com/Alhanaqtah/effective-mobile-test-task/internal/controller/webhook"
+	"github.com/Alhanaqtah/effective-mobile-test-task/internal/lib/i18n"
+	"github.com/Alhanaqtah/effective-mobile-test-task/internal/models"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// undocumented routes are served but intentionally left out of the spec.
+var undocumented = map[string]bool{
+	// The same handler as POST /graphql, described there
+	"GET /graphql": true,
+	// The spec itself and the Swagger UI
+	"GET /docs/swagger.json": true,
+	"GET /docs/*":            true,
+}
+
+var pathParam = regexp.MustCompile(`\{([^}]+)\}`)
+
+type spec struct {
+	BasePath string                          `json:"basePath"`
+	Paths    map[string]map[string]operation `json:"paths"`
+}
+
+type operation struct {
+	Parameters []struct {
+		Name string `json:"name"`
+		In   string `json:"in"`
+	} `json:"parameters"`
+}
+
+// TestSpecMatchesRoutes fails when the routes of v1 and its Swagger spec
+// drift apart: a route is missing from the spec, the spec documents a route
+// that is not served, or path parameters are named differently.
+func TestSpecMatchesRoutes(t *testing.T) {
+	var s spec
+	if err := json.Unmarshal(v1docs.Spec, &s); err != nil {
+		t.Fatalf("parse spec: %v", err)
+	}
+
+	log := slog.New(slog.NewTextHandler(io.Discard, nil))
+	v1 := router.Version{
+		Name: "v1",
+		Controllers: router.Controllers{
+			Users:    user.New(nil, log),
+			Import:   userimport.New(nil, log),
+			Tasks:    task.New(nil, log),
+			Sync:     datasync.New(nil, log),
+			Webhooks: webhook.New(nil, log),
+			Events:   events.New(nil, log),
+			Board:    board.New(nil, nil, log),
+			GraphQL:  gql.New(nil, 1, 1, log),
+		},
+		Spec: v1docs.Spec,
+	}
+
+	if s.BasePath != v1.Path() {
+		t.Errorf("spec basePath = %q, routes are served under %q", s.BasePath, v1.Path())
+	}
+
+	routes := map[string]bool{}
+	walk := func(method, route string, _ http.Handler, _ ...func(http.Handler) http.Handler) error {
+		route = strings.TrimPrefix(route, v1.Path())
+		if route != "/" {
+			route = strings.TrimSuffix(route, "/")
+		}
+		routes[method+" "+route] = true
+		return nil
+	}
+	if err := chi.Walk(router.New([]router.Version{v1}, i18n.EN, nil, nil), walk); err != nil {
+		t.Fatalf("walk routes: %v", err)
+	}
+
+	documented := map[string]bool{}
+	for path, operations := range s.Paths {
+		for method, op := range operations {
+			key := strings.ToUpper(method) + " " + path
+			documented[key] = true
+
+			if !routes[key] {
+				t.Errorf("%s is documented but not served", key)
+				continue
+			}
+
+			var want, got []string
+			for _, m := range pathParam.FindAllStringSubmatch(path, -1) {
+				want = append(want, m[1])
+			}
+			for _, p := range op.Parameters {
+				if p.In == "path" {
+					got = append(got, p.Name)
+				}
+			}
+			sort.Strings(want)
+			sort.Strings(got)
+			if !slices.Equal(want, got) {
+				t.Errorf("%s documents path parameters %v, the route has %v", key, got, want)
+			}
+		}
+	}
+
+	for route := range routes {
+		if !documented[route] && !undocumented[route] {
+			t.Errorf("%s is served but not documented", route)
+		}
+	}
+}
+
+type stub struct{}
+
+func (stub) Register() func(r chi.Router) {
+	return func(r chi.Router) {
+		r.Get("/", func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNoContent)
+		})
+	}
+}
+
+// TestRootAliases checks that the routes at the root serve v1 as deprecated
+// aliases, validated like the routes of v1, while v1 itself stays current.
+// Routes added after the API was versioned have no alias.
+func TestRootAliases(t *testing.T) {
+	log := slog.New(slog.NewTextHandler(io.Discard, nil))
+	validator, err := openapi.New(v1docs.Spec, log)
+	if err != nil {
+		t.Fatalf("new validator: %v", err)
+	}
+
+	root := time.Date(2026, time.October, 18, 0, 0, 0, 0, time.UTC)
+	v1 := router.Version{
+		Name:        "v1",
+		Controllers: router.Controllers{Users: stub{}, GraphQL: stub{}},
+		Root:        root,
+		Validator:   validator.Handler,
+	}
+	r := router.New([]router.Version{v1}, i18n.EN, nil, nil)
+
+	tests := []struct {
+		target      string
+		status      int
+		deprecation string
+		link        string
+	}{
+		{"/api/v1/users", http.StatusNoContent, "", ""},
+		{"/users", http.StatusNoContent, "@" + strconv.FormatInt(root.Unix(), 10), `</api/v1>; rel="successor-version"`},
+		{"/api/v1/users?page=x", http.StatusBadRequest, "", ""},
+		{"/users?page=x", http.StatusBadRequest, "@" + strconv.FormatInt(root.Unix(), 10), `</api/v1>; rel="successor-version"`},
+		{"/api/v1/graphql", http.StatusNoContent, "", ""},
+		{"/graphql", http.StatusNotFound, "", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.target, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, tt.target, nil))
+
+			if w.Code != tt.status {
+				t.Errorf("status = %d, want %d", w.Code, tt.status)
+			}
+			if got := w.Header().Get("Deprecation"); got != tt.deprecation {
+				t.Errorf("Deprecation = %q, want %q", got, tt.deprecation)
+			}
+			if got := w.Header().Get("Link"); got != tt.link {
+				t.Errorf("Link = %q, want %q", got, tt.link)
+			}
+		})
+	}
+}
+
+// creator counts the users it creates.
+type creator struct{ created *int }
+
+func (c creator) Register() func(r chi.Router) {
+	return func(r chi.Router) {
+		r.Post("/", func(w http.ResponseWriter, r *http.Request) {
+			*c.created++
+			w.WriteHeader(http.StatusCreated)
+		})
+	}
+}
+
+// keyStorage keeps idempotency records in memory.
+type keyStorage map[string]*models.IdempotencyRecord
+
+func (s keyStorage) ReserveIdempotencyKey(_ context.Context, key, fingerprint string, _, _ time.Duration) (*models.IdempotencyRecord, bool, error) {
+	if record, ok := s[key]; ok {
+		return record, false, nil
+	}
+	s[key] = &models.IdempotencyRecord{Key: key, Fingerprint: fingerprint}
+	return s[key], true, nil
+}
+
+func (s keyStorage) RenewIdempotencyKey(context.Context, string, time.Duration) error {
+	return nil
+}
+
+func (s keyStorage) SaveIdempotentResponse(_ context.Context, key string, statusCode int, header map[string]string, body []byte) error {
+	*s[key] = models.IdempotencyRecord{Key: key, Fingerprint: s[key].Fingerprint, Completed: true, StatusCode: statusCode, Header: header, Body: body}
+	return nil
+}
+
+func (s keyStorage) ReleaseIdempotencyKey(_ context.Context, key string) error {
+	delete(s, key)
+	return nil
+}
+
+func (s keyStorage) DeleteExpiredIdempotencyKeys(context.Context) (int64, error) {
+	return 0, nil
+}
+
+// TestIdempotencyKeysOfAliases checks that an Idempotency-Key sent to a route
+// of v1 and to its alias at the root identifies one request.
+func TestIdempotencyKeysOfAliases(t *testing.T) {
+	log := slog.New(slog.NewTextHandler(io.Discard, nil))
+	keys := idempotency.New(keyStorage{}, time.Hour, log)
+
+	var created int
+	v1 := router.Version{
+		Name:        "v1",
+		Controllers: router.Controllers{Users: creator{&created}},
+		Root:        time.Date(2026, time.October, 18, 0, 0, 0, 0, time.UTC),
+	}
+	r := router.New([]router.Version{v1}, i18n.EN, nil, keys.Handler)
+
+	for _, target := range []string{"/api/v1/users", "/users"} {
+		req := httptest.NewRequest(http.MethodPost, target, strings.NewReader(`{"passportNumber":"1234 567890"}`))
+		req.Header.Set(idempotency.HeaderKey, "k")
+
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		if w.Code != http.StatusCreated {
+			t.Fatalf("POST %s: status = %d, want %d", target, w.Code, http.StatusCreated)
+		}
+	}
+
+	if created != 1 {
+		t.Errorf("created %d users, want 1", created)
+	}
+}
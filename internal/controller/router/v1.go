@@ -0,0 +1,7 @@
+package router
+
+// @title Time Tracker API
+// @version 1.0
+// @description Test task for Effective-mobile.
+// @description Адреса без версии, например /users, остаются устаревшими псевдонимами /api/v1: их ответы содержат заголовки Deprecation и Link на /api/v1.
+// @BasePath /api/v1
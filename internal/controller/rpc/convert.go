@@ -0,0 +1,58 @@
+package rpc
+
+import (
+	"time"
+
+	"github.com/Alhanaqtah/effective-mobile-test-task/internal/models"
+	pb "github.com/Alhanaqtah/effective-mobile-test-task/pkg/api/timetracker/v1"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func userToProto(u *models.User) *pb.User {
+	return &pb.User{
+		Id:             u.ID,
+		Name:           u.Name,
+		Surname:        u.Surname,
+		Patronymic:     u.Patronymic,
+		Address:        u.Address,
+		PassportSerie:  int32(u.PassportSerie),
+		PassportNumber: int32(u.PassportNumber),
+		Version:        int32(u.Version),
+	}
+}
+
+func taskToProto(t *models.Task) *pb.Task {
+	if t == nil {
+		return nil
+	}
+
+	return &pb.Task{
+		Id:          t.ID,
+		UserId:      t.UserID,
+		Title:       t.Title,
+		Description: t.Description,
+		Done:        t.Done,
+		CreatedAt:   timestamppb.New(t.CreatedAt),
+		StartedAt:   optionalTimestamp(t.StartedAt),
+		DoneAt:      optionalTimestamp(t.DoneAt),
+		Duration:    t.Duration,
+		Version:     int32(t.Version),
+	}
+}
+
+func optionalTimestamp(t *time.Time) *timestamppb.Timestamp {
+	if t == nil {
+		return nil
+	}
+	return timestamppb.New(*t)
+}
+
+// clientTime returns the time of an operation, zero if the client has not
+// given one.
+func clientTime(ts *timestamppb.Timestamp) time.Time {
+	if ts == nil {
+		return time.Time{}
+	}
+	return ts.AsTime()
+}
@@ -0,0 +1,71 @@
+package rpc
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/Alhanaqtah/effective-mobile-test-task/internal/controller/apierror"
+	"github.com/Alhanaqtah/effective-mobile-test-task/internal/lib/i18n"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/protoadapt"
+)
+
+// errorDomain identifies the service in google.rpc.ErrorInfo.
+const errorDomain = "time-tracker"
+
+// grpcCodes maps the HTTP statuses of the error catalog to gRPC codes, so both
+// transports agree on how each service error is classified.
+var grpcCodes = map[int]codes.Code{
+	http.StatusBadRequest:            codes.InvalidArgument,
+	http.StatusNotFound:              codes.NotFound,
+	http.StatusConflict:              codes.AlreadyExists,
+	http.StatusPreconditionFailed:    codes.Aborted,
+	http.StatusRequestEntityTooLarge: codes.ResourceExhausted,
+	http.StatusUnsupportedMediaType:  codes.InvalidArgument,
+	http.StatusUnprocessableEntity:   codes.FailedPrecondition,
+	http.StatusTooManyRequests:       codes.ResourceExhausted,
+	http.StatusBadGateway:            codes.Unavailable,
+}
+
+// statusError converts a service error to a gRPC status carrying the error
+// code of the catalog and the invalid fields, if any.
+func statusError(ctx context.Context, err error) error {
+	lang := i18n.FromContext(ctx)
+
+	entry, fields := apierror.Resolve(err)
+
+	code, ok := grpcCodes[entry.Status]
+	if !ok {
+		code = codes.Internal
+	}
+
+	msg := apierror.Translate(lang, entry.Code)
+
+	st := status.New(code, msg.Detail)
+
+	details := []protoadapt.MessageV1{
+		&errdetails.ErrorInfo{Reason: entry.Code, Domain: errorDomain},
+		&errdetails.LocalizedMessage{Locale: string(lang), Message: msg.Detail},
+	}
+
+	if len(fields) > 0 {
+		violations := make([]*errdetails.BadRequest_FieldViolation, 0, len(fields))
+		for _, fe := range fields {
+			violations = append(violations, &errdetails.BadRequest_FieldViolation{
+				Field:       fe.Field,
+				Description: apierror.Translate(lang, apierror.Lookup(fe.Err).Code).Detail,
+			})
+		}
+		details = append(details, &errdetails.BadRequest{FieldViolations: violations})
+	}
+
+	withDetails, err := st.WithDetails(details...)
+	if err != nil {
+		return st.Err()
+	}
+
+	return withDetails.Err()
+}
@@ -0,0 +1,31 @@
+package rpc
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Alhanaqtah/effective-mobile-test-task/internal/controller/apierror"
+	"github.com/Alhanaqtah/effective-mobile-test-task/internal/lib/validation"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestStatusErrorCodes(t *testing.T) {
+	tests := []struct {
+		err  error
+		want codes.Code
+	}{
+		{validation.For("name", validation.ErrRequired), codes.InvalidArgument},
+		{apierror.ErrBodyTooLarge, codes.ResourceExhausted},
+		{apierror.ErrRateLimited, codes.ResourceExhausted},
+		{apierror.ErrUnsupportedMediaType, codes.InvalidArgument},
+		{context.DeadlineExceeded, codes.Internal},
+	}
+
+	for _, tt := range tests {
+		if got := status.Code(statusError(context.Background(), tt.err)); got != tt.want {
+			t.Errorf("statusError(%v) has code %v, want %v", tt.err, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,195 @@
+// Package rpc serves the user and task services over gRPC. It shares the
+// service layer and the error catalog with the REST controllers.
+package rpc
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net"
+	"runtime/debug"
+	"strings"
+
+	"github.com/Alhanaqtah/effective-mobile-test-task/internal/lib/apikey"
+	"github.com/Alhanaqtah/effective-mobile-test-task/internal/lib/i18n"
+	pb "github.com/Alhanaqtah/effective-mobile-test-task/pkg/api/timetracker/v1"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+type Server struct {
+	server *grpc.Server
+	// health serves grpc.health.v1, so load balancers probing it stop
+	// sending calls once the server drains.
+	health *health.Server
+	// ctx is cancelled on shutdown to end streams, which would otherwise
+	// keep a graceful stop waiting forever.
+	ctx    context.Context
+	cancel context.CancelFunc
+	log    *slog.Logger
+}
+
+// RateLimiter limits the calls of every client, as it does HTTP requests.
+type RateLimiter interface {
+	Call(ctx context.Context, method, ip, key string) error
+}
+
+// New returns a gRPC server of the services. Limiter limits unary calls, nil
+// leaves them unlimited.
+func New(users UserService, tasks TaskService, broker Broker, language i18n.Lang, limiter RateLimiter, log *slog.Logger) *Server {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	s := &Server{
+		health: health.NewServer(),
+		ctx:    ctx,
+		cancel: cancel,
+		log:    log,
+	}
+
+	unary := []grpc.UnaryServerInterceptor{s.recoverUnary, languageUnary(language)}
+	if limiter != nil {
+		// After the language, so the error is translated
+		unary = append(unary, limitUnary(limiter))
+	}
+
+	s.server = grpc.NewServer(
+		grpc.ChainUnaryInterceptor(unary...),
+		grpc.ChainStreamInterceptor(s.recoverStream, languageStream(language)),
+	)
+
+	pb.RegisterUserServiceServer(s.server, &userServer{service: users, log: log})
+	pb.RegisterTaskServiceServer(s.server, &taskServer{service: tasks, broker: broker, done: ctx.Done(), log: log})
+	healthpb.RegisterHealthServer(s.server, s.health)
+
+	return s
+}
+
+// ListenAndServe serves gRPC on addr until Shutdown is called.
+func (s *Server) ListenAndServe(addr string) error {
+	lis, err := net.Listen("tcp", addr)
+	if err != nil {
+		return err
+	}
+
+	if err := s.server.Serve(lis); err != nil && !errors.Is(err, grpc.ErrServerStopped) {
+		return err
+	}
+	return nil
+}
+
+// Drain reports every service as NOT_SERVING from now on, so load balancers
+// stop sending calls before the server shuts down.
+func (s *Server) Drain() {
+	s.health.Shutdown()
+}
+
+// Shutdown ends streams, waits for unary calls in flight and closes the
+// listener. Calls still running when ctx is done are cancelled.
+func (s *Server) Shutdown(ctx context.Context) error {
+	s.cancel()
+
+	stopped := make(chan struct{})
+	go func() {
+		s.server.GracefulStop()
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+		return nil
+	case <-ctx.Done():
+		s.server.Stop()
+		return ctx.Err()
+	}
+}
+
+func (s *Server) recoverUnary(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp any, err error) {
+	defer func() {
+		if rvr := recover(); rvr != nil {
+			err = s.recovered(info.FullMethod, rvr)
+		}
+	}()
+
+	return handler(ctx, req)
+}
+
+func (s *Server) recoverStream(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) (err error) {
+	defer func() {
+		if rvr := recover(); rvr != nil {
+			err = s.recovered(info.FullMethod, rvr)
+		}
+	}()
+
+	return handler(srv, ss)
+}
+
+func (s *Server) recovered(method string, rvr any) error {
+	s.log.Error("panic in gRPC handler",
+		slog.String("method", method),
+		slog.Any("panic", rvr),
+		slog.String("stack", string(debug.Stack())),
+	)
+	return status.Error(codes.Internal, "internal error")
+}
+
+// languageUnary picks the language of error messages from the
+// accept-language metadata, like i18n.Middleware does for HTTP.
+func languageUnary(fallback i18n.Lang) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		return handler(withLanguage(ctx, fallback), req)
+	}
+}
+
+func languageStream(fallback i18n.Lang) grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		return handler(srv, &contextStream{ServerStream: ss, ctx: withLanguage(ss.Context(), fallback)})
+	}
+}
+
+// limitUnary rejects calls of clients over their rate limit. Clients are
+// known by the address of the connection, as gRPC is served without
+// proxies, and the API key in the authorization metadata.
+func limitUnary(limiter RateLimiter) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		var ip string
+		if p, ok := peer.FromContext(ctx); ok {
+			ip = p.Addr.String()
+			if host, _, err := net.SplitHostPort(ip); err == nil {
+				ip = host
+			}
+		}
+
+		md, _ := metadata.FromIncomingContext(ctx)
+		var key string
+		if v := md.Get("authorization"); len(v) > 0 {
+			key = apikey.DigestAuthorization(v[0])
+		}
+
+		if err := limiter.Call(ctx, info.FullMethod, ip, key); err != nil {
+			return nil, statusError(ctx, err)
+		}
+
+		return handler(ctx, req)
+	}
+}
+
+func withLanguage(ctx context.Context, fallback i18n.Lang) context.Context {
+	md, _ := metadata.FromIncomingContext(ctx)
+	return i18n.WithLang(ctx, i18n.Negotiate(strings.Join(md.Get("accept-language"), ","), fallback))
+}
+
+// contextStream overrides the context of a server stream.
+type contextStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *contextStream) Context() context.Context {
+	return s.ctx
+}
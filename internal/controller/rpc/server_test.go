@@ -0,0 +1,175 @@
+package rpc
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/Alhanaqtah/effective-mobile-test-task/internal/controller/ratelimit"
+	"github.com/Alhanaqtah/effective-mobile-test-task/internal/lib/i18n"
+	taskService "github.com/Alhanaqtah/effective-mobile-test-task/internal/service/task"
+	userService "github.com/Alhanaqtah/effective-mobile-test-task/internal/service/user"
+	pb "github.com/Alhanaqtah/effective-mobile-test-task/pkg/api/timetracker/v1"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// TestLimitUnary checks that calls are counted against the address of the
+// connection and the API key, in the class of their method.
+func TestLimitUnary(t *testing.T) {
+	log := slog.New(slog.NewTextHandler(io.Discard, nil))
+	limiter := ratelimit.New(ratelimit.NewMemoryStore(), []ratelimit.Class{
+		{Name: "external", Limit: ratelimit.Limit{Count: 1, Period: time.Hour}, Methods: []string{
+			pb.UserService_CreateUser_FullMethodName,
+		}},
+	}, log)
+	interceptor := limitUnary(limiter)
+
+	handler := func(context.Context, any) (any, error) { return "ok", nil }
+
+	call := func(method, addr, authorization string) error {
+		ctx := peer.NewContext(context.Background(), &peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP(addr), Port: 5000}})
+		if authorization != "" {
+			ctx = metadata.NewIncomingContext(ctx, metadata.Pairs("authorization", authorization))
+		}
+		_, err := interceptor(ctx, nil, &grpc.UnaryServerInfo{FullMethod: method}, handler)
+		return err
+	}
+
+	create := pb.UserService_CreateUser_FullMethodName
+	steps := []struct {
+		name          string
+		method        string
+		addr          string
+		authorization string
+		want          codes.Code
+	}{
+		{"first call", create, "203.0.113.7", "", codes.OK},
+		{"same address", create, "203.0.113.7", "", codes.ResourceExhausted},
+		{"another address", create, "203.0.113.8", "Bearer secret", codes.OK},
+		{"same key from another address", create, "203.0.113.9", "Bearer secret", codes.ResourceExhausted},
+		{"method of no class", pb.UserService_GetUser_FullMethodName, "203.0.113.7", "", codes.OK},
+	}
+	for _, step := range steps {
+		err := call(step.method, step.addr, step.authorization)
+		if got := status.Code(err); got != step.want {
+			t.Fatalf("%s: code = %v, want %v", step.name, got, step.want)
+		}
+	}
+}
+
+// TestInvalidID checks that malformed ids are rejected as invalid arguments
+// before they reach the storage, which the services here do not have.
+func TestInvalidID(t *testing.T) {
+	log := slog.New(slog.NewTextHandler(io.Discard, nil))
+	users := &userServer{service: userService.New(nil, nil, nil, log), log: log}
+	tasks := &taskServer{service: taskService.New(nil, nil, log), log: log}
+
+	calls := map[string]func(ctx context.Context, id string) error{
+		"GetUser": func(ctx context.Context, id string) error {
+			_, err := users.GetUser(ctx, &pb.GetUserRequest{Id: id})
+			return err
+		},
+		"UpdateUser": func(ctx context.Context, id string) error {
+			_, err := users.UpdateUser(ctx, &pb.UpdateUserRequest{Id: id})
+			return err
+		},
+		"DeleteUser": func(ctx context.Context, id string) error {
+			_, err := users.DeleteUser(ctx, &pb.DeleteUserRequest{Id: id})
+			return err
+		},
+		"GetTask": func(ctx context.Context, id string) error {
+			_, err := tasks.GetTask(ctx, &pb.GetTaskRequest{Id: id})
+			return err
+		},
+		"StartTask": func(ctx context.Context, id string) error {
+			_, err := tasks.StartTask(ctx, &pb.StartTaskRequest{Id: id})
+			return err
+		},
+		"FinishTask": func(ctx context.Context, id string) error {
+			_, err := tasks.FinishTask(ctx, &pb.FinishTaskRequest{Id: id})
+			return err
+		},
+		"DeleteTask": func(ctx context.Context, id string) error {
+			_, err := tasks.DeleteTask(ctx, &pb.DeleteTaskRequest{Id: id})
+			return err
+		},
+	}
+
+	for name, call := range calls {
+		for _, id := range []string{"", "not-a-uuid"} {
+			st := status.Convert(call(context.Background(), id))
+			if st.Code() != codes.InvalidArgument {
+				t.Errorf("%s(%q): code = %v, want %v", name, id, st.Code(), codes.InvalidArgument)
+				continue
+			}
+
+			var field string
+			for _, detail := range st.Details() {
+				if br, ok := detail.(*errdetails.BadRequest); ok && len(br.GetFieldViolations()) == 1 {
+					field = br.GetFieldViolations()[0].GetField()
+				}
+			}
+			if field != "id" {
+				t.Errorf("%s(%q): violated field = %q, want %q", name, id, field, "id")
+			}
+		}
+	}
+}
+
+// TestDrain checks that the health service reports NOT_SERVING once the
+// server drains.
+func TestDrain(t *testing.T) {
+	s := New(nil, nil, nil, i18n.EN, nil, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	client := healthpb.NewHealthClient(serve(t, s))
+
+	check := func() healthpb.HealthCheckResponse_ServingStatus {
+		t.Helper()
+		resp, err := client.Check(context.Background(), &healthpb.HealthCheckRequest{})
+		if err != nil {
+			t.Fatalf("check health: %v", err)
+		}
+		return resp.GetStatus()
+	}
+
+	if got := check(); got != healthpb.HealthCheckResponse_SERVING {
+		t.Fatalf("status = %v before draining, want SERVING", got)
+	}
+
+	s.Drain()
+
+	if got := check(); got != healthpb.HealthCheckResponse_NOT_SERVING {
+		t.Errorf("status = %v after draining, want NOT_SERVING", got)
+	}
+}
+
+// serve serves s on a local port until the test ends and returns a
+// connection to it.
+func serve(t *testing.T, s *Server) *grpc.ClientConn {
+	t.Helper()
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	go s.server.Serve(lis)
+	t.Cleanup(s.server.Stop)
+
+	conn, err := grpc.NewClient(lis.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	return conn
+}
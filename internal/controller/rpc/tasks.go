@@ -0,0 +1,191 @@
+package rpc
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/Alhanaqtah/effective-mobile-test-task/internal/controller/apierror"
+	taskHandler "github.com/Alhanaqtah/effective-mobile-test-task/internal/controller/task"
+	"github.com/Alhanaqtah/effective-mobile-test-task/internal/events"
+	"github.com/Alhanaqtah/effective-mobile-test-task/internal/lib/i18n"
+	"github.com/Alhanaqtah/effective-mobile-test-task/internal/lib/request"
+	"github.com/Alhanaqtah/effective-mobile-test-task/internal/models"
+	taskService "github.com/Alhanaqtah/effective-mobile-test-task/internal/service/task"
+	pb "github.com/Alhanaqtah/effective-mobile-test-task/pkg/api/timetracker/v1"
+
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+type TaskService interface {
+	GetTasksInRange(ctx context.Context, userUUID, startDate, endDate string) ([]models.Task, error)
+	SearchTasks(ctx context.Context, query string, userUUIDs []string, page int) ([]models.TaskSearchResult, error)
+	GetTask(ctx context.Context, uuid string) (*models.Task, error)
+	StartTask(ctx context.Context, uuid string, at time.Time, version int) (*models.Task, error)
+	FinishTask(ctx context.Context, uuid string, at time.Time, version int) (*models.Task, error)
+	DeleteTask(ctx context.Context, uuid string, version int) error
+	Batch(ctx context.Context, ops []models.TaskOperation, atomic bool) ([]taskService.OperationResult, error)
+	RunningTasks(ctx context.Context, userUUIDs []string) (map[string]models.Task, error)
+}
+
+type Broker interface {
+	Subscribe(lastEventID string, filter events.Filter) (*events.Subscription, []models.Event, bool)
+}
+
+var operations = map[pb.TaskOperation_Op]string{
+	pb.TaskOperation_OP_CREATE: models.TaskOperationCreate,
+	pb.TaskOperation_OP_UPDATE: models.TaskOperationUpdate,
+	pb.TaskOperation_OP_START:  models.TaskOperationStart,
+	pb.TaskOperation_OP_FINISH: models.TaskOperationFinish,
+	pb.TaskOperation_OP_DELETE: models.TaskOperationDelete,
+}
+
+type taskServer struct {
+	pb.UnimplementedTaskServiceServer
+
+	service TaskService
+	broker  Broker
+	// done is closed when the server shuts down.
+	done <-chan struct{}
+	log  *slog.Logger
+}
+
+func (s *taskServer) ListTasks(ctx context.Context, req *pb.ListTasksRequest) (*pb.ListTasksResponse, error) {
+	tasks, err := s.service.GetTasksInRange(ctx, req.GetUserId(), req.GetStartDate(), req.GetEndDate())
+	if err != nil {
+		return nil, statusError(ctx, err)
+	}
+
+	resp := &pb.ListTasksResponse{Tasks: make([]*pb.Task, len(tasks))}
+	for i := range tasks {
+		resp.Tasks[i] = taskToProto(&tasks[i])
+	}
+
+	return resp, nil
+}
+
+func (s *taskServer) SearchTasks(ctx context.Context, req *pb.SearchTasksRequest) (*pb.SearchTasksResponse, error) {
+	page, err := pageNumber(req.GetPage())
+	if err != nil {
+		return nil, statusError(ctx, err)
+	}
+
+	results, err := s.service.SearchTasks(ctx, req.GetQuery(), req.GetUserIds(), page)
+	if err != nil {
+		return nil, statusError(ctx, err)
+	}
+
+	resp := &pb.SearchTasksResponse{Results: make([]*pb.TaskSearchResult, len(results))}
+	for i, r := range results {
+		resp.Results[i] = &pb.TaskSearchResult{
+			Task:                 taskToProto(&r.Task),
+			Rank:                 r.Rank,
+			TitleHighlight:       r.TitleHighlight,
+			DescriptionHighlight: r.DescriptionHighlight,
+		}
+	}
+
+	return resp, nil
+}
+
+func (s *taskServer) GetTask(ctx context.Context, req *pb.GetTaskRequest) (*pb.Task, error) {
+	task, err := s.service.GetTask(ctx, req.GetId())
+	if err != nil {
+		return nil, statusError(ctx, err)
+	}
+
+	return taskToProto(task), nil
+}
+
+func (s *taskServer) StartTask(ctx context.Context, req *pb.StartTaskRequest) (*pb.Task, error) {
+	task, err := s.service.StartTask(ctx, req.GetId(), clientTime(req.GetAt()), int(req.GetVersion()))
+	if err != nil {
+		return nil, statusError(ctx, err)
+	}
+
+	return taskToProto(task), nil
+}
+
+func (s *taskServer) FinishTask(ctx context.Context, req *pb.FinishTaskRequest) (*pb.Task, error) {
+	task, err := s.service.FinishTask(ctx, req.GetId(), clientTime(req.GetAt()), int(req.GetVersion()))
+	if err != nil {
+		return nil, statusError(ctx, err)
+	}
+
+	return taskToProto(task), nil
+}
+
+func (s *taskServer) DeleteTask(ctx context.Context, req *pb.DeleteTaskRequest) (*emptypb.Empty, error) {
+	if err := s.service.DeleteTask(ctx, req.GetId(), int(req.GetVersion())); err != nil {
+		return nil, statusError(ctx, err)
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+func (s *taskServer) Batch(ctx context.Context, req *pb.BatchRequest) (*pb.BatchResponse, error) {
+	const op = "controller.rpc.Batch"
+
+	log := s.log.With(slog.String("op", op))
+
+	ops := make([]models.TaskOperation, len(req.GetOperations()))
+	for i, o := range req.GetOperations() {
+		ops[i] = operation(o)
+	}
+
+	atomic := !req.GetIndependent()
+
+	results, err := s.service.Batch(ctx, ops, atomic)
+	if err != nil {
+		return nil, statusError(ctx, err)
+	}
+
+	lang := i18n.FromContext(ctx)
+
+	resp := &pb.BatchResponse{
+		Committed: true,
+		Results:   make([]*pb.OperationResult, len(results)),
+	}
+
+	for i, res := range results {
+		result := &pb.OperationResult{
+			Index:  int32(i),
+			Status: res.Status,
+			Task:   taskToProto(res.Task),
+		}
+		if res.Err != nil {
+			entry, _ := apierror.Resolve(res.Err)
+			result.Error = &pb.OperationError{Code: entry.Code, Message: apierror.Translate(lang, entry.Code).Detail}
+		}
+		// An atomic batch is committed only if every operation was applied.
+		if atomic && res.Status != taskService.OperationApplied {
+			resp.Committed = false
+		}
+		resp.Results[i] = result
+	}
+
+	log.Debug("batch applied", slog.Int("operations", len(ops)), slog.Bool("committed", resp.Committed))
+
+	return resp, nil
+}
+
+// operation converts a batch operation the same way the REST API does.
+func operation(o *pb.TaskOperation) models.TaskOperation {
+	op := request.TaskOperation{
+		Op:          operations[o.GetOp()],
+		Ref:         o.GetRef(),
+		TaskID:      o.GetTaskId(),
+		TaskRef:     o.GetTaskRef(),
+		UserID:      o.GetUserId(),
+		Title:       o.Title,
+		Description: o.Description,
+		Version:     int(o.GetVersion()),
+	}
+
+	if o.GetAt() != nil {
+		at := o.GetAt().AsTime()
+		op.At = &at
+	}
+
+	return taskHandler.Operation(op)
+}
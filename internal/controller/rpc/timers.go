@@ -0,0 +1,116 @@
+package rpc
+
+import (
+	"time"
+
+	"github.com/Alhanaqtah/effective-mobile-test-task/internal/controller/apierror"
+	"github.com/Alhanaqtah/effective-mobile-test-task/internal/events"
+	"github.com/Alhanaqtah/effective-mobile-test-task/internal/lib/validation"
+	"github.com/Alhanaqtah/effective-mobile-test-task/internal/timers"
+	pb "github.com/Alhanaqtah/effective-mobile-test-task/pkg/api/timetracker/v1"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	// maxWatched limits the number of users one stream can watch.
+	maxWatched = 100
+
+	minInterval = time.Second
+)
+
+func (s *taskServer) WatchTimers(req *pb.WatchTimersRequest, stream grpc.ServerStreamingServer[pb.TimersUpdate]) error {
+	ctx := stream.Context()
+
+	watched := make(map[string]bool)
+	for _, id := range req.GetUserIds() {
+		watched[id] = true
+	}
+
+	var errs validation.Errors
+	if len(watched) == 0 {
+		errs = append(errs, validation.For("user_ids", validation.ErrRequired))
+	}
+	if len(watched) > maxWatched {
+		errs = append(errs, validation.For("user_ids", apierror.ErrTooManyWatched))
+	}
+	if req.GetInterval() != nil && req.GetInterval().AsDuration() < minInterval {
+		errs = append(errs, validation.For("interval", validation.ErrInvalid))
+	}
+	if err := errs.Err(); err != nil {
+		return statusError(ctx, err)
+	}
+
+	subscribe := func() *events.Subscription {
+		sub, _, _ := s.broker.Subscribe("", events.Filter{UserIDs: watched, Types: timers.Events})
+		return sub
+	}
+
+	// Subscribe before loading the timers so that no change is missed.
+	sub := subscribe()
+	defer func() { sub.Close() }()
+
+	t := timers.New(s.service)
+	if err := t.Refresh(ctx, req.GetUserIds()); err != nil {
+		return statusError(ctx, err)
+	}
+	if err := stream.Send(timersUpdate(t)); err != nil {
+		return err
+	}
+
+	var tick <-chan time.Time
+	if req.GetInterval() != nil {
+		ticker := time.NewTicker(req.GetInterval().AsDuration())
+		defer ticker.Stop()
+		tick = ticker.C
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+
+		case <-s.done:
+			return status.Error(codes.Unavailable, "server is shutting down")
+
+		case event, ok := <-sub.C:
+			var err error
+			if !ok {
+				// Dropped for being too slow: catch up from the database.
+				sub = subscribe()
+				err = t.RefreshAll(ctx)
+			} else {
+				err = t.Refresh(ctx, []string{event.UserID})
+			}
+			if err != nil {
+				return statusError(ctx, err)
+			}
+
+		case <-tick:
+		}
+
+		if err := stream.Send(timersUpdate(t)); err != nil {
+			return err
+		}
+	}
+}
+
+func timersUpdate(t *timers.Set) *pb.TimersUpdate {
+	now := time.Now()
+
+	update := &pb.TimersUpdate{Time: timestamppb.New(now)}
+	for _, timer := range t.Timers(now) {
+		pt := &pb.Timer{UserId: timer.UserID}
+		if timer.Task != nil {
+			pt.Task = taskToProto(timer.Task)
+			pt.Elapsed = durationpb.New(timer.Elapsed)
+		}
+		update.Timers = append(update.Timers, pt)
+	}
+
+	return update
+}
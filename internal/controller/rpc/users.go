@@ -0,0 +1,147 @@
+package rpc
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/Alhanaqtah/effective-mobile-test-task/internal/lib/validation"
+	"github.com/Alhanaqtah/effective-mobile-test-task/internal/models"
+	userService "github.com/Alhanaqtah/effective-mobile-test-task/internal/service/user"
+	pb "github.com/Alhanaqtah/effective-mobile-test-task/pkg/api/timetracker/v1"
+
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+type UserService interface {
+	CreateUser(ctx context.Context, passportSerie, passportNumber int) (*models.User, error)
+	GetUsers(ctx context.Context, page int, filter string) ([]models.User, error)
+	GetUser(ctx context.Context, uuid string) (*models.User, error)
+	UpdateUserInfo(ctx context.Context, uuid string, update models.UserUpdate, version int) (*models.User, error)
+	RemoveUserByUUID(ctx context.Context, uuid string, version int) error
+}
+
+type userServer struct {
+	pb.UnimplementedUserServiceServer
+
+	service UserService
+	log     *slog.Logger
+}
+
+func (s *userServer) CreateUser(ctx context.Context, req *pb.CreateUserRequest) (*pb.User, error) {
+	const op = "controller.rpc.CreateUser"
+
+	log := s.log.With(slog.String("op", op))
+
+	if req.GetPassportNumber() == "" {
+		return nil, statusError(ctx, validation.For("passport_number", validation.ErrRequired))
+	}
+
+	passportSerie, passportNumber, err := userService.ParsePassport(req.GetPassportNumber())
+	if err != nil {
+		return nil, statusError(ctx, validation.For("passport_number", err))
+	}
+
+	user, err := s.service.CreateUser(ctx, passportSerie, passportNumber)
+	if err != nil {
+		return nil, statusError(ctx, err)
+	}
+
+	log.Debug("user created successfully", slog.String("user_id", user.ID))
+
+	return userToProto(user), nil
+}
+
+func (s *userServer) ListUsers(ctx context.Context, req *pb.ListUsersRequest) (*pb.ListUsersResponse, error) {
+	page, err := pageNumber(req.GetPage())
+	if err != nil {
+		return nil, statusError(ctx, err)
+	}
+
+	users, err := s.service.GetUsers(ctx, page, req.GetFilter())
+	if err != nil {
+		return nil, statusError(ctx, err)
+	}
+
+	resp := &pb.ListUsersResponse{Users: make([]*pb.User, len(users))}
+	for i := range users {
+		resp.Users[i] = userToProto(&users[i])
+	}
+
+	return resp, nil
+}
+
+func (s *userServer) GetUser(ctx context.Context, req *pb.GetUserRequest) (*pb.User, error) {
+	user, err := s.service.GetUser(ctx, req.GetId())
+	if err != nil {
+		return nil, statusError(ctx, err)
+	}
+
+	return userToProto(user), nil
+}
+
+func (s *userServer) UpdateUser(ctx context.Context, req *pb.UpdateUserRequest) (*pb.User, error) {
+	const op = "controller.rpc.UpdateUser"
+
+	log := s.log.With(slog.String("op", op))
+
+	update, err := userUpdate(req.GetUser(), req.GetUpdateMask().GetPaths())
+	if err != nil {
+		return nil, statusError(ctx, err)
+	}
+
+	user, err := s.service.UpdateUserInfo(ctx, req.GetId(), update, int(req.GetVersion()))
+	if err != nil {
+		return nil, statusError(ctx, err)
+	}
+
+	log.Debug("user updated successfully", slog.String("user_id", user.ID))
+
+	return userToProto(user), nil
+}
+
+func (s *userServer) DeleteUser(ctx context.Context, req *pb.DeleteUserRequest) (*emptypb.Empty, error) {
+	if err := s.service.RemoveUserByUUID(ctx, req.GetId(), int(req.GetVersion())); err != nil {
+		return nil, statusError(ctx, err)
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+// userUpdate builds the update of the fields listed in the mask. Setting
+// the address to an empty string clears it.
+func userUpdate(user *pb.User, paths []string) (models.UserUpdate, error) {
+	var update models.UserUpdate
+	var errs validation.Errors
+
+	for _, path := range paths {
+		switch path {
+		case "name":
+			update.Name = models.Some(user.GetName())
+		case "surname":
+			update.Surname = models.Some(user.GetSurname())
+		case "patronymic":
+			update.Patronymic = models.Some(user.GetPatronymic())
+		case "address":
+			if user.GetAddress() == "" {
+				update.Address = models.Null[string]()
+			} else {
+				update.Address = models.Some(user.GetAddress())
+			}
+		case "passport_serie":
+			update.PassportSerie = models.Some(int(user.GetPassportSerie()))
+		case "passport_number":
+			update.PassportNumber = models.Some(int(user.GetPassportNumber()))
+		default:
+			errs = append(errs, validation.For("update_mask", validation.ErrUnknownField))
+		}
+	}
+
+	return update, errs.Err()
+}
+
+func pageNumber(page int32) (int, error) {
+	if page < 0 {
+		return 0, validation.For("page", validation.ErrInvalid)
+	}
+	return max(int(page), 1), nil
+}
@@ -0,0 +1,130 @@
+package task
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/Alhanaqtah/effective-mobile-test-task/internal/controller/apierror"
+	"github.com/Alhanaqtah/effective-mobile-test-task/internal/lib/logger/sl"
+	"github.com/Alhanaqtah/effective-mobile-test-task/internal/lib/request"
+	"github.com/Alhanaqtah/effective-mobile-test-task/internal/lib/response"
+	"github.com/Alhanaqtah/effective-mobile-test-task/internal/lib/validation"
+	"github.com/Alhanaqtah/effective-mobile-test-task/internal/models"
+	service "github.com/Alhanaqtah/effective-mobile-test-task/internal/service/task"
+
+	"github.com/go-chi/chi/middleware"
+	"github.com/go-chi/render"
+)
+
+// @Summary Пакетная обработка операций над задачами
+// @Description Применяет упорядоченный список операций create, update, start, finish и delete с клиентским временем. В режиме atomic все операции выполняются в одной транзакции и откатываются при первой ошибке, в режиме independent каждая операция применяется отдельно. Операции могут ссылаться на задачи, созданные ранее в том же пакете, через ref и task_ref
+// @Tags tasks
+// @Accept json
+// @Produce json
+// @Param TaskBatch body request.TaskBatch true "Операции над задачами"
+// @Param Idempotency-Key header string false "Ключ идемпотентности для безопасного повтора запроса"
+// @Success 200 {object} response.TaskBatch "Результаты операций"
+// @Failure 400 {object} problem.Problem "Некорректный запрос"
+// @Failure 409 {object} problem.Problem "Запрос с тем же ключом идемпотентности ещё обрабатывается"
+// @Failure 413 {object} problem.Problem "Слишком много операций в пакете"
+// @Failure 422 {object} problem.Problem "Ключ идемпотентности использован для другого запроса"
+// @Failure 500 {object} problem.Problem "Внутренняя ошибка сервера"
+// @Router /tasks/batch [post]
+func (h *Handler) batch(w http.ResponseWriter, r *http.Request) {
+	const op = "controller.task.batch"
+
+	log := h.log.With(
+		slog.String("op", op),
+		slog.String("req_id", middleware.GetReqID(r.Context())),
+		sl.Trace(r.Context()),
+	)
+
+	var req request.TaskBatch
+	if err := render.DecodeJSON(r.Body, &req); err != nil {
+		log.Error("failed to decode request body", sl.Error(err))
+		apierror.Write(w, r, validation.ErrMalformedBody)
+		return
+	}
+
+	// Mode is one of the values allowed by the spec, atomic by default
+	atomic := req.Mode != request.BatchModeIndependent
+
+	ops := make([]models.TaskOperation, len(req.Operations))
+	for i, o := range req.Operations {
+		ops[i] = Operation(o)
+	}
+
+	log.Debug("applying batch", slog.Int("operations", len(ops)), slog.Bool("atomic", atomic))
+
+	results, err := h.service.Batch(r.Context(), ops, atomic)
+	if err != nil {
+		apierror.Write(w, r, validation.Locate(err, validation.InBody))
+		return
+	}
+
+	resp := response.TaskBatch{
+		Committed: true,
+		Results:   make([]response.TaskOperationResult, len(results)),
+	}
+
+	for i, res := range results {
+		result := response.TaskOperationResult{
+			Index:  i,
+			Status: res.Status,
+			Task:   res.Task,
+		}
+		if res.Err != nil {
+			p := apierror.Problem(r, validation.Locate(res.Err, validation.InBody))
+			result.Error = &p
+		}
+		// An atomic batch is committed only if every operation was applied.
+		if atomic && res.Status != service.OperationApplied {
+			resp.Committed = false
+		}
+		resp.Results[i] = result
+	}
+
+	log.Debug("batch applied", slog.Bool("committed", resp.Committed))
+
+	render.JSON(w, r, resp)
+}
+
+// Operation converts a task operation from a request body. For update an
+// empty description clears it.
+func Operation(o request.TaskOperation) models.TaskOperation {
+	operation := models.TaskOperation{
+		Op:      o.Op,
+		Ref:     o.Ref,
+		TaskID:  o.TaskID,
+		TaskRef: o.TaskRef,
+		UserID:  o.UserID,
+		Version: o.Version,
+	}
+
+	if o.At != nil {
+		operation.At = *o.At
+	}
+
+	if o.Op == models.TaskOperationUpdate {
+		if o.Title != nil {
+			operation.Update.Title = models.Some(*o.Title)
+		}
+		if o.Description != nil {
+			if *o.Description == "" {
+				operation.Update.Description = models.Null[string]()
+			} else {
+				operation.Update.Description = models.Some(*o.Description)
+			}
+		}
+		return operation
+	}
+
+	if o.Title != nil {
+		operation.Title = *o.Title
+	}
+	if o.Description != nil {
+		operation.Description = *o.Description
+	}
+
+	return operation
+}
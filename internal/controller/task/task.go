@@ -2,14 +2,18 @@ package task
 
 import (
 	"context"
-	"errors"
 	"log/slog"
 	"net/http"
+	"strconv"
+	"time"
 
-	"time-tracker/internal/lib/logger/sl"
-	"time-tracker/internal/lib/response"
-	"time-tracker/internal/models"
-	service "time-tracker/internal/service/task"
+	"github.com/Alhanaqtah/effective-mobile-test-task/internal/controller/apierror"
+	"github.com/Alhanaqtah/effective-mobile-test-task/internal/lib/etag"
+	"github.com/Alhanaqtah/effective-mobile-test-task/internal/lib/logger/sl"
+	"github.com/Alhanaqtah/effective-mobile-test-task/internal/lib/response"
+	"github.com/Alhanaqtah/effective-mobile-test-task/internal/lib/validation"
+	"github.com/Alhanaqtah/effective-mobile-test-task/internal/models"
+	service "github.com/Alhanaqtah/effective-mobile-test-task/internal/service/task"
 
 	"github.com/go-chi/chi/middleware"
 	"github.com/go-chi/chi/v5"
@@ -19,8 +23,13 @@ import (
 
 type Service interface {
 	GetTasksInRange(ctx context.Context, userUUID, startDate, endDate string) ([]models.Task, error)
-	StartTask(ctx context.Context, uuid string) (*models.Task, error)
-	FinishTask(ctx context.Context, uuid string) (*models.Task, error)
+	SearchTasks(ctx context.Context, query string, userUUIDs []string, page int) ([]models.TaskSearchResult, error)
+	GetTask(ctx context.Context, uuid string) (*models.Task, error)
+	RunningTasks(ctx context.Context, userUUIDs []string) (map[string]models.Task, error)
+	StartTask(ctx context.Context, uuid string, at time.Time, version int) (*models.Task, error)
+	FinishTask(ctx context.Context, uuid string, at time.Time, version int) (*models.Task, error)
+	DeleteTask(ctx context.Context, uuid string, version int) error
+	Batch(ctx context.Context, ops []models.TaskOperation, atomic bool) ([]service.OperationResult, error)
 }
 
 type Handler struct {
@@ -37,8 +46,13 @@ func New(service Service, log *slog.Logger) *Handler {
 
 func (h *Handler) Register() func(r chi.Router) {
 	return func(r chi.Router) {
+		r.Get("/search", h.searchTasks)
+		r.Get("/running", h.runningTasks)
+		r.Post("/batch", h.batch)
 		r.Get("/{user_id}/worklogs", h.getTasksInRange)
+		r.Get("/{task_id}", h.getTask)
 		r.Post("/{task_id}/start", h.startTask)
+		r.Delete("/{task_id}", h.deleteTask)
 		r.Post("/{task_id}/finish", h.finishTask)
 	}
 }
@@ -48,104 +62,222 @@ func (h *Handler) Register() func(r chi.Router) {
 // @Tags tasks
 // @Accept json
 // @Produce json
-// @Param user_uuid path string true "UUID пользователя"
-// @Param start_date query string true "Дата начала в формате YYYY-MM-DD"
-// @Param end_date query string true "Дата окончания в формате YYYY-MM-DD"
+// @Param user_id path string true "UUID пользователя" format(uuid)
+// @Param start_date query string true "Начало периода в формате RFC 3339, например 2024-07-01T00:00:00Z"
+// @Param end_date query string true "Конец периода в формате RFC 3339, например 2024-07-31T23:59:59Z"
 // @Success 200 {array} models.Task "Список задач"
-// @Failure 400 {object} response.Response "Некорректный запрос"
-// @Failure 500 {object} response.Response "Внутренняя ошибка"
-// @Router /users/{user_uuid}/tasks [get]
+// @Failure 400 {object} problem.Problem "Некорректный запрос"
+// @Failure 500 {object} problem.Problem "Внутренняя ошибка"
+// @Router /tasks/{user_id}/worklogs [get]
 func (h *Handler) getTasksInRange(w http.ResponseWriter, r *http.Request) {
 	const op = "controller.user.getTaskInRange"
 
 	log := h.log.With(
 		slog.String("op", op),
 		slog.String("req_id", middleware.GetReqID(r.Context())),
+		sl.Trace(r.Context()),
 	)
 
-	userUUID := chi.URLParam(r, "user_uuid")
-	if userUUID == "" {
-		log.Error("missing user_id parameter")
-		render.Status(r, http.StatusBadRequest)
-		render.JSON(w, r, response.Err(`'user_id' parameter is required`))
+	userUUID := chi.URLParam(r, "user_id")
+	startDate := r.URL.Query().Get("start_date")
+	endDate := r.URL.Query().Get("end_date")
+
+	log.Debug("getting tasks in range", slog.String("user_id", userUUID), slog.String("start_date", startDate), slog.String("end_date", endDate))
+
+	tasks, err := h.service.GetTasksInRange(r.Context(), userUUID, startDate, endDate)
+	if err != nil {
+		validation.Locate(err, validation.InPath, "user_id")
+		apierror.Write(w, r, validation.Locate(err, validation.InQuery))
 		return
 	}
 
-	startDate := r.URL.Query().Get("start_date")
-	if startDate == "" {
-		log.Error("missing start_date parameter")
-		render.Status(r, http.StatusBadRequest)
-		render.JSON(w, r, response.Err(`'start_date' parameter is required`))
-		return
+	log.Debug("got tasks in range successfully")
+
+	render.JSON(w, r, tasks)
+}
+
+// @Summary Полнотекстовый поиск задач
+// @Description Ищет задачи по заголовку и описанию среди задач указанных пользователей (русская и английская морфология). API не аутентифицирует клиентов, поэтому доступ к задачам пользователей из user_id не проверяется: область поиска задаёт клиент.
+// @Tags tasks
+// @Accept json
+// @Produce json
+// @Param q query string true "Поисковый запрос"
+// @Param user_id query []string true "UUID пользователей, среди задач которых выполняется поиск" collectionFormat(multi)
+// @Param page query int false "Номер страницы" default(1) minimum(1)
+// @Success 200 {array} models.TaskSearchResult "Найденные задачи, отсортированные по релевантности"
+// @Failure 400 {object} problem.Problem "Некорректный запрос"
+// @Failure 500 {object} problem.Problem "Внутренняя ошибка"
+// @Router /tasks/search [get]
+func (h *Handler) searchTasks(w http.ResponseWriter, r *http.Request) {
+	const op = "controller.task.searchTasks"
+
+	log := h.log.With(
+		slog.String("op", op),
+		slog.String("req_id", middleware.GetReqID(r.Context())),
+		sl.Trace(r.Context()),
+	)
+
+	query := r.URL.Query().Get("q")
+	userUUIDs := r.URL.Query()["user_id"]
+
+	// Parameters are validated against the spec
+	page := 1
+	if p := r.URL.Query().Get("page"); p != "" {
+		page, _ = strconv.Atoi(p)
 	}
 
-	endDate := r.URL.Query().Get("end_date")
-	if endDate == "" {
-		log.Error("missing end_date parameter")
-		render.Status(r, http.StatusBadRequest)
-		render.JSON(w, r, response.Err(`'end_date' parameter is required`))
+	log.Debug("searching tasks", slog.String("q", query), slog.Any("user_id", userUUIDs), slog.Int("page", page))
+
+	results, err := h.service.SearchTasks(r.Context(), query, userUUIDs, page)
+	if err != nil {
+		apierror.Write(w, r, validation.Locate(err, validation.InQuery))
 		return
 	}
 
-	log.Debug("getting tasks in range", slog.String("user_id", userUUID), slog.String("start_date", startDate), slog.String("end_date", endDate))
+	log.Debug("tasks searched successfully")
 
-	tasks, err := h.service.GetTasksInRange(r.Context(), userUUID, startDate, endDate)
-	if err != nil {
-		if errors.Is(err, service.ErrInvalidDateRange) {
-			render.Status(r, http.StatusBadRequest)
-			render.JSON(w, r, response.Err("Invalid date range"))
+	render.JSON(w, r, results)
+}
+
+// @Summary Запущенные задачи
+// @Description Возвращает задачу, над которой сейчас работает каждый из указанных пользователей. Пользователи без запущенной задачи отсутствуют в ответе
+// @Tags tasks
+// @Produce json
+// @Param user_id query []string true "UUID пользователей" collectionFormat(multi)
+// @Success 200 {array} models.Task "Запущенные задачи в порядке пользователей в запросе"
+// @Failure 400 {object} problem.Problem "Некорректный запрос"
+// @Failure 500 {object} problem.Problem "Внутренняя ошибка"
+// @Router /tasks/running [get]
+func (h *Handler) runningTasks(w http.ResponseWriter, r *http.Request) {
+	const op = "controller.task.runningTasks"
+
+	log := h.log.With(
+		slog.String("op", op),
+		slog.String("req_id", middleware.GetReqID(r.Context())),
+		sl.Trace(r.Context()),
+	)
+
+	// The spec requires user_id but cannot check the format of its items
+	userUUIDs := r.URL.Query()["user_id"]
+	for _, userUUID := range userUUIDs {
+		if _, err := uuidlib.Parse(userUUID); err != nil {
+			log.Error("invalid userUUID", sl.Error(err))
+			apierror.Write(w, r, validation.Field("user_id", validation.InQuery, validation.ErrInvalid))
 			return
 		}
-		render.Status(r, http.StatusInternalServerError)
-		render.JSON(w, r, response.Err("Internal error"))
+	}
+
+	log.Debug("getting running tasks", slog.Any("user_id", userUUIDs))
+
+	running, err := h.service.RunningTasks(r.Context(), userUUIDs)
+	if err != nil {
+		apierror.Write(w, r, validation.Locate(err, validation.InQuery))
 		return
 	}
 
-	log.Debug("got tasks in range successfully")
+	tasks := make([]models.Task, 0, len(running))
+	for _, userUUID := range userUUIDs {
+		if task, ok := running[userUUID]; ok {
+			tasks = append(tasks, task)
+			delete(running, userUUID)
+		}
+	}
+
+	log.Debug("got running tasks successfully", slog.Int("count", len(tasks)))
 
 	render.JSON(w, r, tasks)
 }
 
+// @Summary Получить задачу
+// @Description Возвращает задачу по ее UUID. Версия задачи возвращается в заголовке ETag
+// @Tags tasks
+// @Accept json
+// @Produce json
+// @Param task_id path string true "UUID задачи" format(uuid)
+// @Param If-None-Match header string false "ETag закэшированной версии задачи"
+// @Success 200 {object} models.Task
+// @Header 200 {string} ETag "Версия задачи"
+// @Success 304 "Задача не изменилась"
+// @Failure 400 {object} problem.Problem "Неверный формат UUID"
+// @Failure 404 {object} problem.Problem "Задача не найдена"
+// @Failure 500 {object} problem.Problem "Внутренняя ошибка"
+// @Router /tasks/{task_id} [get]
+func (h *Handler) getTask(w http.ResponseWriter, r *http.Request) {
+	const op = "controller.task.getTask"
+
+	log := h.log.With(
+		slog.String("op", op),
+		slog.String("req_id", middleware.GetReqID(r.Context())),
+		sl.Trace(r.Context()),
+	)
+
+	uuid := chi.URLParam(r, "task_id")
+
+	log.Debug("getting task", slog.String("uuid", uuid))
+
+	task, err := h.service.GetTask(r.Context(), uuid)
+	if err != nil {
+		apierror.Write(w, r, pathID(err))
+		return
+	}
+
+	tag := etag.Format(task.Version)
+	w.Header().Set("ETag", tag)
+
+	if !etag.NoneMatch(r.Header.Get("If-None-Match"), tag) {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+
+	log.Debug("got task successfully")
+	render.JSON(w, r, task)
+}
+
 // @Summary Запуск задачи
 // @Description Запускает задачу по ее UUID
 // @Tags tasks
 // @Accept json
 // @Produce json
-// @Param task_id path string true "UUID задачи"
+// @Param task_id path string true "UUID задачи" format(uuid)
+// @Param If-Match header string false "ETag версии задачи, которую нужно изменить"
+// @Param Idempotency-Key header string false "Ключ идемпотентности для безопасного повтора запроса"
 // @Success 200 {object} models.Task "Запущенная задача"
-// @Failure 400 {object} response.Response "Неверный формат UUID или пустое тело запроса"
-// @Failure 404 {object} response.Response "Задача не найдена"
-// @Failure 500 {object} response.Response "Внутренняя ошибка сервера"
-// @Router /tasks/{task_uuid} [post]
+// @Header 200 {string} ETag "Новая версия задачи"
+// @Failure 400 {object} problem.Problem "Неверный формат UUID или пустое тело запроса"
+// @Failure 404 {object} problem.Problem "Задача не найдена"
+// @Failure 409 {object} problem.Problem "Запрос с тем же ключом идемпотентности ещё обрабатывается"
+// @Failure 422 {object} problem.Problem "Ключ идемпотентности использован для другого запроса"
+// @Failure 412 {object} problem.Problem "Версия задачи не совпадает с If-Match"
+// @Failure 500 {object} problem.Problem "Внутренняя ошибка сервера"
+// @Router /tasks/{task_id}/start [post]
 func (h *Handler) startTask(w http.ResponseWriter, r *http.Request) {
 	const op = "controller.task.startTask"
 
-	log := h.log.With(slog.String("op", op))
+	log := h.log.With(
+		slog.String("op", op),
+		slog.String("req_id", middleware.GetReqID(r.Context())),
+		sl.Trace(r.Context()),
+	)
 
 	uuid := chi.URLParam(r, "task_id")
 
-	_, err := uuidlib.Parse(uuid)
+	version, err := h.ifMatch(r, uuid)
 	if err != nil {
-		log.Error("invalid userUUID", sl.Error(err))
-		render.Status(r, http.StatusBadRequest)
-		render.JSON(w, r, response.Err(`invalid user uuid format`))
+		log.Error("failed to evaluate If-Match header", sl.Error(err))
+		apierror.Write(w, r, err)
 		return
 	}
 
-	log.Debug("starting task", slog.String("uuid", uuid))
+	log.Debug("starting task", slog.String("uuid", uuid), slog.Int("version", version))
 
-	task, err := h.service.StartTask(r.Context(), uuid)
+	task, err := h.service.StartTask(r.Context(), uuid, time.Time{}, version)
 	if err != nil {
-		if errors.Is(err, service.ErrTaskNotFound) {
-			render.Status(r, http.StatusNotFound)
-			render.JSON(w, r, response.Err("Task not found"))
-			return
-		}
-		render.Status(r, http.StatusInternalServerError)
-		render.JSON(w, r, response.Err("Internal error"))
+		apierror.Write(w, r, validation.Locate(pathID(err), validation.InBody))
 		return
 	}
 
+	w.Header().Set("ETag", etag.Format(task.Version))
+
 	log.Debug("task started successfully")
 	render.JSON(w, r, task)
 }
@@ -155,41 +287,113 @@ func (h *Handler) startTask(w http.ResponseWriter, r *http.Request) {
 // @Tags tasks
 // @Accept json
 // @Produce json
-// @Param task_id path string true "ID задачи"
+// @Param task_id path string true "UUID задачи" format(uuid)
+// @Param If-Match header string false "ETag версии задачи, которую нужно изменить"
+// @Param Idempotency-Key header string false "Ключ идемпотентности для безопасного повтора запроса"
 // @Success 200 {object} models.Task
-// @Failure 400 {object} response.Response "Неверный формат UUID"
-// @Failure 404 {object} response.Response "Задача не найдена"
-// @Failure 500 {object} response.Response "Внутренняя ошибка"
+// @Header 200 {string} ETag "Новая версия задачи"
+// @Failure 400 {object} problem.Problem "Неверный формат UUID"
+// @Failure 404 {object} problem.Problem "Задача не найдена"
+// @Failure 409 {object} problem.Problem "Запрос с тем же ключом идемпотентности ещё обрабатывается"
+// @Failure 422 {object} problem.Problem "Ключ идемпотентности использован для другого запроса"
+// @Failure 412 {object} problem.Problem "Версия задачи не совпадает с If-Match"
+// @Failure 500 {object} problem.Problem "Внутренняя ошибка"
 // @Router /tasks/{task_id}/finish [post]
 func (h *Handler) finishTask(w http.ResponseWriter, r *http.Request) {
 	const op = "controller.task.finishTask"
 
-	log := h.log.With(slog.String("op", op))
+	log := h.log.With(
+		slog.String("op", op),
+		slog.String("req_id", middleware.GetReqID(r.Context())),
+		sl.Trace(r.Context()),
+	)
 
 	uuid := chi.URLParam(r, "task_id")
 
-	_, err := uuidlib.Parse(uuid)
+	version, err := h.ifMatch(r, uuid)
 	if err != nil {
-		log.Error("invalid userUUID", sl.Error(err))
-		render.Status(r, http.StatusBadRequest)
-		render.JSON(w, r, response.Err(`invalid user uuid format`))
+		log.Error("failed to evaluate If-Match header", sl.Error(err))
+		apierror.Write(w, r, err)
 		return
 	}
 
-	log.Debug("finishing task", slog.String("uuid", uuid))
+	log.Debug("finishing task", slog.String("uuid", uuid), slog.Int("version", version))
 
-	task, err := h.service.FinishTask(r.Context(), uuid)
+	task, err := h.service.FinishTask(r.Context(), uuid, time.Time{}, version)
 	if err != nil {
-		if errors.Is(err, service.ErrTaskNotFound) {
-			render.Status(r, http.StatusNotFound)
-			render.JSON(w, r, response.Err("Task not found"))
-			return
-		}
-		render.Status(r, http.StatusInternalServerError)
-		render.JSON(w, r, response.Err("Internal error"))
+		apierror.Write(w, r, validation.Locate(pathID(err), validation.InBody))
 		return
 	}
 
+	w.Header().Set("ETag", etag.Format(task.Version))
+
 	log.Debug("task finished successfully")
 	render.JSON(w, r, task)
 }
+
+// @Summary Удаление задачи
+// @Description Удаляет задачу по ее UUID
+// @Tags tasks
+// @Produce json
+// @Param task_id path string true "UUID задачи" format(uuid)
+// @Param If-Match header string false "ETag версии задачи, которую нужно удалить"
+// @Success 200 {object} response.Response "Задача успешно удалена"
+// @Failure 400 {object} problem.Problem "Неверный формат UUID"
+// @Failure 404 {object} problem.Problem "Задача не найдена"
+// @Failure 412 {object} problem.Problem "Версия задачи не совпадает с If-Match"
+// @Failure 500 {object} problem.Problem "Внутренняя ошибка"
+// @Router /tasks/{task_id} [delete]
+func (h *Handler) deleteTask(w http.ResponseWriter, r *http.Request) {
+	const op = "controller.task.deleteTask"
+
+	log := h.log.With(
+		slog.String("op", op),
+		slog.String("req_id", middleware.GetReqID(r.Context())),
+		sl.Trace(r.Context()),
+	)
+
+	uuid := chi.URLParam(r, "task_id")
+
+	version, err := h.ifMatch(r, uuid)
+	if err != nil {
+		log.Error("failed to evaluate If-Match header", sl.Error(err))
+		apierror.Write(w, r, err)
+		return
+	}
+
+	log.Debug("deleting task", slog.String("uuid", uuid), slog.Int("version", version))
+
+	if err := h.service.DeleteTask(r.Context(), uuid, version); err != nil {
+		apierror.Write(w, r, pathID(err))
+		return
+	}
+
+	log.Debug("task deleted successfully")
+	render.JSON(w, r, response.Ok("Task deleted successfully"))
+}
+
+// ifMatch returns the version a write of the task uuid must expect. If-Match
+// may list several entity tags, so the current version of the task is looked
+// up to tell which of them applies.
+func (h *Handler) ifMatch(r *http.Request, uuid string) (int, error) {
+	versions, err := etag.ParseIfMatch(r.Header.Get("If-Match"))
+	if err != nil {
+		return 0, validation.Field("If-Match", validation.InHeader, validation.ErrInvalid)
+	}
+	if len(versions) < 2 {
+		return etag.Match(versions, etag.Any), nil
+	}
+
+	task, err := h.service.GetTask(r.Context(), uuid)
+	if err != nil {
+		return 0, pathID(err)
+	}
+
+	return etag.Match(versions, task.Version), nil
+}
+
+// pathID reports the task id checked by the service as the task_id path
+// parameter it was sent in.
+func pathID(err error) error {
+	return validation.Locate(validation.Rename(err, "id", "task_id"), validation.InPath, "task_id")
+}
@@ -0,0 +1,236 @@
+package user
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"mime"
+	"net/http"
+	"sort"
+
+	"github.com/Alhanaqtah/effective-mobile-test-task/internal/controller/apierror"
+	"github.com/Alhanaqtah/effective-mobile-test-task/internal/lib/request"
+	"github.com/Alhanaqtah/effective-mobile-test-task/internal/lib/validation"
+	"github.com/Alhanaqtah/effective-mobile-test-task/internal/models"
+
+	jsonpatch "github.com/evanphx/json-patch/v5"
+)
+
+// Media types accepted by PATCH /users/{uuid}.
+const (
+	mediaTypeJSON       = "application/json"
+	mediaTypeMergePatch = "application/merge-patch+json"
+	mediaTypeJSONPatch  = "application/json-patch+json"
+
+	acceptPatch = mediaTypeJSON + ", " + mediaTypeMergePatch + ", " + mediaTypeJSONPatch
+)
+
+// patchable lists the user document members a patch may change.
+var patchable = []string{"name", "surname", "patronymic", "address", "passport_serie", "passport_number"}
+
+// patchMediaType returns the media type of the PATCH body. A missing
+// Content-Type is treated as plain JSON, as before patch formats existed.
+func patchMediaType(r *http.Request) (string, error) {
+	contentType := r.Header.Get("Content-Type")
+	if contentType == "" {
+		return mediaTypeJSON, nil
+	}
+
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return "", apierror.ErrUnsupportedMediaType
+	}
+
+	switch mediaType {
+	case mediaTypeJSON, mediaTypeMergePatch, mediaTypeJSONPatch:
+		return mediaType, nil
+	default:
+		return "", apierror.ErrUnsupportedMediaType
+	}
+}
+
+// decodeJSONUpdate keeps the original application/json semantics: only
+// non-empty fields are changed, so nothing can be cleared this way.
+func decodeJSONUpdate(body []byte) (models.UserUpdate, error) {
+	var req request.UpdateUser
+	if err := json.Unmarshal(body, &req); err != nil {
+		return models.UserUpdate{}, validation.ErrMalformedBody
+	}
+
+	var update models.UserUpdate
+
+	setString := func(field *models.Optional[string], value *string) {
+		if value != nil && *value != "" {
+			*field = models.Some(*value)
+		}
+	}
+	setInt := func(field *models.Optional[int], value *int) {
+		if value != nil && *value != 0 {
+			*field = models.Some(*value)
+		}
+	}
+
+	setString(&update.Name, req.Name)
+	setString(&update.Surname, req.Surname)
+	setString(&update.Patronymic, req.Patronymic)
+	setString(&update.Address, req.Address)
+	setInt(&update.PassportSerie, req.PassportSerie)
+	setInt(&update.PassportNumber, req.PassportNumber)
+
+	return update, nil
+}
+
+// DecodeMergePatch turns an RFC 7396 merge patch into an update. Members set
+// to null are cleared, absent members are left untouched.
+func DecodeMergePatch(body []byte) (models.UserUpdate, error) {
+	var doc map[string]json.RawMessage
+	if err := json.Unmarshal(body, &doc); err != nil || doc == nil {
+		return models.UserUpdate{}, validation.ErrMalformedBody
+	}
+
+	return updateFromDocument(doc)
+}
+
+// applyJSONPatch applies an RFC 6902 patch to the current state of the user
+// and returns the difference as an update.
+func applyJSONPatch(user *models.User, body []byte) (models.UserUpdate, error) {
+	patch, err := jsonpatch.DecodePatch(body)
+	if err != nil {
+		return models.UserUpdate{}, validation.ErrMalformedBody
+	}
+
+	original, err := userDocument(user)
+	if err != nil {
+		return models.UserUpdate{}, err
+	}
+
+	patched, err := patch.Apply(original)
+	if err != nil {
+		return models.UserUpdate{}, fmt.Errorf("%w: %w", apierror.ErrPatchFailed, err)
+	}
+
+	var before, after map[string]json.RawMessage
+	if err := json.Unmarshal(original, &before); err != nil {
+		return models.UserUpdate{}, err
+	}
+	if err := json.Unmarshal(patched, &after); err != nil {
+		return models.UserUpdate{}, validation.ErrMalformedBody
+	}
+
+	// Only the members the patch changed end up in the update; removed
+	// members are cleared.
+	changed := make(map[string]json.RawMessage)
+	for key, value := range after {
+		if old, ok := before[key]; !ok || !jsonEqual(old, value) {
+			changed[key] = value
+		}
+	}
+	for key := range before {
+		if _, ok := after[key]; !ok {
+			changed[key] = json.RawMessage("null")
+		}
+	}
+
+	return updateFromDocument(changed)
+}
+
+// userDocument renders the patchable part of the user as the document JSON
+// Patch operations are applied to. An empty address is represented as null.
+func userDocument(user *models.User) ([]byte, error) {
+	doc := map[string]interface{}{
+		"name":            user.Name,
+		"surname":         user.Surname,
+		"patronymic":      user.Patronymic,
+		"address":         nil,
+		"passport_serie":  user.PassportSerie,
+		"passport_number": user.PassportNumber,
+	}
+	if user.Address != "" {
+		doc["address"] = user.Address
+	}
+
+	return json.Marshal(doc)
+}
+
+func updateFromDocument(doc map[string]json.RawMessage) (models.UserUpdate, error) {
+	var update models.UserUpdate
+	var errs validation.Errors
+
+	keys := make([]string, 0, len(doc))
+	for key := range doc {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		if !isPatchable(key) {
+			errs = append(errs, validation.Field(key, validation.InBody, validation.ErrUnknownField))
+		}
+	}
+
+	decodeString := func(field string, target *models.Optional[string]) {
+		raw, ok := doc[field]
+		if !ok {
+			return
+		}
+		if isNull(raw) {
+			*target = models.Null[string]()
+			return
+		}
+		var value string
+		if err := json.Unmarshal(raw, &value); err != nil {
+			errs = append(errs, validation.Field(field, validation.InBody, validation.ErrInvalid))
+			return
+		}
+		*target = models.Some(value)
+	}
+
+	decodeInt := func(field string, target *models.Optional[int]) {
+		raw, ok := doc[field]
+		if !ok {
+			return
+		}
+		if isNull(raw) {
+			*target = models.Null[int]()
+			return
+		}
+		var value int
+		if err := json.Unmarshal(raw, &value); err != nil {
+			errs = append(errs, validation.Field(field, validation.InBody, validation.ErrInvalid))
+			return
+		}
+		*target = models.Some(value)
+	}
+
+	decodeString("name", &update.Name)
+	decodeString("surname", &update.Surname)
+	decodeString("patronymic", &update.Patronymic)
+	decodeString("address", &update.Address)
+	decodeInt("passport_serie", &update.PassportSerie)
+	decodeInt("passport_number", &update.PassportNumber)
+
+	return update, errs.Err()
+}
+
+func isPatchable(field string) bool {
+	for _, f := range patchable {
+		if f == field {
+			return true
+		}
+	}
+	return false
+}
+
+func isNull(raw json.RawMessage) bool {
+	return bytes.Equal(bytes.TrimSpace(raw), []byte("null"))
+}
+
+func jsonEqual(a, b json.RawMessage) bool {
+	var x, y interface{}
+	if json.Unmarshal(a, &x) != nil || json.Unmarshal(b, &y) != nil {
+		return false
+	}
+	xb, _ := json.Marshal(x)
+	yb, _ := json.Marshal(y)
+	return bytes.Equal(xb, yb)
+}
@@ -2,29 +2,31 @@ package user
 
 import (
 	"context"
-	"errors"
+	"io"
 	"log/slog"
 	"net/http"
 	"strconv"
-	"strings"
 
-	"time-tracker/internal/lib/logger/sl"
-	"time-tracker/internal/lib/request"
-	"time-tracker/internal/lib/response"
-	"time-tracker/internal/models"
-	service "time-tracker/internal/service/user"
+	"github.com/Alhanaqtah/effective-mobile-test-task/internal/controller/apierror"
+	"github.com/Alhanaqtah/effective-mobile-test-task/internal/lib/etag"
+	"github.com/Alhanaqtah/effective-mobile-test-task/internal/lib/logger/sl"
+	"github.com/Alhanaqtah/effective-mobile-test-task/internal/lib/request"
+	"github.com/Alhanaqtah/effective-mobile-test-task/internal/lib/response"
+	"github.com/Alhanaqtah/effective-mobile-test-task/internal/lib/validation"
+	"github.com/Alhanaqtah/effective-mobile-test-task/internal/models"
+	service "github.com/Alhanaqtah/effective-mobile-test-task/internal/service/user"
 
 	"github.com/go-chi/chi/middleware"
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/render"
-	uuidlib "github.com/google/uuid"
 )
 
 type Service interface {
 	CreateUser(ctx context.Context, passportSerie, passportNumber int) (*models.User, error)
 	GetUsers(ctx context.Context, page int, filter string) ([]models.User, error)
-	UpdateUserInfo(ctx context.Context, userInfo *models.User) (*models.User, error)
-	RemoveUserByUUID(ctx context.Context, uuid string) error
+	GetUser(ctx context.Context, uuid string) (*models.User, error)
+	UpdateUserInfo(ctx context.Context, uuid string, update models.UserUpdate, version int) (*models.User, error)
+	RemoveUserByUUID(ctx context.Context, uuid string, version int) error
 }
 
 type TaskService interface {
@@ -47,6 +49,7 @@ func (h *Handler) Register() func(r chi.Router) {
 	return func(r chi.Router) {
 		r.Post("/", h.createUser)
 		r.Get("/", h.getUsers)
+		r.Get("/{uuid}", h.getUser)
 		r.Patch("/{uuid}", h.updateUser)
 		r.Delete("/{uuid}", h.deleteUser)
 	}
@@ -58,10 +61,13 @@ func (h *Handler) Register() func(r chi.Router) {
 // @Accept json
 // @Produce json
 // @Param CreateUser body request.CreateUser true "Данные для создания пользователя"
+// @Param Idempotency-Key header string false "Ключ идемпотентности для безопасного повтора запроса"
 // @Success 201 {object} models.User "Пользователь создан успешно"
-// @Failure 400 {object} response.Response "Некорректные данные запроса"
-// @Failure 409 {object} response.Response "Пользователь уже существует"
-// @Failure 500 {object} response.Response "Внутренняя ошибка сервера"
+// @Failure 400 {object} problem.Problem "Некорректные данные запроса"
+// @Failure 409 {object} problem.Problem "Пользователь уже существует или запрос с тем же ключом идемпотентности ещё обрабатывается"
+// @Failure 422 {object} problem.Problem "Паспортные данные отклонены внешним сервисом или ключ идемпотентности использован для другого запроса"
+// @Failure 500 {object} problem.Problem "Внутренняя ошибка сервера"
+// @Failure 502 {object} problem.Problem "Внешний сервис недоступен"
 // @Router /users [post]
 func (h *Handler) createUser(w http.ResponseWriter, r *http.Request) {
 	const op = "controller.user.createUser"
@@ -69,54 +75,29 @@ func (h *Handler) createUser(w http.ResponseWriter, r *http.Request) {
 	log := h.log.With(
 		slog.String("op", op),
 		slog.String("req_id", middleware.GetReqID(r.Context())),
+		sl.Trace(r.Context()),
 	)
 
-	var credentials *request.CreateUser
+	var credentials request.CreateUser
 	if err := render.DecodeJSON(r.Body, &credentials); err != nil {
 		log.Error("failed to decode request body", sl.Error(err))
-		render.Status(r, http.StatusInternalServerError)
-		render.JSON(w, r, response.Err("Internal Error"))
-		return
-	}
-
-	if credentials.PassportNumber == "" {
-		log.Debug("request body is empty")
-		render.Status(r, http.StatusBadRequest)
-		render.JSON(w, r, response.Err("Request body is empty"))
+		apierror.Write(w, r, validation.ErrMalformedBody)
 		return
 	}
 
 	log.Debug("creating new user", slog.String("passport_number", credentials.PassportNumber))
 
-	passport := strings.Split(credentials.PassportNumber, " ")
-
-	passportSerie, err := strconv.Atoi(passport[0])
+	passportSerie, passportNumber, err := service.ParsePassport(credentials.PassportNumber)
 	if err != nil {
-		log.Error("failed to get passport serie", sl.Error(err))
-		render.Status(r, http.StatusBadRequest)
-		render.JSON(w, r, response.Err("Invalid passport serie"))
-		return
-	}
-
-	passportNumber, err := strconv.Atoi(passport[1])
-	if err != nil {
-		log.Error("failed to get passport number", sl.Error(err))
-		render.Status(r, http.StatusBadRequest)
-		render.JSON(w, r, response.Err("Invalid passport number"))
+		log.Error("failed to parse passport", sl.Error(err))
+		apierror.Write(w, r, validation.Field("passportNumber", validation.InBody, err))
 		return
 	}
 
 	user, err := h.service.CreateUser(r.Context(), passportSerie, passportNumber)
 	if err != nil {
-		if errors.Is(err, service.ErrExists) {
-			render.Status(r, http.StatusConflict)
-			render.JSON(w, r, response.Err("User already exists"))
-			return
-		} else {
-			render.Status(r, http.StatusInternalServerError)
-			render.JSON(w, r, response.Err("Internal error"))
-			return
-		}
+		apierror.Write(w, r, err)
+		return
 	}
 
 	log.Debug("user created successfully")
@@ -130,10 +111,10 @@ func (h *Handler) createUser(w http.ResponseWriter, r *http.Request) {
 // @Tags users
 // @Accept json
 // @Produce json
-// @Param page query int false "Номер страницы" default(1)
+// @Param page query int false "Номер страницы" default(1) minimum(1)
 // @Param filter query string false "Строка фильтра"
 // @Success 200 {array} models.User
-// @Failure 500 {object} response.Response "Внутренняя ошибка"
+// @Failure 500 {object} problem.Problem "Внутренняя ошибка"
 // @Router /users [get]
 func (h *Handler) getUsers(w http.ResponseWriter, r *http.Request) {
 	const op = "controller.user.getUsers"
@@ -141,23 +122,13 @@ func (h *Handler) getUsers(w http.ResponseWriter, r *http.Request) {
 	log := h.log.With(
 		slog.String("op", op),
 		slog.String("req_id", middleware.GetReqID(r.Context())),
+		sl.Trace(r.Context()),
 	)
 
-	// Getting `page` param & validation
-	var page int
-	p := r.URL.Query().Get("page")
-	if p == "" {
-		page = 1
-		log.Debug(`set "page" value to default`, slog.Int("page", page))
-	} else {
-		parsedPage, err := strconv.Atoi(p)
-		if err != nil || parsedPage < 1 {
-			log.Error(`error while parsing "page" param`, sl.Error(err))
-			page = 1
-			log.Debug(`set "page" value to default`, slog.Int("page", page))
-		}
-		page = parsedPage
-		log.Debug(`validate "page" value`, slog.Int("page", page))
+	// Getting `page` param, validated against the spec
+	page := 1
+	if p := r.URL.Query().Get("page"); p != "" {
+		page, _ = strconv.Atoi(p)
 	}
 
 	// Getting `filter` param
@@ -167,8 +138,7 @@ func (h *Handler) getUsers(w http.ResponseWriter, r *http.Request) {
 
 	users, err := h.service.GetUsers(r.Context(), page, filter)
 	if err != nil {
-		render.Status(r, http.StatusInternalServerError)
-		render.JSON(w, r, response.Err("Internal error"))
+		apierror.Write(w, r, err)
 		return
 	}
 
@@ -177,66 +147,142 @@ func (h *Handler) getUsers(w http.ResponseWriter, r *http.Request) {
 	render.JSON(w, r, users)
 }
 
-// @Summary Обновить пользователя
-// @Description Обновить информацию о пользователе по UUID
+// @Summary Получить пользователя
+// @Description Получить пользователя по UUID. Версия пользователя возвращается в заголовке ETag
 // @Tags users
 // @Accept json
 // @Produce json
-// @Param uuid path string true "UUID пользователя"
-// @Param user body models.User true "Информация о пользователе"
+// @Param uuid path string true "UUID пользователя" format(uuid)
+// @Param If-None-Match header string false "ETag закэшированной версии пользователя"
+// @Success 200 {object} models.User
+// @Header 200 {string} ETag "Версия пользователя"
+// @Success 304 "Пользователь не изменился"
+// @Failure 400 {object} problem.Problem "Неверный формат UUID"
+// @Failure 404 {object} problem.Problem "Пользователь не найден"
+// @Failure 500 {object} problem.Problem "Внутренняя ошибка"
+// @Router /users/{uuid} [get]
+func (h *Handler) getUser(w http.ResponseWriter, r *http.Request) {
+	const op = "controller.user.getUser"
+
+	log := h.log.With(
+		slog.String("op", op),
+		slog.String("req_id", middleware.GetReqID(r.Context())),
+		sl.Trace(r.Context()),
+	)
+
+	uuid := chi.URLParam(r, "uuid")
+
+	log.Debug("getting user", slog.String("user_uuid", uuid))
+
+	user, err := h.service.GetUser(r.Context(), uuid)
+	if err != nil {
+		apierror.Write(w, r, pathID(err))
+		return
+	}
+
+	tag := etag.Format(user.Version)
+	w.Header().Set("ETag", tag)
+	w.Header().Set("Accept-Patch", acceptPatch)
+
+	if !etag.NoneMatch(r.Header.Get("If-None-Match"), tag) {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+
+	log.Debug("got user successfully", slog.String("user_uuid", uuid))
+
+	render.JSON(w, r, user)
+}
+
+// @Summary Обновить пользователя
+// @Description Обновить информацию о пользователе по UUID.
+// @Description application/json изменяет только непустые поля, application/merge-patch+json (RFC 7396) позволяет очистить адрес через null,
+// @Description application/json-patch+json (RFC 6902) применяет список операций к текущему состоянию пользователя
+// @Tags users
+// @Accept json,application/merge-patch+json,application/json-patch+json
+// @Produce json
+// @Param uuid path string true "UUID пользователя" format(uuid)
+// @Param If-Match header string false "ETag версии пользователя, которую нужно обновить"
+// @Param user body request.UpdateUser true "Изменяемые поля пользователя"
 // @Success 200 {object} models.User
-// @Failure 400 {object} response.Response "Неверный формат UUID или пустое тело запроса"
-// @Failure 404 {object} response.Response "Пользователь не найден"
-// @Failure 500 {object} response.Response "Внутренняя ошибка"
-// @Router /users/{uuid} [put]
+// @Header 200 {string} ETag "Новая версия пользователя"
+// @Failure 400 {object} problem.Problem "Неверный формат UUID, некорректные поля или пустое тело запроса"
+// @Failure 404 {object} problem.Problem "Пользователь не найден"
+// @Failure 409 {object} problem.Problem "Пользователь с такими паспортными данными уже существует"
+// @Failure 412 {object} problem.Problem "Версия пользователя не совпадает с If-Match"
+// @Failure 415 {object} problem.Problem "Неподдерживаемый тип содержимого"
+// @Failure 422 {object} problem.Problem "JSON Patch не может быть применён"
+// @Failure 500 {object} problem.Problem "Внутренняя ошибка"
+// @Router /users/{uuid} [patch]
 func (h *Handler) updateUser(w http.ResponseWriter, r *http.Request) {
 	const op = "controller.user.updateUser"
 
 	log := h.log.With(
 		slog.String("op", op),
 		slog.String("req_id", middleware.GetReqID(r.Context())),
+		sl.Trace(r.Context()),
 	)
 
 	uuid := chi.URLParam(r, "uuid")
 
-	_, err := uuidlib.Parse(uuid)
+	version, err := h.ifMatch(r, uuid)
 	if err != nil {
-		log.Error("invalid userUUID", sl.Error(err))
-		render.Status(r, http.StatusBadRequest)
-		render.JSON(w, r, response.Err(`invalid user uuid format`))
+		log.Error("failed to evaluate If-Match header", sl.Error(err))
+		apierror.Write(w, r, err)
 		return
 	}
 
-	log.Debug("patching user info", slog.String("user_uuid", uuid))
+	mediaType, err := patchMediaType(r)
+	if err != nil {
+		log.Error("unsupported media type", slog.String("content_type", r.Header.Get("Content-Type")))
+		w.Header().Set("Accept-Patch", acceptPatch)
+		apierror.Write(w, r, err)
+		return
+	}
 
-	var userInfo models.User
-	if err := render.DecodeJSON(r.Body, &userInfo); err != nil {
-		log.Error("failed to decode request body", sl.Error(err))
-		render.Status(r, http.StatusInternalServerError)
-		render.JSON(w, r, response.Err("Internal Error"))
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		log.Error("failed to read request body", sl.Error(err))
+		apierror.Write(w, r, validation.ErrMalformedBody)
 		return
 	}
 
-	// Set uuid from user
-	userInfo.ID = uuid
+	log.Debug("patching user info", slog.String("user_uuid", uuid), slog.String("media_type", mediaType), slog.Int("version", version))
 
-	user, err := h.service.UpdateUserInfo(r.Context(), &userInfo)
-	if err != nil {
-		if errors.Is(err, service.ErrUserNotFound) {
-			render.Status(r, http.StatusNotFound)
-			render.JSON(w, r, response.Err("User not found"))
-			return
-		} else if errors.Is(err, service.ErrEmptyBody) {
-			render.Status(r, http.StatusBadRequest)
-			render.JSON(w, r, response.Err("Request body is empty"))
-			return
-		} else {
-			render.Status(r, http.StatusInternalServerError)
-			render.JSON(w, r, response.Err("Internal error"))
-			return
+	var update models.UserUpdate
+
+	switch mediaType {
+	case mediaTypeMergePatch:
+		update, err = DecodeMergePatch(body)
+	case mediaTypeJSONPatch:
+		// JSON Patch operations are applied to a snapshot of the user, so the
+		// update must not overwrite changes made after the snapshot was taken.
+		var current *models.User
+		current, err = h.service.GetUser(r.Context(), uuid)
+		if err != nil {
+			break
 		}
+		if version == etag.Any {
+			version = current.Version
+		}
+		update, err = applyJSONPatch(current, body)
+	default:
+		update, err = decodeJSONUpdate(body)
+	}
+	if err != nil {
+		log.Error("failed to decode user update", sl.Error(err))
+		apierror.Write(w, r, pathID(err))
+		return
 	}
 
+	user, err := h.service.UpdateUserInfo(r.Context(), uuid, update, version)
+	if err != nil {
+		apierror.Write(w, r, validation.Locate(pathID(err), validation.InBody))
+		return
+	}
+
+	w.Header().Set("ETag", etag.Format(user.Version))
+
 	log.Debug("user patched succesfully", slog.String("user_uuid", uuid))
 
 	render.Status(r, http.StatusOK)
@@ -248,11 +294,13 @@ func (h *Handler) updateUser(w http.ResponseWriter, r *http.Request) {
 // @Tags users
 // @Accept json
 // @Produce json
-// @Param uuid path string true "UUID пользователя"
+// @Param uuid path string true "UUID пользователя" format(uuid)
+// @Param If-Match header string false "ETag версии пользователя, которого нужно удалить"
 // @Success 200 {object} response.Response "Пользователь успешно удалён"
-// @Failure 400 {object} response.Response "Неверный формат UUID"
-// @Failure 404 {object} response.Response "Пользователь не найден"
-// @Failure 500 {object} response.Response "Внутренняя ошибка"
+// @Failure 400 {object} problem.Problem "Неверный формат UUID"
+// @Failure 404 {object} problem.Problem "Пользователь не найден"
+// @Failure 412 {object} problem.Problem "Версия пользователя не совпадает с If-Match"
+// @Failure 500 {object} problem.Problem "Внутренняя ошибка"
 // @Router /users/{uuid} [delete]
 func (h *Handler) deleteUser(w http.ResponseWriter, r *http.Request) {
 	const op = "controller.user.deleteUser"
@@ -260,31 +308,24 @@ func (h *Handler) deleteUser(w http.ResponseWriter, r *http.Request) {
 	log := h.log.With(
 		slog.String("op", op),
 		slog.String("req_id", middleware.GetReqID(r.Context())),
+		sl.Trace(r.Context()),
 	)
 
 	uuid := chi.URLParam(r, "uuid")
 
-	_, err := uuidlib.Parse(uuid)
+	version, err := h.ifMatch(r, uuid)
 	if err != nil {
-		log.Error("invalid userUUID", sl.Error(err))
-		render.Status(r, http.StatusBadRequest)
-		render.JSON(w, r, response.Err(`invalid user uuid format`))
+		log.Error("failed to evaluate If-Match header", sl.Error(err))
+		apierror.Write(w, r, err)
 		return
 	}
 
-	log.Debug("removing user", slog.String("user_uuid", uuid))
+	log.Debug("removing user", slog.String("user_uuid", uuid), slog.Int("version", version))
 
-	err = h.service.RemoveUserByUUID(r.Context(), uuid)
+	err = h.service.RemoveUserByUUID(r.Context(), uuid, version)
 	if err != nil {
-		if errors.Is(err, service.ErrUserNotFound) {
-			render.Status(r, http.StatusNotFound)
-			render.JSON(w, r, response.Err("User not found"))
-			return
-		} else {
-			render.Status(r, http.StatusInternalServerError)
-			render.JSON(w, r, response.Err("Internal error"))
-			return
-		}
+		apierror.Write(w, r, pathID(err))
+		return
 	}
 
 	log.Debug("user removed succesfully", slog.String("user_uuid", uuid))
@@ -292,3 +333,29 @@ func (h *Handler) deleteUser(w http.ResponseWriter, r *http.Request) {
 	render.Status(r, http.StatusOK)
 	render.JSON(w, r, response.Ok("User removed successfully"))
 }
+
+// ifMatch returns the version a write of the user uuid must expect. If-Match
+// may list several entity tags, so the current version of the user is looked
+// up to tell which of them applies.
+func (h *Handler) ifMatch(r *http.Request, uuid string) (int, error) {
+	versions, err := etag.ParseIfMatch(r.Header.Get("If-Match"))
+	if err != nil {
+		return 0, validation.Field("If-Match", validation.InHeader, validation.ErrInvalid)
+	}
+	if len(versions) < 2 {
+		return etag.Match(versions, etag.Any), nil
+	}
+
+	user, err := h.service.GetUser(r.Context(), uuid)
+	if err != nil {
+		return 0, pathID(err)
+	}
+
+	return etag.Match(versions, user.Version), nil
+}
+
+// pathID reports the user id checked by the service as the uuid path
+// parameter it was sent in.
+func pathID(err error) error {
+	return validation.Locate(validation.Rename(err, "id", "uuid"), validation.InPath, "uuid")
+}
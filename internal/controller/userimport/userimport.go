@@ -0,0 +1,174 @@
+package userimport
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"mime"
+	"net/http"
+	"slices"
+	"strconv"
+
+	"github.com/Alhanaqtah/effective-mobile-test-task/internal/controller/apierror"
+	"github.com/Alhanaqtah/effective-mobile-test-task/internal/lib/logger/sl"
+	"github.com/Alhanaqtah/effective-mobile-test-task/internal/lib/validation"
+	"github.com/Alhanaqtah/effective-mobile-test-task/internal/models"
+	service "github.com/Alhanaqtah/effective-mobile-test-task/internal/service/userimport"
+
+	"github.com/go-chi/chi/middleware"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+)
+
+// maxBodySize limits the size of an uploaded import file.
+const maxBodySize = 4 << 20
+
+var rowStatuses = []string{
+	models.ImportRowPending,
+	models.ImportRowCreated,
+	models.ImportRowExists,
+	models.ImportRowInvalid,
+	models.ImportRowEnrichmentFailed,
+	models.ImportRowFailed,
+}
+
+type Service interface {
+	Import(ctx context.Context, format string, r io.Reader) (*models.ImportJob, error)
+	GetJob(ctx context.Context, jobID, rowStatus string, page int) (*models.ImportJob, error)
+}
+
+type Handler struct {
+	service Service
+	log     *slog.Logger
+}
+
+func New(service Service, log *slog.Logger) *Handler {
+	return &Handler{
+		service: service,
+		log:     log,
+	}
+}
+
+func (h *Handler) Register() func(r chi.Router) {
+	return func(r chi.Router) {
+		r.Post("/", h.importUsers)
+		r.Get("/{job_id}", h.getJob)
+	}
+}
+
+// @Summary Массовый импорт пользователей
+// @Description Принимает список паспортов в формате CSV (одна колонка "<серия> <номер>" или две колонки серии и номера, заголовок необязателен) или NDJSON (по объекту {"passportNumber": "1234 567890"} на строку) и создает задание импорта, которое обрабатывается в фоне. Ход выполнения доступен по адресу из заголовка Location
+// @Tags users
+// @Accept text/csv
+// @Accept application/x-ndjson
+// @Produce json
+// @Param file body string true "Список паспортов"
+// @Success 202 {object} models.ImportJob "Задание импорта создано"
+// @Header 202 {string} Location "Адрес статуса задания импорта"
+// @Failure 400 {object} problem.Problem "Некорректный или пустой файл импорта"
+// @Failure 413 {object} problem.Problem "Слишком много строк в файле импорта"
+// @Failure 415 {object} problem.Problem "Неподдерживаемый формат файла"
+// @Failure 500 {object} problem.Problem "Внутренняя ошибка сервера"
+// @Router /users/import [post]
+func (h *Handler) importUsers(w http.ResponseWriter, r *http.Request) {
+	const op = "controller.userimport.importUsers"
+
+	log := h.log.With(
+		slog.String("op", op),
+		slog.String("req_id", middleware.GetReqID(r.Context())),
+		sl.Trace(r.Context()),
+	)
+
+	format, err := importFormat(r)
+	if err != nil {
+		log.Error("unsupported content type", slog.String("content_type", r.Header.Get("Content-Type")))
+		apierror.Write(w, r, err)
+		return
+	}
+
+	job, err := h.service.Import(r.Context(), format, http.MaxBytesReader(w, r.Body, maxBodySize))
+	if err != nil {
+		log.Error("failed to import users", sl.Error(err))
+
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			apierror.Write(w, r, apierror.ErrBodyTooLarge)
+			return
+		}
+		apierror.Write(w, r, err)
+		return
+	}
+
+	log.Debug("import job created", slog.String("job_id", job.ID))
+
+	w.Header().Set("Location", r.URL.JoinPath(job.ID).Path)
+	render.Status(r, http.StatusAccepted)
+	render.JSON(w, r, job)
+}
+
+// @Summary Статус задания импорта
+// @Description Возвращает статус задания импорта, количество строк в каждом статусе и постраничный список результатов обработки строк
+// @Tags users
+// @Produce json
+// @Param job_id path string true "UUID задания импорта"
+// @Param status query string false "Фильтр строк по статусу" Enums(pending, created, exists, invalid, enrichment_failed, failed)
+// @Param page query int false "Номер страницы" default(1)
+// @Success 200 {object} models.ImportJob
+// @Failure 400 {object} problem.Problem "Некорректные параметры запроса"
+// @Failure 404 {object} problem.Problem "Задание импорта не найдено"
+// @Failure 500 {object} problem.Problem "Внутренняя ошибка сервера"
+// @Router /users/import/{job_id} [get]
+func (h *Handler) getJob(w http.ResponseWriter, r *http.Request) {
+	const op = "controller.userimport.getJob"
+
+	log := h.log.With(
+		slog.String("op", op),
+		slog.String("req_id", middleware.GetReqID(r.Context())),
+		sl.Trace(r.Context()),
+	)
+
+	jobID := chi.URLParam(r, "job_id")
+
+	status := r.URL.Query().Get("status")
+	if status != "" && !slices.Contains(rowStatuses, status) {
+		log.Error(`invalid "status" param`, slog.String("status", status))
+		apierror.Write(w, r, validation.Field("status", validation.InQuery, validation.ErrInvalid))
+		return
+	}
+
+	page := 1
+	if p := r.URL.Query().Get("page"); p != "" {
+		parsedPage, err := strconv.Atoi(p)
+		if err != nil || parsedPage < 1 {
+			log.Error(`error while parsing "page" param`, sl.Error(err))
+			apierror.Write(w, r, validation.Field("page", validation.InQuery, validation.ErrInvalid))
+			return
+		}
+		page = parsedPage
+	}
+
+	job, err := h.service.GetJob(r.Context(), jobID, status, page)
+	if err != nil {
+		apierror.Write(w, r, err)
+		return
+	}
+
+	render.JSON(w, r, job)
+}
+
+func importFormat(r *http.Request) (string, error) {
+	mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if err != nil {
+		return "", apierror.ErrUnsupportedMediaType
+	}
+
+	switch mediaType {
+	case "text/csv":
+		return service.FormatCSV, nil
+	case "application/x-ndjson", "application/jsonl":
+		return service.FormatNDJSON, nil
+	default:
+		return "", apierror.ErrUnsupportedMediaType
+	}
+}
@@ -0,0 +1,268 @@
+package webhook
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"slices"
+	"strconv"
+
+	"github.com/Alhanaqtah/effective-mobile-test-task/internal/controller/apierror"
+	"github.com/Alhanaqtah/effective-mobile-test-task/internal/lib/logger/sl"
+	"github.com/Alhanaqtah/effective-mobile-test-task/internal/lib/request"
+	"github.com/Alhanaqtah/effective-mobile-test-task/internal/lib/validation"
+	"github.com/Alhanaqtah/effective-mobile-test-task/internal/models"
+
+	"github.com/go-chi/chi/middleware"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+)
+
+var deliveryStatuses = []string{
+	models.DeliveryPending,
+	models.DeliveryDelivered,
+	models.DeliveryDead,
+}
+
+type Service interface {
+	CreateWebhook(ctx context.Context, url, secret string, eventTypes []string) (*models.Webhook, error)
+	GetWebhooks(ctx context.Context) ([]models.Webhook, error)
+	GetWebhook(ctx context.Context, webhookID string) (*models.Webhook, error)
+	SetActive(ctx context.Context, webhookID string, active bool) (*models.Webhook, error)
+	DeleteWebhook(ctx context.Context, webhookID string) error
+	GetDeliveries(ctx context.Context, webhookID, status string, page int) ([]models.WebhookDelivery, error)
+	Replay(ctx context.Context, webhookID, deliveryID string) (*models.WebhookDelivery, error)
+}
+
+type Handler struct {
+	service Service
+	log     *slog.Logger
+}
+
+func New(service Service, log *slog.Logger) *Handler {
+	return &Handler{
+		service: service,
+		log:     log,
+	}
+}
+
+func (h *Handler) Register() func(r chi.Router) {
+	return func(r chi.Router) {
+		r.Post("/", h.createWebhook)
+		r.Get("/", h.getWebhooks)
+		r.Get("/{webhook_id}", h.getWebhook)
+		r.Patch("/{webhook_id}", h.updateWebhook)
+		r.Delete("/{webhook_id}", h.deleteWebhook)
+		r.Get("/{webhook_id}/deliveries", h.getDeliveries)
+		r.Post("/{webhook_id}/deliveries/{delivery_id}/replay", h.replayDelivery)
+	}
+}
+
+// @Summary Создать подписку на события
+// @Description Регистрирует адрес, на который методом POST отправляются события пользователей и задач. Каждый запрос подписан: заголовок X-Webhook-Signature содержит "sha256=" и HMAC-SHA256 строки "<X-Webhook-Timestamp>.<тело запроса>" в шестнадцатеричном виде. Ключ подписи возвращается только в ответе на этот запрос. Неуспешные доставки повторяются с экспоненциальной задержкой, после исчерпания попыток доставка получает статус dead. Адреса localhost, loopback, частных и link-local сетей не принимаются, перенаправления не выполняются
+// @Tags webhooks
+// @Accept json
+// @Produce json
+// @Param CreateWebhook body request.CreateWebhook true "Параметры подписки"
+// @Param Idempotency-Key header string false "Ключ идемпотентности для безопасного повтора запроса"
+// @Success 201 {object} models.Webhook "Подписка создана"
+// @Failure 400 {object} problem.Problem "Некорректный запрос"
+// @Failure 409 {object} problem.Problem "Запрос с тем же ключом идемпотентности ещё обрабатывается"
+// @Failure 422 {object} problem.Problem "Ключ идемпотентности использован для другого запроса"
+// @Failure 500 {object} problem.Problem "Внутренняя ошибка сервера"
+// @Router /webhooks [post]
+func (h *Handler) createWebhook(w http.ResponseWriter, r *http.Request) {
+	const op = "controller.webhook.createWebhook"
+
+	log := h.log.With(
+		slog.String("op", op),
+		slog.String("req_id", middleware.GetReqID(r.Context())),
+		sl.Trace(r.Context()),
+	)
+
+	var req request.CreateWebhook
+	if err := render.DecodeJSON(r.Body, &req); err != nil {
+		log.Error("failed to decode request body", sl.Error(err))
+		apierror.Write(w, r, validation.ErrMalformedBody)
+		return
+	}
+
+	webhook, err := h.service.CreateWebhook(r.Context(), req.URL, req.Secret, req.EventTypes)
+	if err != nil {
+		apierror.Write(w, r, validation.Locate(err, validation.InBody))
+		return
+	}
+
+	log.Debug("webhook created successfully", slog.String("webhook_id", webhook.ID))
+
+	w.Header().Set("Location", r.URL.JoinPath(webhook.ID).Path)
+	render.Status(r, http.StatusCreated)
+	render.JSON(w, r, webhook)
+}
+
+// @Summary Получить подписки на события
+// @Tags webhooks
+// @Produce json
+// @Success 200 {array} models.Webhook
+// @Failure 500 {object} problem.Problem "Внутренняя ошибка сервера"
+// @Router /webhooks [get]
+func (h *Handler) getWebhooks(w http.ResponseWriter, r *http.Request) {
+	webhooks, err := h.service.GetWebhooks(r.Context())
+	if err != nil {
+		apierror.Write(w, r, err)
+		return
+	}
+
+	render.JSON(w, r, webhooks)
+}
+
+// @Summary Получить подписку на события
+// @Tags webhooks
+// @Produce json
+// @Param webhook_id path string true "UUID подписки"
+// @Success 200 {object} models.Webhook
+// @Failure 400 {object} problem.Problem "Некорректный UUID"
+// @Failure 404 {object} problem.Problem "Подписка не найдена"
+// @Failure 500 {object} problem.Problem "Внутренняя ошибка сервера"
+// @Router /webhooks/{webhook_id} [get]
+func (h *Handler) getWebhook(w http.ResponseWriter, r *http.Request) {
+	webhook, err := h.service.GetWebhook(r.Context(), chi.URLParam(r, "webhook_id"))
+	if err != nil {
+		apierror.Write(w, r, validation.Locate(err, validation.InPath))
+		return
+	}
+
+	render.JSON(w, r, webhook)
+}
+
+// @Summary Приостановить или возобновить подписку
+// @Description События, произошедшие пока подписка приостановлена, не доставляются. Ожидающие доставки и повторы неуспешных доставок не отправляются до возобновления подписки
+// @Tags webhooks
+// @Accept json
+// @Produce json
+// @Param webhook_id path string true "UUID подписки"
+// @Param UpdateWebhook body request.UpdateWebhook true "Изменения подписки"
+// @Success 200 {object} models.Webhook
+// @Failure 400 {object} problem.Problem "Некорректный запрос"
+// @Failure 404 {object} problem.Problem "Подписка не найдена"
+// @Failure 500 {object} problem.Problem "Внутренняя ошибка сервера"
+// @Router /webhooks/{webhook_id} [patch]
+func (h *Handler) updateWebhook(w http.ResponseWriter, r *http.Request) {
+	const op = "controller.webhook.updateWebhook"
+
+	log := h.log.With(
+		slog.String("op", op),
+		slog.String("req_id", middleware.GetReqID(r.Context())),
+		sl.Trace(r.Context()),
+	)
+
+	var req request.UpdateWebhook
+	if err := render.DecodeJSON(r.Body, &req); err != nil {
+		log.Error("failed to decode request body", sl.Error(err))
+		apierror.Write(w, r, validation.ErrMalformedBody)
+		return
+	}
+
+	if req.Active == nil {
+		apierror.Write(w, r, validation.Field("active", validation.InBody, validation.ErrRequired))
+		return
+	}
+
+	webhook, err := h.service.SetActive(r.Context(), chi.URLParam(r, "webhook_id"), *req.Active)
+	if err != nil {
+		apierror.Write(w, r, validation.Locate(err, validation.InPath))
+		return
+	}
+
+	log.Debug("webhook updated successfully", slog.Bool("active", webhook.Active))
+
+	render.JSON(w, r, webhook)
+}
+
+// @Summary Удалить подписку на события
+// @Description Удаляет подписку вместе с историей доставок
+// @Tags webhooks
+// @Param webhook_id path string true "UUID подписки"
+// @Success 204 "Подписка удалена"
+// @Failure 400 {object} problem.Problem "Некорректный UUID"
+// @Failure 404 {object} problem.Problem "Подписка не найдена"
+// @Failure 500 {object} problem.Problem "Внутренняя ошибка сервера"
+// @Router /webhooks/{webhook_id} [delete]
+func (h *Handler) deleteWebhook(w http.ResponseWriter, r *http.Request) {
+	if err := h.service.DeleteWebhook(r.Context(), chi.URLParam(r, "webhook_id")); err != nil {
+		apierror.Write(w, r, validation.Locate(err, validation.InPath))
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// @Summary Получить доставки подписки
+// @Description Возвращает доставки событий подписчику, начиная с последних. Доставки со статусом dead образуют очередь недоставленных событий, их можно отправить повторно
+// @Tags webhooks
+// @Produce json
+// @Param webhook_id path string true "UUID подписки"
+// @Param status query string false "Фильтр по статусу доставки" Enums(pending, delivered, dead)
+// @Param page query int false "Номер страницы" default(1)
+// @Success 200 {array} models.WebhookDelivery
+// @Failure 400 {object} problem.Problem "Некорректные параметры запроса"
+// @Failure 404 {object} problem.Problem "Подписка не найдена"
+// @Failure 500 {object} problem.Problem "Внутренняя ошибка сервера"
+// @Router /webhooks/{webhook_id}/deliveries [get]
+func (h *Handler) getDeliveries(w http.ResponseWriter, r *http.Request) {
+	const op = "controller.webhook.getDeliveries"
+
+	log := h.log.With(
+		slog.String("op", op),
+		slog.String("req_id", middleware.GetReqID(r.Context())),
+		sl.Trace(r.Context()),
+	)
+
+	status := r.URL.Query().Get("status")
+	if status != "" && !slices.Contains(deliveryStatuses, status) {
+		log.Error(`invalid "status" param`, slog.String("status", status))
+		apierror.Write(w, r, validation.Field("status", validation.InQuery, validation.ErrInvalid))
+		return
+	}
+
+	page := 1
+	if p := r.URL.Query().Get("page"); p != "" {
+		parsedPage, err := strconv.Atoi(p)
+		if err != nil || parsedPage < 1 {
+			log.Error(`error while parsing "page" param`, sl.Error(err))
+			apierror.Write(w, r, validation.Field("page", validation.InQuery, validation.ErrInvalid))
+			return
+		}
+		page = parsedPage
+	}
+
+	deliveries, err := h.service.GetDeliveries(r.Context(), chi.URLParam(r, "webhook_id"), status, page)
+	if err != nil {
+		apierror.Write(w, r, validation.Locate(err, validation.InPath))
+		return
+	}
+
+	render.JSON(w, r, deliveries)
+}
+
+// @Summary Повторить доставку
+// @Description Ставит доставку в очередь на немедленную отправку с обнулённым счётчиком попыток. Подходит для доставок из очереди недоставленных событий
+// @Tags webhooks
+// @Produce json
+// @Param webhook_id path string true "UUID подписки"
+// @Param delivery_id path string true "UUID доставки"
+// @Success 202 {object} models.WebhookDelivery "Доставка поставлена в очередь"
+// @Failure 400 {object} problem.Problem "Некорректный UUID"
+// @Failure 404 {object} problem.Problem "Подписка или доставка не найдена"
+// @Failure 500 {object} problem.Problem "Внутренняя ошибка сервера"
+// @Router /webhooks/{webhook_id}/deliveries/{delivery_id}/replay [post]
+func (h *Handler) replayDelivery(w http.ResponseWriter, r *http.Request) {
+	delivery, err := h.service.Replay(r.Context(), chi.URLParam(r, "webhook_id"), chi.URLParam(r, "delivery_id"))
+	if err != nil {
+		apierror.Write(w, r, validation.Locate(err, validation.InPath))
+		return
+	}
+
+	render.Status(r, http.StatusAccepted)
+	render.JSON(w, r, delivery)
+}
@@ -0,0 +1,148 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/Alhanaqtah/effective-mobile-test-task/internal/lib/logger/sl"
+	"github.com/Alhanaqtah/effective-mobile-test-task/internal/models"
+)
+
+// Channel is the Postgres notification channel events are exchanged on.
+const Channel = "time_tracker_events"
+
+const (
+	// maxPayload is the largest payload Postgres accepts in NOTIFY.
+	maxPayload = 7999
+
+	retryDelay    = time.Second
+	maxRetryDelay = 30 * time.Second
+)
+
+// PubSub delivers payloads to every instance of the service.
+type PubSub interface {
+	// Notify sends payload on channel once the transaction in ctx commits.
+	Notify(ctx context.Context, channel string, payload []byte) error
+	// Listen calls ready once it listens on channel and handle for every
+	// payload sent on it, until ctx is done or the connection fails.
+	Listen(ctx context.Context, channel string, ready func(), handle func(payload []byte)) error
+}
+
+// Cluster publishes events through the database to the brokers of all
+// instances sharing it, this one included. Event ids are assigned by each
+// broker, so a client resuming a stream on another instance gets a reset.
+type Cluster struct {
+	broker *Broker
+	pubsub PubSub
+	log    *slog.Logger
+}
+
+func NewCluster(broker *Broker, pubsub PubSub, log *slog.Logger) *Cluster {
+	return &Cluster{
+		broker: broker,
+		pubsub: pubsub,
+		log:    log,
+	}
+}
+
+// Publish sends the event to every instance. It must be called in the
+// transaction making the change, so the event is delivered if and only if
+// the change commits.
+func (c *Cluster) Publish(ctx context.Context, e models.Event) error {
+	const op = "events.Cluster.Publish"
+
+	// Instances agree on when the event happened.
+	if e.Time.IsZero() {
+		e.Time = time.Now()
+	}
+
+	payload, err := encode(e)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := c.pubsub.Notify(ctx, Channel, payload); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func encode(e models.Event) ([]byte, error) {
+	e.ID = ""
+
+	payload, err := json.Marshal(e)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(payload) <= maxPayload {
+		return payload, nil
+	}
+
+	// Too large to notify; subscribers still learn what changed and can
+	// fetch the entity.
+	e.Data = nil
+	return json.Marshal(e)
+}
+
+// Run publishes the events of all instances to the local broker until ctx
+// is done. The listener reconnects after failures.
+func (c *Cluster) Run(ctx context.Context) {
+	const op = "events.Cluster.Run"
+
+	log := c.log.With(slog.String("op", op))
+
+	delay := retryDelay
+	reconnect := false
+
+	for {
+		started := time.Now()
+
+		err := c.pubsub.Listen(ctx, Channel, func() {
+			if reconnect {
+				// Events sent while no listener was connected are lost.
+				log.Warn("event listener reconnected, resetting subscribers")
+				c.broker.Reset()
+			}
+		}, c.receive)
+		if ctx.Err() != nil {
+			return
+		}
+		reconnect = true
+
+		// Reset the backoff once a connection has been healthy for a while.
+		if time.Since(started) > maxRetryDelay {
+			delay = retryDelay
+		}
+
+		if err == nil {
+			err = errors.New("listener stopped")
+		}
+		log.Error("event listener failed, reconnecting", slog.Duration("delay", delay), sl.Error(err))
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(delay):
+		}
+
+		delay = min(delay*2, maxRetryDelay)
+	}
+}
+
+func (c *Cluster) receive(payload []byte) {
+	const op = "events.Cluster.receive"
+
+	var e models.Event
+	if err := json.Unmarshal(payload, &e); err != nil {
+		c.log.Error("failed to decode event", slog.String("op", op), sl.Error(err))
+		return
+	}
+
+	c.broker.Publish(e)
+}
@@ -0,0 +1,104 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Alhanaqtah/effective-mobile-test-task/internal/models"
+)
+
+// loopback delivers notifications to its listener right away and fails
+// the first listen after ready, like a dropped connection.
+type loopback struct {
+	handle  func(payload []byte)
+	listens int
+	done    chan struct{}
+}
+
+func (p *loopback) Notify(_ context.Context, _ string, payload []byte) error {
+	p.handle(payload)
+	return nil
+}
+
+func (p *loopback) Listen(ctx context.Context, _ string, ready func(), handle func(payload []byte)) error {
+	p.handle = handle
+	p.listens++
+	ready()
+
+	if p.listens == 1 {
+		return errors.New("connection lost")
+	}
+
+	close(p.done)
+	<-ctx.Done()
+	return ctx.Err()
+}
+
+func TestClusterResetsAfterReconnect(t *testing.T) {
+	broker := New(16)
+	pubsub := &loopback{done: make(chan struct{})}
+	cluster := NewCluster(broker, pubsub, slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+	sub, _, _ := broker.Subscribe("", Filter{})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go cluster.Run(ctx)
+
+	select {
+	case <-pubsub.done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("listener did not reconnect")
+	}
+
+	if _, ok := <-sub.C; ok {
+		t.Fatal("subscription is open after reconnect")
+	}
+
+	sub, _, _ = broker.Subscribe("", Filter{})
+	if err := cluster.Publish(ctx, models.Event{Type: models.EventTaskCreated, UserID: "u1"}); err != nil {
+		t.Fatal(err)
+	}
+
+	e := <-sub.C
+	if e.Type != models.EventTaskCreated || e.UserID != "u1" || e.ID == "" || e.Time.IsZero() {
+		t.Fatalf("got event %+v", e)
+	}
+}
+
+func TestBrokerReset(t *testing.T) {
+	broker := New(16)
+	broker.Publish(models.Event{Type: models.EventTaskCreated})
+
+	sub, _, _ := broker.Subscribe("", Filter{})
+	broker.Publish(models.Event{Type: models.EventTaskUpdated})
+	last := (<-sub.C).ID
+
+	broker.Reset()
+
+	if _, ok := <-sub.C; ok {
+		t.Fatal("subscription is open after reset")
+	}
+	if _, replay, resumed := broker.Subscribe(last, Filter{}); resumed || len(replay) > 0 {
+		t.Fatalf("resumed %v with %d events from before the reset", resumed, len(replay))
+	}
+}
+
+func TestEncodeDropsLargeData(t *testing.T) {
+	payload, err := encode(models.Event{ID: "x.1", Type: models.EventUserUpdated, Data: strings.Repeat("a", maxPayload)})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(payload) > maxPayload {
+		t.Fatalf("payload of %d bytes", len(payload))
+	}
+	if strings.Contains(string(payload), `"x.1"`) || !strings.Contains(string(payload), `"data":null`) {
+		t.Fatalf("payload %s", payload)
+	}
+}
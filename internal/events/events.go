@@ -0,0 +1,179 @@
+// Package events fans out user and task events to live subscribers and
+// keeps the most recent ones so that subscribers can resume after
+// a reconnect.
+package events
+
+import (
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Alhanaqtah/effective-mobile-test-task/internal/models"
+)
+
+// subscriberBuffer is the number of events a subscriber may lag behind
+// before it is disconnected.
+const subscriberBuffer = 64
+
+// Filter selects events for a subscriber. Empty sets match everything.
+type Filter struct {
+	UserIDs map[string]bool
+	Types   map[string]bool
+}
+
+func (f Filter) Match(e models.Event) bool {
+	if len(f.UserIDs) > 0 && !f.UserIDs[e.UserID] {
+		return false
+	}
+	if len(f.Types) > 0 && !f.Types[e.Type] {
+		return false
+	}
+	return true
+}
+
+// Subscription delivers events matching its filter. C is closed when the
+// subscription is closed or the subscriber falls too far behind.
+type Subscription struct {
+	C <-chan models.Event
+
+	c      chan models.Event
+	filter Filter
+	broker *Broker
+}
+
+func (s *Subscription) Close() {
+	s.broker.unsubscribe(s)
+}
+
+// Broker is an in-memory event bus with a bounded replay buffer. Event ids
+// are "<epoch>.<seq>", where epoch identifies the process, so ids from
+// before a restart are recognised as not resumable.
+type Broker struct {
+	mu     sync.Mutex
+	epoch  string
+	seq    uint64
+	buffer []models.Event
+	start  int
+	subs   map[*Subscription]struct{}
+}
+
+func New(size int) *Broker {
+	return &Broker{
+		epoch:  strconv.FormatInt(time.Now().UnixNano(), 36),
+		buffer: make([]models.Event, 0, max(size, 1)),
+		subs:   make(map[*Subscription]struct{}),
+	}
+}
+
+// Publish assigns the event an id and delivers it to subscribers.
+func (b *Broker) Publish(e models.Event) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.seq++
+	e.ID = b.epoch + "." + strconv.FormatUint(b.seq, 10)
+	if e.Time.IsZero() {
+		e.Time = time.Now()
+	}
+
+	if len(b.buffer) < cap(b.buffer) {
+		b.buffer = append(b.buffer, e)
+	} else {
+		b.buffer[b.start] = e
+		b.start = (b.start + 1) % len(b.buffer)
+	}
+
+	for s := range b.subs {
+		if !s.filter.Match(e) {
+			continue
+		}
+
+		select {
+		case s.c <- e:
+		default:
+			// The subscriber can't keep up; it reconnects with Last-Event-ID.
+			b.remove(s)
+		}
+	}
+}
+
+// Reset starts a new epoch, forgetting the buffered events and closing every
+// subscription, for when events may have been missed. Subscribers resuming
+// with an id of the old epoch are told to reload their state.
+func (b *Broker) Reset() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.epoch = strconv.FormatInt(time.Now().UnixNano(), 36)
+	b.seq = 0
+	b.buffer = b.buffer[:0]
+	b.start = 0
+
+	for s := range b.subs {
+		b.remove(s)
+	}
+}
+
+// Subscribe starts a subscription. If lastEventID is given, buffered events
+// published after it are returned for replay; resumed is false when the
+// event is no longer buffered or comes from another process, meaning some
+// events were missed.
+func (b *Broker) Subscribe(lastEventID string, filter Filter) (sub *Subscription, replay []models.Event, resumed bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	c := make(chan models.Event, subscriberBuffer)
+	sub = &Subscription{C: c, c: c, filter: filter, broker: b}
+	b.subs[sub] = struct{}{}
+
+	if lastEventID == "" {
+		return sub, nil, true
+	}
+
+	last, ok := b.parseID(lastEventID)
+	if !ok || last > b.seq {
+		return sub, nil, false
+	}
+
+	oldest := b.seq - uint64(len(b.buffer)) + 1
+	resumed = last+1 >= oldest
+
+	for i := range b.buffer {
+		e := b.buffer[(b.start+i)%len(b.buffer)]
+		seq, _ := b.parseID(e.ID)
+		if seq > last && filter.Match(e) {
+			replay = append(replay, e)
+		}
+	}
+
+	return sub, replay, resumed
+}
+
+func (b *Broker) parseID(id string) (uint64, bool) {
+	epoch, seq, ok := strings.Cut(id, ".")
+	if !ok || epoch != b.epoch {
+		return 0, false
+	}
+
+	n, err := strconv.ParseUint(seq, 10, 64)
+	if err != nil {
+		return 0, false
+	}
+	return n, true
+}
+
+func (b *Broker) unsubscribe(s *Subscription) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.remove(s)
+}
+
+func (b *Broker) remove(s *Subscription) {
+	if _, ok := b.subs[s]; !ok {
+		return
+	}
+	delete(b.subs, s)
+	close(s.c)
+}
@@ -0,0 +1,158 @@
+// Package health serves the probes of the service: liveness on /healthz,
+// which only shows that the process serves requests, and readiness on
+// /readyz, which checks the dependencies the service cannot work without.
+package health
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/Alhanaqtah/effective-mobile-test-task/internal/lib/logger/sl"
+
+	"github.com/go-chi/render"
+)
+
+// checkTimeout bounds every check of a readiness probe, so a hanging
+// dependency fails the probe instead of timing it out.
+const checkTimeout = 2 * time.Second
+
+const (
+	StatusOK       = "ok"
+	StatusFailed   = "failed"
+	StatusDraining = "draining"
+)
+
+var (
+	ErrMigrationDirty   = errors.New("migration failed halfway")
+	ErrMigrationPending = errors.New("schema is not migrated to the latest version")
+)
+
+// Check returns an error if a dependency is not usable.
+type Check func(ctx context.Context) error
+
+// Status is the body of the probes.
+type Status struct {
+	Status string            `json:"status"`           // ok, failed or draining
+	Checks map[string]string `json:"checks,omitempty"` // Result of every check: ok or the error
+}
+
+type check struct {
+	name  string
+	check Check
+}
+
+type Health struct {
+	checks   []check
+	onDrain  []func()
+	draining atomic.Bool
+	log      *slog.Logger
+}
+
+func New(log *slog.Logger) *Health {
+	return &Health{log: log}
+}
+
+// Add registers a check run by every readiness probe. Checks must be added
+// before the probes are served.
+func (h *Health) Add(name string, c Check) {
+	h.checks = append(h.checks, check{name: name, check: c})
+}
+
+// OnDrain registers f to be called by Drain, so probes served elsewhere, e.g.
+// by gRPC, fail along with the readiness probe. Functions must be registered
+// before the probes are served.
+func (h *Health) OnDrain(f func()) {
+	h.onDrain = append(h.onDrain, f)
+}
+
+// Drain fails every readiness probe from now on, so load balancers stop
+// sending requests before the server shuts down.
+func (h *Health) Drain() {
+	h.draining.Store(true)
+	for _, f := range h.onDrain {
+		f()
+	}
+}
+
+// Live responds 200 as long as the process serves requests.
+func (h *Health) Live(w http.ResponseWriter, r *http.Request) {
+	render.JSON(w, r, Status{Status: StatusOK})
+}
+
+// Ready runs the checks concurrently and responds 200 if all of them pass
+// and 503 otherwise, or right away while the server is draining.
+func (h *Health) Ready(w http.ResponseWriter, r *http.Request) {
+	const op = "health.Ready"
+
+	if h.draining.Load() {
+		render.Status(r, http.StatusServiceUnavailable)
+		render.JSON(w, r, Status{Status: StatusDraining})
+		return
+	}
+
+	log := h.log.With(slog.String("op", op), sl.Trace(r.Context()))
+
+	ctx, cancel := context.WithTimeout(r.Context(), checkTimeout)
+	defer cancel()
+
+	errs := make([]error, len(h.checks))
+
+	var wg sync.WaitGroup
+	for i, c := range h.checks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs[i] = c.check(ctx)
+		}()
+	}
+	wg.Wait()
+
+	status := Status{Status: StatusOK, Checks: make(map[string]string, len(h.checks))}
+	for i, c := range h.checks {
+		if errs[i] != nil {
+			log.Warn("readiness check failed", slog.String("check", c.name), sl.Error(errs[i]))
+			status.Status = StatusFailed
+			status.Checks[c.name] = errs[i].Error()
+			continue
+		}
+		status.Checks[c.name] = StatusOK
+	}
+
+	if status.Status != StatusOK {
+		render.Status(r, http.StatusServiceUnavailable)
+	}
+	render.JSON(w, r, status)
+}
+
+type Migrator interface {
+	MigrationVersion(ctx context.Context) (version uint, dirty bool, err error)
+	LatestMigration() uint
+}
+
+// Migrations checks that the schema is migrated at least to the latest
+// version known to this build and that no migration failed halfway. A newer
+// schema is fine: during a rolling deploy a newer instance migrates it while
+// this one still serves.
+func Migrations(m Migrator) Check {
+	return func(ctx context.Context) error {
+		version, dirty, err := m.MigrationVersion(ctx)
+		if err != nil {
+			return err
+		}
+
+		if dirty {
+			return fmt.Errorf("%w: version %d", ErrMigrationDirty, version)
+		}
+		if latest := m.LatestMigration(); version < latest {
+			return fmt.Errorf("%w: version %d, latest %d", ErrMigrationPending, version, latest)
+		}
+
+		return nil
+	}
+}
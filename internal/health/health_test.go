@@ -0,0 +1,54 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+type migrator struct {
+	version uint
+	dirty   bool
+}
+
+func (m migrator) MigrationVersion(context.Context) (uint, bool, error) {
+	return m.version, m.dirty, nil
+}
+
+func (migrator) LatestMigration() uint {
+	return 5
+}
+
+func TestMigrations(t *testing.T) {
+	tests := []struct {
+		name string
+		m    migrator
+		want error
+	}{
+		{"latest", migrator{version: 5}, nil},
+		{"newer", migrator{version: 6}, nil},
+		{"pending", migrator{version: 4}, ErrMigrationPending},
+		{"dirty", migrator{version: 5, dirty: true}, ErrMigrationDirty},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Migrations(tt.m)(context.Background())
+			if !errors.Is(err, tt.want) || (tt.want == nil && err != nil) {
+				t.Errorf("err = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestDrain(t *testing.T) {
+	h := New(nil)
+
+	drained := 0
+	h.OnDrain(func() { drained++ })
+
+	h.Drain()
+
+	if !h.draining.Load() || drained != 1 {
+		t.Errorf("draining = %v, drain functions called %d times, want true and 1", h.draining.Load(), drained)
+	}
+}
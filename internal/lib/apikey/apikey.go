@@ -0,0 +1,27 @@
+// Package apikey identifies API clients by the bearer token they send.
+package apikey
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"strings"
+)
+
+// Digest returns a digest of the bearer token of r, so tokens are neither
+// stored nor logged, or "" if there is none.
+func Digest(r *http.Request) string {
+	return DigestAuthorization(r.Header.Get("Authorization"))
+}
+
+// DigestAuthorization returns a digest of the bearer token in the value of
+// an Authorization header or authorization metadata, or "" if there is none.
+func DigestAuthorization(v string) string {
+	token, ok := strings.CutPrefix(v, "Bearer ")
+	if !ok || token == "" {
+		return ""
+	}
+
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:16])
+}
@@ -0,0 +1,105 @@
+package etag
+
+import (
+	"errors"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Any is the version matching every existing entity, used for "If-Match: *"
+// and when the client sent no precondition at all.
+const Any = 0
+
+// None is the version no entity has, expected by an If-Match header that
+// lists only tags we did not issue, so the write fails its precondition.
+const None = -1
+
+var ErrInvalid = errors.New("invalid entity tag")
+
+// Format returns the strong entity tag for an entity version.
+func Format(version int) string {
+	return `"` + strconv.Itoa(version) + `"`
+}
+
+// ParseIfMatch returns the entity versions listed by an If-Match header, any
+// of which satisfies it. An empty header or "*" yields none, which matches
+// every existing entity. If-Match uses the strong comparison, so weak tags
+// and tags we did not issue match nothing; a header listing only such tags
+// yields None. Only headers that are not a list of entity tags are invalid.
+func ParseIfMatch(header string) ([]int, error) {
+	header = strings.TrimSpace(header)
+	if header == "" || header == "*" {
+		return nil, nil
+	}
+
+	var versions []int
+	listed := false
+	for _, tag := range strings.Split(header, ",") {
+		tag = strings.TrimSpace(tag)
+		if tag == "" {
+			// Lists may have empty elements
+			continue
+		}
+
+		weak := strings.HasPrefix(tag, "W/")
+		opaque := strings.TrimPrefix(tag, "W/")
+		if len(opaque) < 2 || opaque[0] != '"' || opaque[len(opaque)-1] != '"' ||
+			strings.Contains(opaque[1:len(opaque)-1], `"`) {
+			return nil, ErrInvalid
+		}
+		listed = true
+
+		if weak {
+			continue
+		}
+
+		version, err := strconv.Atoi(opaque[1 : len(opaque)-1])
+		if err != nil || version < 1 {
+			continue
+		}
+
+		versions = append(versions, version)
+	}
+	if !listed {
+		return nil, ErrInvalid
+	}
+	if len(versions) == 0 {
+		return []int{None}, nil
+	}
+
+	return versions, nil
+}
+
+// Match returns the version a write conditional on versions must expect of
+// an entity at version current: Any if no version is required, current if it
+// is listed, and otherwise the first listed version, failing the write.
+func Match(versions []int, current int) int {
+	if len(versions) == 0 {
+		return Any
+	}
+	if slices.Contains(versions, current) {
+		return current
+	}
+	return versions[0]
+}
+
+// NoneMatch reports whether an If-None-Match header does not match tag,
+// i.e. whether the client's cached copy is stale.
+func NoneMatch(header, tag string) bool {
+	header = strings.TrimSpace(header)
+	if header == "" {
+		return true
+	}
+	if header == "*" {
+		return false
+	}
+
+	for _, t := range strings.Split(header, ",") {
+		// If-None-Match uses the weak comparison.
+		if strings.TrimPrefix(strings.TrimSpace(t), "W/") == tag {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,90 @@
+package etag_test
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/Alhanaqtah/effective-mobile-test-task/internal/lib/etag"
+)
+
+func TestParseIfMatch(t *testing.T) {
+	tests := []struct {
+		header  string
+		want    []int
+		wantErr bool
+	}{
+		{header: "", want: nil},
+		{header: "*", want: nil},
+		{header: `"3"`, want: []int{3}},
+		{header: ` "3" , "5"`, want: []int{3, 5}},
+		{header: `"3", , "5",`, want: []int{3, 5}},
+		{header: `W/"3"`, want: []int{etag.None}},
+		{header: `"3", W/"5"`, want: []int{3}},
+		{header: `"0"`, want: []int{etag.None}},
+		{header: `"abc", "4"`, want: []int{4}},
+		{header: `3`, wantErr: true},
+		{header: `W/3`, wantErr: true},
+		{header: `"3"4"`, wantErr: true},
+		{header: `"3", 5`, wantErr: true},
+		{header: `,`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.header, func(t *testing.T) {
+			got, err := etag.ParseIfMatch(tt.header)
+			if tt.wantErr {
+				if !errors.Is(err, etag.ErrInvalid) {
+					t.Errorf("ParseIfMatch(%q) error = %v, want %v", tt.header, err, etag.ErrInvalid)
+				}
+				return
+			}
+			if err != nil || !slices.Equal(got, tt.want) {
+				t.Errorf("ParseIfMatch(%q) = %v, %v, want %v", tt.header, got, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestMatch(t *testing.T) {
+	tests := []struct {
+		name     string
+		versions []int
+		current  int
+		want     int
+	}{
+		{name: "no precondition", versions: nil, current: 4, want: etag.Any},
+		{name: "single", versions: []int{3}, current: etag.Any, want: 3},
+		{name: "current listed", versions: []int{3, 4}, current: 4, want: 4},
+		{name: "current not listed", versions: []int{3, 5}, current: 4, want: 3},
+		{name: "nothing we issued", versions: []int{etag.None}, current: etag.Any, want: etag.None},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := etag.Match(tt.versions, tt.current); got != tt.want {
+				t.Errorf("Match(%v, %d) = %d, want %d", tt.versions, tt.current, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNoneMatch(t *testing.T) {
+	tests := []struct {
+		header string
+		want   bool
+	}{
+		{header: "", want: true},
+		{header: "*", want: false},
+		{header: `"2"`, want: false},
+		{header: `W/"2"`, want: false},
+		{header: `"1", "2"`, want: false},
+		{header: `"1"`, want: true},
+	}
+
+	for _, tt := range tests {
+		if got := etag.NoneMatch(tt.header, etag.Format(2)); got != tt.want {
+			t.Errorf("NoneMatch(%q) = %v, want %v", tt.header, got, tt.want)
+		}
+	}
+}
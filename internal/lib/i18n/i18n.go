@@ -0,0 +1,107 @@
+package i18n
+
+import (
+	"context"
+	"net/http"
+	"sync/atomic"
+
+	"golang.org/x/text/language"
+)
+
+// Lang - язык сообщений API
+type Lang string
+
+const (
+	EN Lang = "en"
+	RU Lang = "ru"
+)
+
+// Supported lists the languages messages are translated to. The order
+// matters: it breaks ties when the client has no preference.
+var Supported = []Lang{EN, RU}
+
+var matcher = newMatcher()
+
+// fallback is the language of messages built outside of a negotiated
+// request, set from the configuration on startup.
+var fallback atomic.Value
+
+func init() {
+	fallback.Store(EN)
+}
+
+// SetDefault sets the language FromContext returns for contexts without one.
+func SetDefault(lang Lang) {
+	fallback.Store(lang)
+}
+
+// Default returns the language set by SetDefault, EN if it was not called.
+func Default() Lang {
+	return fallback.Load().(Lang)
+}
+
+func newMatcher() language.Matcher {
+	tags := make([]language.Tag, 0, len(Supported))
+	for _, lang := range Supported {
+		tags = append(tags, language.Make(string(lang)))
+	}
+	return language.NewMatcher(tags)
+}
+
+// IsSupported reports whether messages are translated to lang.
+func IsSupported(lang Lang) bool {
+	for _, l := range Supported {
+		if l == lang {
+			return true
+		}
+	}
+	return false
+}
+
+// Negotiate picks the best supported language for an Accept-Language header,
+// returning fallback when the header is empty or nothing matches.
+func Negotiate(acceptLanguage string, fallback Lang) Lang {
+	tags, _, err := language.ParseAcceptLanguage(acceptLanguage)
+	if err != nil || len(tags) == 0 {
+		return fallback
+	}
+
+	_, index, confidence := matcher.Match(tags...)
+	if confidence == language.No {
+		return fallback
+	}
+
+	return Supported[index]
+}
+
+type ctxKey struct{}
+
+// WithLang returns a copy of ctx carrying lang.
+func WithLang(ctx context.Context, lang Lang) context.Context {
+	return context.WithValue(ctx, ctxKey{}, lang)
+}
+
+// FromContext returns the language stored in ctx, or the default one if
+// there is none.
+func FromContext(ctx context.Context) Lang {
+	if lang, ok := ctx.Value(ctxKey{}).(Lang); ok {
+		return lang
+	}
+	return Default()
+}
+
+// Middleware negotiates the response language from Accept-Language and
+// stores it in the request context.
+func Middleware(fallback Lang) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		fn := func(w http.ResponseWriter, r *http.Request) {
+			lang := Negotiate(r.Header.Get("Accept-Language"), fallback)
+
+			w.Header().Add("Vary", "Accept-Language")
+			w.Header().Set("Content-Language", string(lang))
+
+			next.ServeHTTP(w, r.WithContext(WithLang(r.Context(), lang)))
+		}
+		return http.HandlerFunc(fn)
+	}
+}
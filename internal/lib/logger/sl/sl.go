@@ -1,6 +1,11 @@
 package sl
 
-import "log/slog"
+import (
+	"context"
+	"log/slog"
+
+	"go.opentelemetry.io/otel/trace"
+)
 
 // Error wraps errors for slog
 func Error(err error) slog.Attr {
@@ -9,3 +14,14 @@ func Error(err error) slog.Attr {
 		Value: slog.StringValue(err.Error()),
 	}
 }
+
+// Trace adds the trace ID of the span in ctx to log records, so they can be
+// found from a trace. Without a span the attribute is empty and is omitted.
+func Trace(ctx context.Context) slog.Attr {
+	sc := trace.SpanContextFromContext(ctx)
+	if !sc.HasTraceID() {
+		return slog.Attr{}
+	}
+
+	return slog.String("trace_id", sc.TraceID().String())
+}
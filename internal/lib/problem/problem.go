@@ -0,0 +1,41 @@
+package problem
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+// ContentType - тип содержимого ответа с ошибкой (RFC 7807)
+const ContentType = "application/problem+json"
+
+// Problem - ответ с ошибкой в формате RFC 7807
+type Problem struct {
+	Type      string       `json:"type"`                 // URI типа ошибки
+	Title     string       `json:"title"`                // Краткое описание типа ошибки
+	Status    int          `json:"status"`               // HTTP статус ответа
+	Detail    string       `json:"detail,omitempty"`     // Описание конкретного случая ошибки
+	Instance  string       `json:"instance,omitempty"`   // Путь запроса, в котором возникла ошибка
+	Code      string       `json:"code"`                 // Стабильный машиночитаемый код ошибки
+	RequestID string       `json:"request_id,omitempty"` // Идентификатор запроса
+	Errors    []FieldError `json:"errors,omitempty"`     // Ошибки валидации отдельных полей
+}
+
+// FieldError - ошибка валидации отдельного поля запроса
+type FieldError struct {
+	Field  string `json:"field"`        // Имя поля или параметра
+	In     string `json:"in,omitempty"` // Расположение поля: path, query, header или body
+	Code   string `json:"code"`         // Машиночитаемый код ошибки поля
+	Detail string `json:"detail"`       // Описание ошибки поля
+}
+
+// TypeURI - функция для получения URI типа ошибки по её коду
+func TypeURI(code string) string {
+	return "urn:time-tracker:problem:" + code
+}
+
+// Write - функция для отправки ответа с ошибкой
+func Write(w http.ResponseWriter, p Problem) {
+	w.Header().Set("Content-Type", ContentType)
+	w.WriteHeader(p.Status)
+	json.NewEncoder(w).Encode(p)
+}
@@ -1,6 +1,87 @@
 package request
 
+import (
+	"encoding/json"
+	"time"
+)
+
 // CreateUser содержит данные для создания нового пользователя
 type CreateUser struct {
-	PassportNumber string `json:"passportNumber,omitempty"` // Номер паспорта пользователя
+	PassportNumber string `json:"passportNumber,omitempty" validate:"required"` // Номер паспорта пользователя
+}
+
+// UpdateUser содержит изменяемые поля пользователя
+type UpdateUser struct {
+	Name           *string `json:"name,omitempty"`            // Имя пользователя
+	Surname        *string `json:"surname,omitempty"`         // Фамилия пользователя
+	Patronymic     *string `json:"patronymic,omitempty"`      // Отчество пользователя
+	Address        *string `json:"address,omitempty"`         // Адрес пользователя, null очищает адрес (только merge-patch)
+	PassportSerie  *int    `json:"passport_serie,omitempty"`  // Серия паспорта пользователя
+	PassportNumber *int    `json:"passport_number,omitempty"` // Номер паспорта пользователя
+}
+
+// Режимы применения пакета операций над задачами
+const (
+	BatchModeAtomic      = "atomic"
+	BatchModeIndependent = "independent"
+)
+
+// TaskBatch содержит упорядоченный список операций над задачами
+type TaskBatch struct {
+	Mode       string          `json:"mode,omitempty" enums:"atomic,independent"` // atomic - все операции в одной транзакции (по умолчанию), independent - каждая операция применяется отдельно
+	Operations []TaskOperation `json:"operations" validate:"required"`            // Операции в порядке применения
+}
+
+// TaskOperation содержит операцию над задачей в пакетном запросе
+type TaskOperation struct {
+	Op          string     `json:"op" enums:"create,update,start,finish,delete" validate:"required"` // Тип операции
+	Ref         string     `json:"ref,omitempty"`                                                    // Клиентская ссылка на задачу, создаваемую операцией create
+	TaskID      string     `json:"task_id,omitempty"`                                                // UUID задачи для update, start, finish и delete
+	TaskRef     string     `json:"task_ref,omitempty"`                                               // Ссылка на задачу, созданную предыдущей операцией пакета
+	UserID      string     `json:"user_id,omitempty"`                                                // UUID пользователя для create
+	Title       *string    `json:"title,omitempty"`                                                  // Заголовок задачи для create и update
+	Description *string    `json:"description,omitempty"`                                            // Описание задачи для create и update, пустая строка в update очищает описание
+	Version     int        `json:"version,omitempty"`                                                // Ожидаемая версия задачи, 0 отключает проверку
+	At          *time.Time `json:"at,omitempty"`                                                     // Клиентское время операции в формате RFC 3339, по умолчанию текущее время
+}
+
+// SyncPush содержит изменения, сделанные клиентом без связи с сервером
+type SyncPush struct {
+	Changes []SyncChange `json:"changes"` // Изменения в порядке их выполнения на клиенте
+}
+
+// SyncChange содержит изменение пользователя или задачи
+type SyncChange struct {
+	Entity string `json:"entity" enums:"user,task"` // Тип сущности
+	// Изменение пользователя
+	User *SyncUserChange `json:"user,omitempty"`
+	// Операция над задачей, как в пакетном запросе
+	Task *TaskOperation `json:"task,omitempty"`
+}
+
+// SyncUserChange содержит изменение пользователя
+type SyncUserChange struct {
+	Op      string          `json:"op" enums:"update,delete"`             // Операция
+	ID      string          `json:"id"`                                   // UUID пользователя
+	Version int             `json:"version,omitempty"`                    // Версия пользователя, на которой основано изменение, 0 отключает проверку
+	Patch   json.RawMessage `json:"patch,omitempty" swaggertype:"object"` // Изменения полей в формате JSON Merge Patch (RFC 7396) для update
+}
+
+// CreateWebhook содержит данные для подписки на события
+type CreateWebhook struct {
+	URL        string   `json:"url"`                   // Адрес, на который отправляются события
+	Secret     string   `json:"secret,omitempty"`      // Ключ подписи HMAC, по умолчанию генерируется сервером
+	EventTypes []string `json:"event_types,omitempty"` // Типы событий, по умолчанию все события
+}
+
+// UpdateWebhook содержит изменяемые поля подписки
+type UpdateWebhook struct {
+	Active *bool `json:"active"` // false приостанавливает отправку событий, true возобновляет
+}
+
+// GraphQL содержит запрос GraphQL
+type GraphQL struct {
+	Query         string         `json:"query"`                   // Текст запроса
+	OperationName string         `json:"operationName,omitempty"` // Выполняемая операция, если запрос содержит несколько операций
+	Variables     map[string]any `json:"variables,omitempty"`     // Значения переменных запроса
 }
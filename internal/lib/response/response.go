@@ -1,15 +1,13 @@
 package response
 
 const (
-	StatusOK  = "OK"
-	StatusErr = "Error"
+	StatusOK = "OK"
 )
 
 // Response - общий ответ API
 type Response struct {
 	Status  string `json:"status"`            // Статус ответа
 	Message string `json:"message,omitempty"` // Сообщение, если есть
-	Error   string `json:"error,omitempty"`   // Ошибка, если есть
 }
 
 // Ok - функция для создания успешного ответа
@@ -19,11 +17,3 @@ func Ok(msg string) Response {
 		Message: msg,
 	}
 }
-
-// Err - функция для создания ответа с ошибкой
-func Err(errMsg string) Response {
-	return Response{
-		Status: StatusErr,
-		Error:  errMsg,
-	}
-}
@@ -0,0 +1,20 @@
+package response
+
+import (
+	"github.com/Alhanaqtah/effective-mobile-test-task/internal/lib/problem"
+	"github.com/Alhanaqtah/effective-mobile-test-task/internal/models"
+)
+
+// SyncPush - результат применения изменений клиента
+type SyncPush struct {
+	Results []SyncChangeResult `json:"results"` // Результаты изменений в порядке запроса
+}
+
+// SyncChangeResult - результат отдельного изменения клиента
+type SyncChangeResult struct {
+	Index  int              `json:"index"`           // Номер изменения в запросе, начиная с 0
+	Status string           `json:"status"`          // applied, conflict или failed
+	User   *models.User     `json:"user,omitempty"`  // Пользователь после изменения или текущая версия на сервере при конфликте
+	Task   *models.Task     `json:"task,omitempty"`  // Задача после изменения или текущая версия на сервере при конфликте
+	Error  *problem.Problem `json:"error,omitempty"` // Ошибка изменения
+}
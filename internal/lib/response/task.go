@@ -0,0 +1,20 @@
+package response
+
+import (
+	"github.com/Alhanaqtah/effective-mobile-test-task/internal/lib/problem"
+	"github.com/Alhanaqtah/effective-mobile-test-task/internal/models"
+)
+
+// TaskBatch - результат пакетной обработки операций над задачами
+type TaskBatch struct {
+	Committed bool                  `json:"committed"` // Признак того, что изменения сохранены
+	Results   []TaskOperationResult `json:"results"`   // Результаты операций в порядке запроса
+}
+
+// TaskOperationResult - результат отдельной операции пакета
+type TaskOperationResult struct {
+	Index  int              `json:"index"`           // Номер операции в запросе, начиная с 0
+	Status string           `json:"status"`          // applied, failed, rolled_back или skipped
+	Task   *models.Task     `json:"task,omitempty"`  // Задача после применения операции
+	Error  *problem.Problem `json:"error,omitempty"` // Ошибка операции
+}
@@ -0,0 +1,124 @@
+package validation
+
+import (
+	"errors"
+	"slices"
+	"strings"
+)
+
+var (
+	ErrRequired      = errors.New("value is required")
+	ErrInvalid       = errors.New("value is invalid")
+	ErrMalformedBody = errors.New("request body is malformed")
+	ErrTooLong       = errors.New("value is too long")
+	ErrUnknownField  = errors.New("field is unknown or read-only")
+)
+
+// Locations of a validated field in the request.
+const (
+	InPath   = "path"
+	InQuery  = "query"
+	InHeader = "header"
+	InBody   = "body"
+)
+
+// FieldError binds a validation error to the request field it was caused by.
+// It unwraps to the underlying error, so errors.Is keeps matching sentinels.
+// In is only known to the transport: services leave it empty and the
+// controllers locate the field with Locate.
+type FieldError struct {
+	Field string
+	In    string
+	Err   error
+}
+
+func (e *FieldError) Error() string {
+	return e.Field + ": " + e.Err.Error()
+}
+
+func (e *FieldError) Unwrap() error {
+	return e.Err
+}
+
+// Field returns a FieldError for the field located at in.
+func Field(field, in string, err error) *FieldError {
+	return &FieldError{Field: field, In: in, Err: err}
+}
+
+// For returns a FieldError for field without a location, as returned by
+// services, which do not know how the field was sent.
+func For(field string, err error) *FieldError {
+	return &FieldError{Field: field, Err: err}
+}
+
+// Locate sets the location of the field errors of err that have none to in.
+// If fields are given, only the errors of these fields are located, so a
+// handler reading fields from several places calls it once per place. It
+// returns err to allow writing it right away.
+func Locate(err error, in string, fields ...string) error {
+	for _, fe := range Fields(err) {
+		if fe.In != "" {
+			continue
+		}
+		if len(fields) > 0 && !slices.Contains(fields, fe.Field) {
+			continue
+		}
+		fe.In = in
+	}
+	return err
+}
+
+// Rename renames the field errors of err for field that have no location to
+// name, so a transport can report a field of a service under the name the
+// client sent it with. It returns err to allow locating it right away.
+func Rename(err error, field, name string) error {
+	for _, fe := range Fields(err) {
+		if fe.In == "" && fe.Field == field {
+			fe.Field = name
+		}
+	}
+	return err
+}
+
+// Errors collects several field errors found in one request.
+type Errors []*FieldError
+
+func (e Errors) Error() string {
+	msgs := make([]string, 0, len(e))
+	for _, fe := range e {
+		msgs = append(msgs, fe.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+func (e Errors) Unwrap() []error {
+	errs := make([]error, 0, len(e))
+	for _, fe := range e {
+		errs = append(errs, fe)
+	}
+	return errs
+}
+
+// Err returns nil when no field errors were collected, so it can be
+// returned from validation helpers directly.
+func (e Errors) Err() error {
+	if len(e) == 0 {
+		return nil
+	}
+	return e
+}
+
+// Fields flattens err into the field errors it carries.
+func Fields(err error) []*FieldError {
+	var errs Errors
+	if errors.As(err, &errs) {
+		return errs
+	}
+
+	var fe *FieldError
+	if errors.As(err, &fe) {
+		return []*FieldError{fe}
+	}
+
+	return nil
+}
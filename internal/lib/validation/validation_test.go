@@ -0,0 +1,68 @@
+package validation_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/Alhanaqtah/effective-mobile-test-task/internal/lib/validation"
+)
+
+func TestLocate(t *testing.T) {
+	err := fmt.Errorf("op: %w", validation.Errors{
+		validation.For("user_id", validation.ErrInvalid),
+		validation.For("start_date", validation.ErrInvalid),
+		validation.Field("If-Match", validation.InHeader, validation.ErrInvalid),
+	})
+
+	validation.Locate(err, validation.InPath, "user_id")
+	validation.Locate(err, validation.InQuery)
+
+	want := map[string]string{
+		"user_id":    validation.InPath,
+		"start_date": validation.InQuery,
+		"If-Match":   validation.InHeader,
+	}
+	for _, fe := range validation.Fields(err) {
+		if fe.In != want[fe.Field] {
+			t.Errorf("%s is located in %q, want %q", fe.Field, fe.In, want[fe.Field])
+		}
+	}
+
+	if !errors.Is(err, validation.ErrInvalid) {
+		t.Error("located error does not match its sentinel")
+	}
+}
+
+func TestLocateWithoutFields(t *testing.T) {
+	err := errors.New("not a field error")
+	if got := validation.Locate(err, validation.InBody); got != err {
+		t.Errorf("Locate() = %v, want the error unchanged", got)
+	}
+	if validation.Locate(nil, validation.InBody) != nil {
+		t.Error("Locate(nil) is not nil")
+	}
+}
+
+func TestRename(t *testing.T) {
+	err := fmt.Errorf("op: %w", validation.Errors{
+		validation.For("id", validation.ErrInvalid),
+		validation.Field("id", validation.InBody, validation.ErrUnknownField),
+	})
+
+	validation.Locate(validation.Rename(err, "id", "task_id"), validation.InPath, "task_id")
+
+	want := map[string]string{
+		"task_id": validation.InPath,
+		"id":      validation.InBody,
+	}
+	fields := validation.Fields(err)
+	if len(fields) != len(want) {
+		t.Fatalf("got %d fields, want %d", len(fields), len(want))
+	}
+	for _, fe := range fields {
+		if in, ok := want[fe.Field]; !ok || fe.In != in {
+			t.Errorf("%s is located in %q, want %q", fe.Field, fe.In, in)
+		}
+	}
+}
@@ -0,0 +1,110 @@
+package metrics
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/Alhanaqtah/effective-mobile-test-task/internal/lib/logger/sl"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// statsTimeout bounds the queries run for a scrape.
+const statsTimeout = 5 * time.Second
+
+type Pool interface {
+	Stat() *pgxpool.Stat
+}
+
+type Stats interface {
+	CountRunningTasks(ctx context.Context) (int, error)
+	CountUsers(ctx context.Context) (int, error)
+}
+
+// RegisterPool exposes the statistics of the database connection pool.
+func (m *Metrics) RegisterPool(pool Pool) {
+	m.registry.MustRegister(&poolCollector{pool: pool})
+}
+
+// RegisterStats exposes the business gauges, queried from stats on every
+// scrape so that every instance reports the totals of the whole service.
+func (m *Metrics) RegisterStats(stats Stats) {
+	m.registry.MustRegister(&statsCollector{stats: stats, log: m.log})
+}
+
+var (
+	poolAcquiredConns = prometheus.NewDesc("pgxpool_acquired_conns", "Connections currently acquired from the pool.", nil, nil)
+	poolIdleConns     = prometheus.NewDesc("pgxpool_idle_conns", "Idle connections in the pool.", nil, nil)
+	poolTotalConns    = prometheus.NewDesc("pgxpool_total_conns", "Connections in the pool, including those being constructed.", nil, nil)
+	poolMaxConns      = prometheus.NewDesc("pgxpool_max_conns", "Maximum size of the pool.", nil, nil)
+	poolAcquires      = prometheus.NewDesc("pgxpool_acquires_total", "Successful acquires of a connection from the pool.", nil, nil)
+	poolEmptyAcquires = prometheus.NewDesc("pgxpool_empty_acquires_total", "Acquires that waited for a connection because the pool was empty.", nil, nil)
+	poolCanceled      = prometheus.NewDesc("pgxpool_canceled_acquires_total", "Acquires canceled by their context.", nil, nil)
+	poolAcquireWait   = prometheus.NewDesc("pgxpool_acquire_duration_seconds_total", "Total time spent acquiring connections from the pool.", nil, nil)
+)
+
+type poolCollector struct {
+	pool Pool
+}
+
+func (c *poolCollector) Describe(ch chan<- *prometheus.Desc) {
+	for _, desc := range []*prometheus.Desc{
+		poolAcquiredConns, poolIdleConns, poolTotalConns, poolMaxConns,
+		poolAcquires, poolEmptyAcquires, poolCanceled, poolAcquireWait,
+	} {
+		ch <- desc
+	}
+}
+
+func (c *poolCollector) Collect(ch chan<- prometheus.Metric) {
+	stat := c.pool.Stat()
+
+	ch <- prometheus.MustNewConstMetric(poolAcquiredConns, prometheus.GaugeValue, float64(stat.AcquiredConns()))
+	ch <- prometheus.MustNewConstMetric(poolIdleConns, prometheus.GaugeValue, float64(stat.IdleConns()))
+	ch <- prometheus.MustNewConstMetric(poolTotalConns, prometheus.GaugeValue, float64(stat.TotalConns()))
+	ch <- prometheus.MustNewConstMetric(poolMaxConns, prometheus.GaugeValue, float64(stat.MaxConns()))
+	ch <- prometheus.MustNewConstMetric(poolAcquires, prometheus.CounterValue, float64(stat.AcquireCount()))
+	ch <- prometheus.MustNewConstMetric(poolEmptyAcquires, prometheus.CounterValue, float64(stat.EmptyAcquireCount()))
+	ch <- prometheus.MustNewConstMetric(poolCanceled, prometheus.CounterValue, float64(stat.CanceledAcquireCount()))
+	ch <- prometheus.MustNewConstMetric(poolAcquireWait, prometheus.CounterValue, stat.AcquireDuration().Seconds())
+}
+
+var (
+	runningTimers = prometheus.NewDesc("timetracker_running_timers", "Tasks started and not finished yet.", nil, nil)
+	users         = prometheus.NewDesc("timetracker_users", "Users created and not deleted.", nil, nil)
+)
+
+type statsCollector struct {
+	stats Stats
+	log   *slog.Logger
+}
+
+func (c *statsCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- runningTimers
+	ch <- users
+}
+
+// Collect skips a gauge whose query failed, so a database outage shows as
+// missing data instead of zero.
+func (c *statsCollector) Collect(ch chan<- prometheus.Metric) {
+	const op = "metrics.statsCollector.Collect"
+
+	log := c.log.With(slog.String("op", op))
+
+	ctx, cancel := context.WithTimeout(context.Background(), statsTimeout)
+	defer cancel()
+
+	if running, err := c.stats.CountRunningTasks(ctx); err != nil {
+		log.Error("failed to count running tasks", sl.Error(err))
+	} else {
+		ch <- prometheus.MustNewConstMetric(runningTimers, prometheus.GaugeValue, float64(running))
+	}
+
+	if count, err := c.stats.CountUsers(ctx); err != nil {
+		log.Error("failed to count users", sl.Error(err))
+	} else {
+		ch <- prometheus.MustNewConstMetric(users, prometheus.GaugeValue, float64(count))
+	}
+}
@@ -0,0 +1,41 @@
+package metrics
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// Transport instruments requests to the external service named service
+// sent through next, http.DefaultTransport if nil.
+func (m *Metrics) Transport(service string, next http.RoundTripper) http.RoundTripper {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+
+	return &transport{metrics: m, service: service, next: next}
+}
+
+type transport struct {
+	metrics *Metrics
+	service string
+	next    http.RoundTripper
+}
+
+func (t *transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	start := time.Now()
+
+	resp, err := t.next.RoundTrip(req)
+	if err != nil {
+		t.metrics.externalDuration.WithLabelValues(t.service, "error").Observe(time.Since(start).Seconds())
+		t.metrics.externalErrors.WithLabelValues(t.service, "transport").Inc()
+		return nil, err
+	}
+
+	t.metrics.externalDuration.WithLabelValues(t.service, strconv.Itoa(resp.StatusCode)).Observe(time.Since(start).Seconds())
+	if resp.StatusCode >= http.StatusInternalServerError {
+		t.metrics.externalErrors.WithLabelValues(t.service, "server_error").Inc()
+	}
+
+	return resp, nil
+}
@@ -0,0 +1,117 @@
+// Package metrics exposes the Prometheus metrics of the service: RED metrics
+// of the HTTP API, connection pool statistics, latency of external services
+// and business gauges.
+package metrics
+
+import (
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/middleware"
+	"github.com/go-chi/chi/v5"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// unmatchedRoute labels requests that did not match any route, so scans of
+// random paths do not create a series per path.
+const unmatchedRoute = "unmatched"
+
+// Metrics holds the collectors of the service and the registry they are
+// exposed from.
+type Metrics struct {
+	registry *prometheus.Registry
+	log      *slog.Logger
+
+	requests *prometheus.HistogramVec
+	inFlight prometheus.Gauge
+
+	externalDuration *prometheus.HistogramVec
+	externalErrors   *prometheus.CounterVec
+}
+
+// New returns metrics registered on a new registry together with the Go
+// runtime and process collectors.
+func New(log *slog.Logger) *Metrics {
+	m := &Metrics{
+		registry: prometheus.NewRegistry(),
+		log:      log,
+
+		requests: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "http_request_duration_seconds",
+			Help:    "Duration of HTTP requests by method, chi route pattern and status code.",
+			Buckets: prometheus.DefBuckets,
+		}, []string{"method", "route", "status"}),
+		inFlight: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: "http_requests_in_flight",
+			Help: "HTTP requests being served.",
+		}),
+
+		externalDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "external_request_duration_seconds",
+			Help:    "Duration of requests to external services by service and status code, error if no response was received.",
+			Buckets: prometheus.DefBuckets,
+		}, []string{"service", "status"}),
+		externalErrors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "external_request_errors_total",
+			Help: "Failed requests to external services by service and reason: transport or server_error.",
+		}, []string{"service", "reason"}),
+	}
+
+	m.registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		m.requests,
+		m.inFlight,
+		m.externalDuration,
+		m.externalErrors,
+	)
+
+	return m
+}
+
+// Handler serves the metrics in the Prometheus exposition format.
+func (m *Metrics) Handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{})
+}
+
+// Middleware records every request under its chi route pattern rather than
+// its path, which keeps IDs out of the labels. It must run inside the chi
+// router so the pattern is known once the request is served, and outside
+// the recoverer so panics are recorded as the 500 it writes.
+func (m *Metrics) Middleware(next http.Handler) http.Handler {
+	fn := func(w http.ResponseWriter, r *http.Request) {
+		m.inFlight.Inc()
+		defer m.inFlight.Dec()
+
+		ww := middleware.NewWrapResponseWriter(w, r.ProtoMajor)
+		start := time.Now()
+
+		defer func() {
+			route := unmatchedRoute
+			if rctx := chi.RouteContext(r.Context()); rctx != nil && rctx.RoutePattern() != "" {
+				route = rctx.RoutePattern()
+			}
+
+			status := ww.Status()
+			switch {
+			case status != 0:
+			case r.Header.Get("Upgrade") != "":
+				// The connection was hijacked by a WebSocket handler
+				status = http.StatusSwitchingProtocols
+			default:
+				// Nothing was written, net/http sends an empty 200
+				status = http.StatusOK
+			}
+
+			m.requests.WithLabelValues(r.Method, route, strconv.Itoa(status)).Observe(time.Since(start).Seconds())
+		}()
+
+		next.ServeHTTP(ww, r)
+	}
+
+	return http.HandlerFunc(fn)
+}
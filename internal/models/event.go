@@ -0,0 +1,24 @@
+package models
+
+import "time"
+
+// Типы событий
+const (
+	EventTaskCreated  = "task.created"
+	EventTaskUpdated  = "task.updated"
+	EventTaskStarted  = "task.started"
+	EventTaskFinished = "task.finished"
+	EventTaskDeleted  = "task.deleted"
+	EventUserCreated  = "user.created"
+	EventUserUpdated  = "user.updated"
+	EventUserDeleted  = "user.deleted"
+)
+
+// Event представляет собой событие изменения пользователя или задачи
+type Event struct {
+	ID     string    `json:"id"`      // Идентификатор события
+	Type   string    `json:"type"`    // Тип события
+	UserID string    `json:"user_id"` // Идентификатор пользователя, к которому относится событие
+	Time   time.Time `json:"time"`    // Время события
+	Data   any       `json:"data"`    // Пользователь или задача после изменения
+}
@@ -0,0 +1,11 @@
+package models
+
+// IdempotencyRecord представляет собой сохранённый результат запроса с ключом идемпотентности
+type IdempotencyRecord struct {
+	Key         string            // Ключ идемпотентности с областью клиента и маршрута
+	Fingerprint string            // Отпечаток запроса: метод, путь и тело
+	Completed   bool              // Признак того, что исходный запрос обработан
+	StatusCode  int               // HTTP статус сохранённого ответа
+	Header      map[string]string // Заголовки сохранённого ответа, повторяемые при воспроизведении
+	Body        []byte            // Тело сохранённого ответа
+}
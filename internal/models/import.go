@@ -0,0 +1,40 @@
+package models
+
+import "time"
+
+// Статусы задания импорта
+const (
+	ImportJobPending  = "pending"
+	ImportJobRunning  = "running"
+	ImportJobFinished = "finished"
+)
+
+// Статусы строки задания импорта
+const (
+	ImportRowPending          = "pending"
+	ImportRowCreated          = "created"
+	ImportRowExists           = "exists"
+	ImportRowInvalid          = "invalid"
+	ImportRowEnrichmentFailed = "enrichment_failed"
+	ImportRowFailed           = "failed"
+)
+
+// ImportJob представляет собой задание массового импорта пользователей
+type ImportJob struct {
+	ID         string         `json:"id"`                    // Уникальный идентификатор задания
+	Status     string         `json:"status"`                // Статус задания: pending, running или finished
+	Total      int            `json:"total"`                 // Количество строк в задании
+	Counts     map[string]int `json:"counts,omitempty"`      // Количество строк в каждом статусе
+	CreatedAt  time.Time      `json:"created_at"`            // Время создания задания
+	FinishedAt *time.Time     `json:"finished_at,omitempty"` // Время завершения задания
+	Rows       []ImportRow    `json:"rows,omitempty"`        // Результаты обработки строк
+}
+
+// ImportRow представляет собой строку задания импорта и результат её обработки
+type ImportRow struct {
+	Line     int    `json:"line"`              // Номер строки во входных данных
+	Passport string `json:"passport"`          // Паспортные данные из строки
+	Status   string `json:"status"`            // Результат: pending, created, exists, invalid, enrichment_failed или failed
+	UserID   string `json:"user_id,omitempty"` // Идентификатор созданного пользователя
+	Error    string `json:"error,omitempty"`   // Описание ошибки обработки строки
+}
@@ -0,0 +1,89 @@
+package models
+
+import "time"
+
+// Типы сущностей, передаваемых при синхронизации
+const (
+	EntityUser      = "user"
+	EntityTask      = "task"
+	EntityTimeEntry = "time_entry"
+)
+
+// TimeEntry представляет собой интервал учёта времени по задаче.
+// Задача хранит один интервал, поэтому идентификатор записи совпадает с идентификатором задачи
+type TimeEntry struct {
+	ID        string     `json:"id"`                 // Уникальный идентификатор записи
+	TaskID    string     `json:"task_id"`            // Идентификатор задачи
+	UserID    string     `json:"user_id,omitempty"`  // Идентификатор пользователя
+	StartedAt time.Time  `json:"started_at"`         // Время начала интервала
+	EndedAt   *time.Time `json:"ended_at,omitempty"` // Время окончания интервала, если задача завершена
+	Duration  *float64   `json:"duration,omitempty"` // Продолжительность интервала в минутах, если задача завершена
+}
+
+// TimeEntryOf возвращает интервал учёта времени задачи: от последнего запуска (или создания) до завершения
+func TimeEntryOf(task Task) TimeEntry {
+	entry := TimeEntry{
+		ID:        task.ID,
+		TaskID:    task.ID,
+		UserID:    task.UserID,
+		StartedAt: task.CreatedAt,
+	}
+	if task.StartedAt != nil {
+		entry.StartedAt = *task.StartedAt
+	}
+	if task.Done && task.DoneAt != nil {
+		entry.EndedAt = task.DoneAt
+		duration := task.DoneAt.Sub(entry.StartedAt).Minutes()
+		entry.Duration = &duration
+	}
+	return entry
+}
+
+// UserChanges содержит изменения пользователей с момента синхронизации
+type UserChanges struct {
+	Created []User   `json:"created"` // Созданные пользователи
+	Updated []User   `json:"updated"` // Изменённые пользователи
+	Deleted []string `json:"deleted"` // Идентификаторы удалённых пользователей
+}
+
+// TaskChanges содержит изменения задач с момента синхронизации
+type TaskChanges struct {
+	Created []Task   `json:"created"` // Созданные задачи
+	Updated []Task   `json:"updated"` // Изменённые задачи
+	Deleted []string `json:"deleted"` // Идентификаторы удалённых задач
+}
+
+// TimeEntryChanges содержит изменения интервалов учёта времени с момента синхронизации
+type TimeEntryChanges struct {
+	Created []TimeEntry `json:"created"` // Созданные интервалы
+	Updated []TimeEntry `json:"updated"` // Изменённые интервалы
+	Deleted []string    `json:"deleted"` // Идентификаторы удалённых интервалов
+}
+
+// ChangePosition представляет собой позицию в ленте изменений: изменения упорядочены по транзакции, которая их записала, и по номеру изменения
+type ChangePosition struct {
+	Tx  int64 // Идентификатор транзакции, записавшей изменение
+	Seq int64 // Номер изменения
+}
+
+// ChangeSet представляет собой порцию изменений ленты синхронизации
+type ChangeSet struct {
+	Users       UserChanges      `json:"users"`        // Изменения пользователей
+	Tasks       TaskChanges      `json:"tasks"`        // Изменения задач
+	TimeEntries TimeEntryChanges `json:"time_entries"` // Изменения интервалов учёта времени
+	Cursor      ChangePosition   `json:"-"`            // Позиция в ленте изменений, до которой включена порция
+	Token       string           `json:"token"`        // Токен для следующего запроса синхронизации
+	HasMore     bool             `json:"has_more"`     // Признак того, что в ленте остались изменения
+	Delayed     bool             `json:"delayed"`      // Признак того, что часть зафиксированных изменений задержана незавершённой транзакцией в PostgreSQL
+	HorizonAge  time.Duration    `json:"-"`            // Длительность самой старой незавершённой транзакции, если изменения задержаны
+}
+
+// SyncChange представляет собой изменение, сделанное клиентом без связи с сервером
+type SyncChange struct {
+	Entity  string        // Тип сущности: user или task
+	Op      string        // Операция над пользователем: update или delete
+	ID      string        // Идентификатор пользователя
+	Version int           // Версия пользователя, на которой основано изменение
+	User    UserUpdate    // Изменения пользователя для update
+	Task    TaskOperation // Операция над задачей
+}
@@ -5,10 +5,55 @@ import "time"
 // Task представляет собой модель задачи
 type Task struct {
 	ID          string     `json:"id,omitempty"`          // Уникальный идентификатор задачи
+	UserID      string     `json:"user_id,omitempty"`     // Идентификатор пользователя, которому принадлежит задача
 	Title       string     `json:"title,omitempty"`       // Заголовок задачи
 	Description string     `json:"description,omitempty"` // Описание задачи
 	Done        bool       `json:"done,omitempty"`        // Признак завершённости задачи
 	CreatedAt   time.Time  `json:"created_at,omitempty"`  // Время создания задачи
+	StartedAt   *time.Time `json:"started_at,omitempty"`  // Время последнего запуска задачи
 	DoneAt      *time.Time `json:"done_at,omitempty"`     // Время завершения задачи (если задача завершена)
 	Duration    *float64   `json:"duration,omitempty"`    // Продолжительность выполнения задачи в часах (если указано)
+	Version     int        `json:"version,omitempty"`     // Версия задачи для оптимистичной блокировки
+}
+
+// TaskUpdate описывает изменения задачи. Незаданные поля не изменяются
+type TaskUpdate struct {
+	Title       Optional[string] // Новый заголовок задачи
+	Description Optional[string] // Новое описание задачи, null очищает описание
+}
+
+// IsEmpty сообщает, что обновление не изменяет ни одного поля
+func (u TaskUpdate) IsEmpty() bool {
+	return !u.Title.Set && !u.Description.Set
+}
+
+// TaskSearchResult представляет собой задачу, найденную полнотекстовым поиском
+type TaskSearchResult struct {
+	Task
+	Rank                 float32 `json:"rank"`                            // Релевантность задачи поисковому запросу
+	TitleHighlight       string  `json:"title_highlight,omitempty"`       // Заголовок в HTML: текст экранирован, совпадения выделены тегами <mark>
+	DescriptionHighlight string  `json:"description_highlight,omitempty"` // Фрагменты описания в HTML: текст экранирован, совпадения выделены тегами <mark>
+}
+
+// Типы операций пакетной обработки задач
+const (
+	TaskOperationCreate = "create"
+	TaskOperationUpdate = "update"
+	TaskOperationStart  = "start"
+	TaskOperationFinish = "finish"
+	TaskOperationDelete = "delete"
+)
+
+// TaskOperation представляет собой операцию над задачей в пакетном запросе
+type TaskOperation struct {
+	Op          string     // Тип операции: create, update, start, finish или delete
+	Ref         string     // Клиентская ссылка на задачу, создаваемую операцией create
+	TaskID      string     // Идентификатор задачи для update, start, finish и delete
+	TaskRef     string     // Ссылка на задачу, созданную предыдущей операцией пакета
+	UserID      string     // Идентификатор пользователя для create
+	Title       string     // Заголовок задачи для create
+	Description string     // Описание задачи для create
+	Update      TaskUpdate // Изменения задачи для update
+	Version     int        // Ожидаемая версия задачи, 0 отключает проверку
+	At          time.Time  // Клиентское время операции, нулевое значение означает текущее время
 }
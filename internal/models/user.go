@@ -9,4 +9,37 @@ type User struct {
 	Address        string `json:"address,omitempty"`         // Адрес пользователя
 	PassportSerie  int    `json:"passport_serie,omitempty"`  // Серия паспорта пользователя
 	PassportNumber int    `json:"passport_number,omitempty"` // Номер паспорта пользователя
+	Version        int    `json:"version,omitempty"`         // Версия пользователя для оптимистичной блокировки
+}
+
+// Optional представляет собой изменение поля: поле не изменяется, получает новое значение или очищается
+type Optional[T any] struct {
+	Set   bool // Признак изменения поля
+	Null  bool // Признак очистки поля
+	Value T    // Новое значение поля
+}
+
+// Some - функция для создания изменения, задающего полю значение
+func Some[T any](value T) Optional[T] {
+	return Optional[T]{Set: true, Value: value}
+}
+
+// Null - функция для создания изменения, очищающего поле
+func Null[T any]() Optional[T] {
+	return Optional[T]{Set: true, Null: true}
+}
+
+// UserUpdate представляет собой набор изменений пользователя
+type UserUpdate struct {
+	Name           Optional[string] // Имя пользователя
+	Surname        Optional[string] // Фамилия пользователя
+	Patronymic     Optional[string] // Отчество пользователя
+	Address        Optional[string] // Адрес пользователя
+	PassportSerie  Optional[int]    // Серия паспорта пользователя
+	PassportNumber Optional[int]    // Номер паспорта пользователя
+}
+
+// IsEmpty сообщает, что набор не содержит ни одного изменения
+func (u UserUpdate) IsEmpty() bool {
+	return !u.Name.Set && !u.Surname.Set && !u.Patronymic.Set && !u.Address.Set && !u.PassportSerie.Set && !u.PassportNumber.Set
 }
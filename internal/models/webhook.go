@@ -0,0 +1,41 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Статусы доставки вебхука
+const (
+	DeliveryPending   = "pending"
+	DeliveryDelivered = "delivered"
+	DeliveryDead      = "dead"
+)
+
+// Webhook представляет собой подписку внешней системы на события
+type Webhook struct {
+	ID         string    `json:"id"`               // Уникальный идентификатор подписки
+	URL        string    `json:"url"`              // Адрес, на который отправляются события
+	Secret     string    `json:"secret,omitempty"` // Ключ подписи HMAC, возвращается только при создании
+	EventTypes []string  `json:"event_types"`      // Типы событий, пустой список означает все события
+	Active     bool      `json:"active"`           // Признак того, что события отправляются
+	CreatedAt  time.Time `json:"created_at"`       // Время создания подписки
+}
+
+// WebhookDelivery представляет собой доставку события подписчику
+type WebhookDelivery struct {
+	ID             string          `json:"id"`                           // Уникальный идентификатор доставки
+	WebhookID      string          `json:"webhook_id"`                   // Идентификатор подписки
+	EventID        int64           `json:"event_id"`                     // Идентификатор события
+	EventType      string          `json:"event_type"`                   // Тип события
+	Payload        json.RawMessage `json:"payload" swaggertype:"object"` // Отправляемое событие
+	Status         string          `json:"status"`                       // Статус: pending, delivered или dead
+	Attempts       int             `json:"attempts"`                     // Количество попыток доставки
+	NextAttemptAt  time.Time       `json:"next_attempt_at"`              // Время следующей попытки
+	LastStatusCode int             `json:"last_status_code,omitempty"`   // HTTP статус последней попытки
+	LastError      string          `json:"last_error,omitempty"`         // Ошибка последней попытки
+	CreatedAt      time.Time       `json:"created_at"`                   // Время создания доставки
+	DeliveredAt    *time.Time      `json:"delivered_at,omitempty"`       // Время успешной доставки
+	URL            string          `json:"-"`                            // Адрес подписки
+	Secret         string          `json:"-"`                            // Ключ подписи подписки
+}
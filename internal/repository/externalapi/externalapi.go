@@ -1,12 +1,13 @@
 package externalapi
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"net/http"
 
-	"time-tracker/internal/models"
+	"github.com/Alhanaqtah/effective-mobile-test-task/internal/models"
 )
 
 var (
@@ -19,38 +20,67 @@ type PeopleInfoRepo struct {
 	client  *http.Client
 }
 
-func New(address string) *PeopleInfoRepo {
+// New returns a client of the people info service at address. Requests are
+// sent through transport, http.DefaultTransport if nil.
+func New(address string, transport http.RoundTripper) *PeopleInfoRepo {
 	return &PeopleInfoRepo{
 		address: address,
-		client:  &http.Client{},
+		client:  &http.Client{Transport: transport},
 	}
 }
 
-func (p *PeopleInfoRepo) GetUserInfo(passportSerie, passportNumber int) (*models.User, error) {
+func (p *PeopleInfoRepo) GetUserInfo(ctx context.Context, passportSerie, passportNumber int) (*models.User, error) {
 	const op = "repository.externalapi.GetUserInfo"
 
 	url := fmt.Sprintf("http://%s/info?passportSerie=%d&passportNumber=%d", p.address, passportSerie, passportNumber)
 
-	resp, err := http.Get(url)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
 	if err != nil {
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w: %w", op, ErrExternalAPIError, err)
+	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		if resp.StatusCode != http.StatusBadRequest {
+		if resp.StatusCode == http.StatusBadRequest {
 			return nil, fmt.Errorf("%s: %w", op, ErrBadRequest)
 		}
-		if resp.StatusCode != http.StatusInternalServerError {
-			return nil, fmt.Errorf("%s: %w", op, ErrExternalAPIError)
-		}
+		return nil, fmt.Errorf("%s: %w: status %d", op, ErrExternalAPIError, resp.StatusCode)
 	}
 
 	// Декодирование JSON-ответа
 	var user models.User
 	if err := json.NewDecoder(resp.Body).Decode(&user); err != nil {
-		return nil, fmt.Errorf("%s: %w", op, err)
+		return nil, fmt.Errorf("%s: %w: %w", op, ErrExternalAPIError, err)
 	}
 
 	return &user, nil
 }
+
+// Ping checks that the people info service answers requests. Any response
+// other than a server error means it is up, including the 400 returned for
+// the missing passport.
+func (p *PeopleInfoRepo) Ping(ctx context.Context) error {
+	const op = "repository.externalapi.Ping"
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("http://%s/info", p.address), nil)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("%s: %w: %w", op, ErrExternalAPIError, err)
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusInternalServerError {
+		return fmt.Errorf("%s: %w: status %d", op, ErrExternalAPIError, resp.StatusCode)
+	}
+
+	return nil
+}
@@ -0,0 +1,131 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Alhanaqtah/effective-mobile-test-task/internal/models"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// reserveAttempts bounds the retries of ReserveIdempotencyKey when the record
+// it conflicted with is purged before it is read.
+const reserveAttempts = 3
+
+// ReserveIdempotencyKey claims key for a new request for lockTimeout. If the
+// key is free, or its previous record has expired, or a previous request died
+// and its lock was not renewed in time, the key is claimed and reserved is
+// true. Otherwise the existing record is returned.
+func (s *Storage) ReserveIdempotencyKey(ctx context.Context, key, fingerprint string, ttl, lockTimeout time.Duration) (*models.IdempotencyRecord, bool, error) {
+	const op = "repository.postgres.ReserveIdempotencyKey"
+
+	for range reserveAttempts {
+		ct, err := s.pool.Exec(ctx, `
+			INSERT INTO idempotency_keys (key, fingerprint, expires_at, locked_until)
+			VALUES ($1, $2, now() + make_interval(secs => $3), now() + make_interval(secs => $4))
+			ON CONFLICT (key) DO UPDATE
+			SET fingerprint = EXCLUDED.fingerprint,
+				status_code = NULL,
+				headers = NULL,
+				body = NULL,
+				created_at = now(),
+				expires_at = EXCLUDED.expires_at,
+				locked_until = EXCLUDED.locked_until
+			WHERE idempotency_keys.expires_at < now()
+				OR (idempotency_keys.status_code IS NULL AND idempotency_keys.locked_until < now())
+		`, key, fingerprint, ttl.Seconds(), lockTimeout.Seconds())
+		if err != nil {
+			return nil, false, fmt.Errorf("%s: %w", op, err)
+		}
+
+		if ct.RowsAffected() == 1 {
+			return &models.IdempotencyRecord{Key: key, Fingerprint: fingerprint}, true, nil
+		}
+
+		row := s.pool.QueryRow(ctx, `
+			SELECT key, fingerprint, status_code, headers, body
+			FROM idempotency_keys
+			WHERE key = $1
+		`, key)
+
+		var record models.IdempotencyRecord
+
+		var statusCode sql.NullInt32
+
+		err = row.Scan(&record.Key, &record.Fingerprint, &statusCode, &record.Header, &record.Body)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				// The record was purged between the two statements, try again.
+				continue
+			}
+			return nil, false, fmt.Errorf("%s: %w", op, err)
+		}
+
+		if statusCode.Valid {
+			record.Completed = true
+			record.StatusCode = int(statusCode.Int32)
+		}
+
+		return &record, false, nil
+	}
+
+	return nil, false, fmt.Errorf("%s: key purged %d times while reserved", op, reserveAttempts)
+}
+
+// RenewIdempotencyKey extends the lock on key held by a request still being
+// processed by lockTimeout, so the key is not claimed by a retry meanwhile.
+func (s *Storage) RenewIdempotencyKey(ctx context.Context, key string, lockTimeout time.Duration) error {
+	const op = "repository.postgres.RenewIdempotencyKey"
+
+	_, err := s.pool.Exec(ctx, `
+		UPDATE idempotency_keys
+		SET locked_until = now() + make_interval(secs => $2)
+		WHERE key = $1 AND status_code IS NULL
+	`, key, lockTimeout.Seconds())
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (s *Storage) SaveIdempotentResponse(ctx context.Context, key string, statusCode int, header map[string]string, body []byte) error {
+	const op = "repository.postgres.SaveIdempotentResponse"
+
+	_, err := s.pool.Exec(ctx, `
+		UPDATE idempotency_keys
+		SET status_code = $2, headers = $3, body = $4, locked_until = NULL
+		WHERE key = $1
+	`, key, statusCode, header, body)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (s *Storage) ReleaseIdempotencyKey(ctx context.Context, key string) error {
+	const op = "repository.postgres.ReleaseIdempotencyKey"
+
+	_, err := s.pool.Exec(ctx, `DELETE FROM idempotency_keys WHERE key = $1 AND status_code IS NULL`, key)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (s *Storage) DeleteExpiredIdempotencyKeys(ctx context.Context) (int64, error) {
+	const op = "repository.postgres.DeleteExpiredIdempotencyKeys"
+
+	ct, err := s.pool.Exec(ctx, `DELETE FROM idempotency_keys WHERE expires_at < now()`)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return ct.RowsAffected(), nil
+}
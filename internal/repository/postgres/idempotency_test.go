@@ -0,0 +1,58 @@
+package postgres
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// TestRenewIdempotencyKey checks that a key is claimed again only once the
+// request holding it stopped renewing its lock.
+func TestRenewIdempotencyKey(t *testing.T) {
+	s := testStorage(t)
+	ctx := context.Background()
+
+	key := "test " + uuid.NewString()
+	t.Cleanup(func() {
+		s.pool.Exec(context.Background(), `DELETE FROM idempotency_keys WHERE key = $1`, key)
+	})
+
+	reserve := func() bool {
+		t.Helper()
+		_, reserved, err := s.ReserveIdempotencyKey(ctx, key, "fingerprint", time.Hour, time.Minute)
+		if err != nil {
+			t.Fatalf("reserve idempotency key: %v", err)
+		}
+		return reserved
+	}
+	expire := func() {
+		t.Helper()
+		if _, err := s.pool.Exec(ctx, `UPDATE idempotency_keys SET locked_until = now() - interval '1 second' WHERE key = $1`, key); err != nil {
+			t.Fatalf("expire lock: %v", err)
+		}
+	}
+
+	if !reserve() {
+		t.Fatal("did not reserve a free key")
+	}
+	if reserve() {
+		t.Fatal("reserved a locked key")
+	}
+
+	// The request is still processed and renews its lock
+	expire()
+	if err := s.RenewIdempotencyKey(ctx, key, time.Minute); err != nil {
+		t.Fatalf("renew idempotency key: %v", err)
+	}
+	if reserve() {
+		t.Fatal("reserved a renewed key")
+	}
+
+	// The request died
+	expire()
+	if !reserve() {
+		t.Fatal("did not reserve a key whose lock expired")
+	}
+}
@@ -0,0 +1,282 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Alhanaqtah/effective-mobile-test-task/internal/models"
+	"github.com/Alhanaqtah/effective-mobile-test-task/internal/repository"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// CreateImportJob stores a job of rows claimed by owner for lease.
+func (s *Storage) CreateImportJob(ctx context.Context, rows []models.ImportRow, owner string, lease time.Duration) (*models.ImportJob, error) {
+	const op = "repository.postgres.CreateImportJob"
+
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback(ctx)
+
+	job := models.ImportJob{Total: len(rows)}
+
+	err = tx.QueryRow(ctx,
+		`INSERT INTO import_jobs (total, owner, lease_until)
+		 VALUES ($1, $2, now() + $3::bigint * interval '1 millisecond')
+		 RETURNING id, status, created_at`,
+		job.Total, owner, lease.Milliseconds(),
+	).Scan(&job.ID, &job.Status, &job.CreatedAt)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	lines := make([]int, len(rows))
+	passports := make([]string, len(rows))
+	statuses := make([]string, len(rows))
+	errs := make([]*string, len(rows))
+	for i, row := range rows {
+		lines[i] = row.Line
+		passports[i] = row.Passport
+		statuses[i] = row.Status
+		if row.Error != "" {
+			errs[i] = &rows[i].Error
+		}
+	}
+
+	_, err = tx.Exec(ctx, `
+		INSERT INTO import_job_rows (job_id, line, passport, status, error)
+		SELECT $1, line, passport, status, error
+		FROM unnest($2::int[], $3::text[], $4::text[], $5::text[]) AS r(line, passport, status, error)
+	`, job.ID, lines, passports, statuses, errs)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return &job, nil
+}
+
+func (s *Storage) StartImportJob(ctx context.Context, jobID string) error {
+	const op = "repository.postgres.StartImportJob"
+
+	_, err := s.pool.Exec(ctx, `UPDATE import_jobs SET status = $2 WHERE id = $1`, jobID, models.ImportJobRunning)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (s *Storage) FinishImportJob(ctx context.Context, jobID string) error {
+	const op = "repository.postgres.FinishImportJob"
+
+	_, err := s.pool.Exec(ctx,
+		`UPDATE import_jobs SET status = $2, finished_at = now(), owner = NULL, lease_until = NULL WHERE id = $1`,
+		jobID, models.ImportJobFinished,
+	)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// ClaimImportJobs claims the unfinished jobs whose lease expired for owner
+// and returns them, oldest first. Jobs whose owner still renews their lease
+// are left to it.
+func (s *Storage) ClaimImportJobs(ctx context.Context, owner string, lease time.Duration) ([]string, error) {
+	const op = "repository.postgres.ClaimImportJobs"
+
+	rows, err := s.pool.Query(ctx, `
+		WITH claimed AS (
+			UPDATE import_jobs j
+			SET owner = $2, lease_until = now() + $3::bigint * interval '1 millisecond'
+			WHERE j.id IN (
+				SELECT id
+				FROM import_jobs
+				WHERE status <> $1 AND (lease_until IS NULL OR lease_until < now())
+				FOR UPDATE SKIP LOCKED
+			)
+			RETURNING j.id, j.created_at
+		)
+		SELECT id FROM claimed ORDER BY created_at
+	`, models.ImportJobFinished, owner, lease.Milliseconds())
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	ids, err := pgx.CollectRows(rows, pgx.RowTo[string])
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return ids, nil
+}
+
+// RenewImportJob extends the lease of owner on the job. It reports false if
+// the job is not owned by owner anymore.
+func (s *Storage) RenewImportJob(ctx context.Context, jobID, owner string, lease time.Duration) (bool, error) {
+	const op = "repository.postgres.RenewImportJob"
+
+	ct, err := s.pool.Exec(ctx, `
+		UPDATE import_jobs
+		SET lease_until = now() + $3::bigint * interval '1 millisecond'
+		WHERE id = $1 AND owner = $2
+	`, jobID, owner, lease.Milliseconds())
+	if err != nil {
+		return false, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return ct.RowsAffected() == 1, nil
+}
+
+// ReleaseImportJob gives up the lease of owner on the job, so another
+// instance resumes it right away.
+func (s *Storage) ReleaseImportJob(ctx context.Context, jobID, owner string) error {
+	const op = "repository.postgres.ReleaseImportJob"
+
+	_, err := s.pool.Exec(ctx,
+		`UPDATE import_jobs SET owner = NULL, lease_until = NULL WHERE id = $1 AND owner = $2`,
+		jobID, owner,
+	)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (s *Storage) PendingImportRows(ctx context.Context, jobID string) ([]models.ImportRow, error) {
+	const op = "repository.postgres.PendingImportRows"
+
+	rows, err := s.pool.Query(ctx, `
+		SELECT line, passport, status
+		FROM import_job_rows
+		WHERE job_id = $1 AND status = $2
+		ORDER BY line
+	`, jobID, models.ImportRowPending)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	var result []models.ImportRow
+	for rows.Next() {
+		var row models.ImportRow
+		if err := rows.Scan(&row.Line, &row.Passport, &row.Status); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		result = append(result, row)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return result, nil
+}
+
+// SetImportRowResult records the result of a pending row. A row processed
+// already keeps its result.
+func (s *Storage) SetImportRowResult(ctx context.Context, jobID string, row models.ImportRow) error {
+	const op = "repository.postgres.SetImportRowResult"
+
+	_, err := s.pool.Exec(ctx, `
+		UPDATE import_job_rows
+		SET status = $3, user_id = $4, error = $5
+		WHERE job_id = $1 AND line = $2 AND status = $6
+	`, jobID, row.Line, row.Status,
+		sql.NullString{String: row.UserID, Valid: row.UserID != ""},
+		sql.NullString{String: row.Error, Valid: row.Error != ""},
+		models.ImportRowPending,
+	)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// GetImportJob returns the job with per-status row counts and one page of its
+// rows, optionally only those in rowStatus.
+func (s *Storage) GetImportJob(ctx context.Context, jobID, rowStatus string, limit, offset int) (*models.ImportJob, error) {
+	const op = "repository.postgres.GetImportJob"
+
+	var job models.ImportJob
+
+	err := s.pool.QueryRow(ctx,
+		`SELECT id, status, total, created_at, finished_at FROM import_jobs WHERE id = $1`,
+		jobID,
+	).Scan(&job.ID, &job.Status, &job.Total, &job.CreatedAt, &job.FinishedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("%s: %w", op, repository.ErrImportJobNotFound)
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	counts, err := s.pool.Query(ctx,
+		`SELECT status, count(*) FROM import_job_rows WHERE job_id = $1 GROUP BY status`,
+		jobID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer counts.Close()
+
+	job.Counts = make(map[string]int)
+	for counts.Next() {
+		var status string
+		var count int
+		if err := counts.Scan(&status, &count); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		job.Counts[status] = count
+	}
+
+	if err = counts.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	rows, err := s.pool.Query(ctx, `
+		SELECT line, passport, status, user_id, error
+		FROM import_job_rows
+		WHERE job_id = $1 AND ($2 = '' OR status = $2)
+		ORDER BY line
+		LIMIT $3 OFFSET $4
+	`, jobID, rowStatus, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var row models.ImportRow
+
+		var userID sql.NullString
+		var rowErr sql.NullString
+
+		if err := rows.Scan(&row.Line, &row.Passport, &row.Status, &userID, &rowErr); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+
+		row.UserID = userID.String
+		row.Error = rowErr.String
+
+		job.Rows = append(job.Rows, row)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return &job, nil
+}
@@ -0,0 +1,83 @@
+package postgres
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/Alhanaqtah/effective-mobile-test-task/internal/models"
+
+	"github.com/google/uuid"
+)
+
+// TestClaimImportJobs checks that a job is resumed by another instance only
+// once the lease of its owner expired, and that the first result of a row
+// is kept.
+func TestClaimImportJobs(t *testing.T) {
+	s := testStorage(t)
+	ctx := context.Background()
+
+	owner, other := uuid.NewString(), uuid.NewString()
+
+	rows := []models.ImportRow{{Line: 1, Passport: "1234 567890", Status: models.ImportRowPending}}
+	job, err := s.CreateImportJob(ctx, rows, owner, time.Minute)
+	if err != nil {
+		t.Fatalf("create import job: %v", err)
+	}
+	t.Cleanup(func() {
+		s.pool.Exec(context.Background(), `DELETE FROM import_jobs WHERE id = $1`, job.ID)
+	})
+
+	claim := func() bool {
+		t.Helper()
+		ids, err := s.ClaimImportJobs(ctx, other, time.Minute)
+		if err != nil {
+			t.Fatalf("claim import jobs: %v", err)
+		}
+		return slices.Contains(ids, job.ID)
+	}
+
+	if claim() {
+		t.Fatal("claimed a job its owner holds")
+	}
+
+	// The owner stops renewing the lease
+	if _, err := s.pool.Exec(ctx, `UPDATE import_jobs SET lease_until = now() - interval '1 second' WHERE id = $1`, job.ID); err != nil {
+		t.Fatalf("expire lease: %v", err)
+	}
+	if !claim() {
+		t.Fatal("did not claim a job with an expired lease")
+	}
+
+	if owned, err := s.RenewImportJob(ctx, job.ID, owner, time.Minute); err != nil || owned {
+		t.Fatalf("renew by the previous owner: %v, %v, want false", owned, err)
+	}
+	if owned, err := s.RenewImportJob(ctx, job.ID, other, time.Minute); err != nil || !owned {
+		t.Fatalf("renew by the new owner: %v, %v, want true", owned, err)
+	}
+
+	created := models.ImportRow{Line: 1, Status: models.ImportRowCreated, UserID: uuid.NewString()}
+	if err := s.SetImportRowResult(ctx, job.ID, created); err != nil {
+		t.Fatalf("set row result: %v", err)
+	}
+	if err := s.SetImportRowResult(ctx, job.ID, models.ImportRow{Line: 1, Status: models.ImportRowExists}); err != nil {
+		t.Fatalf("set row result again: %v", err)
+	}
+
+	var status string
+	if err := s.pool.QueryRow(ctx, `SELECT status FROM import_job_rows WHERE job_id = $1 AND line = 1`, job.ID).Scan(&status); err != nil {
+		t.Fatalf("read row: %v", err)
+	}
+	if status != models.ImportRowCreated {
+		t.Errorf("row status = %q, want %q", status, models.ImportRowCreated)
+	}
+
+	// A released job is resumed right away
+	if err := s.ReleaseImportJob(ctx, job.ID, other); err != nil {
+		t.Fatalf("release import job: %v", err)
+	}
+	if !claim() {
+		t.Fatal("did not claim a released job")
+	}
+}
@@ -0,0 +1,359 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Alhanaqtah/effective-mobile-test-task/internal/models"
+	"github.com/Alhanaqtah/effective-mobile-test-task/internal/repository"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// SaveEvent writes event to the outbox. Called within InTx it is stored
+// atomically with the mutation that produced it.
+func (s *Storage) SaveEvent(ctx context.Context, event models.Event) error {
+	const op = "repository.postgres.SaveEvent"
+
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	var userID sql.NullString
+	if event.UserID != "" {
+		userID = sql.NullString{String: event.UserID, Valid: true}
+	}
+
+	_, err = s.db(ctx).Exec(ctx,
+		`INSERT INTO outbox (event_type, user_id, payload) VALUES ($1, $2, $3)`,
+		event.Type, userID, payload,
+	)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// DispatchOutbox turns up to limit outbox events into deliveries for every
+// active webhook subscribed to them and returns the number of events handled.
+func (s *Storage) DispatchOutbox(ctx context.Context, limit int) (int, error) {
+	const op = "repository.postgres.DispatchOutbox"
+
+	ct, err := s.db(ctx).Exec(ctx, `
+		WITH batch AS (
+			SELECT id, event_type, payload
+			FROM outbox
+			WHERE dispatched_at IS NULL
+			ORDER BY id
+			LIMIT $1
+			FOR UPDATE SKIP LOCKED
+		), deliveries AS (
+			INSERT INTO webhook_deliveries (webhook_id, event_id, event_type, payload)
+			SELECT w.id, b.id, b.event_type, b.payload
+			FROM batch b
+			JOIN webhooks w ON w.active AND (cardinality(w.event_types) = 0 OR b.event_type = ANY(w.event_types))
+		)
+		UPDATE outbox SET dispatched_at = now() WHERE id IN (SELECT id FROM batch)
+	`, limit)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return int(ct.RowsAffected()), nil
+}
+
+// PurgeOutbox deletes events dispatched before olderThan.
+func (s *Storage) PurgeOutbox(ctx context.Context, olderThan time.Time) (int64, error) {
+	const op = "repository.postgres.PurgeOutbox"
+
+	ct, err := s.db(ctx).Exec(ctx, `DELETE FROM outbox WHERE dispatched_at < $1`, olderThan)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return ct.RowsAffected(), nil
+}
+
+// ClaimDeliveries returns up to limit due deliveries of active webhooks and
+// postpones them by lease, so that other instances don't send them at the
+// same time. Deliveries of paused webhooks wait until they are resumed.
+func (s *Storage) ClaimDeliveries(ctx context.Context, limit int, lease time.Duration) ([]models.WebhookDelivery, error) {
+	const op = "repository.postgres.ClaimDeliveries"
+
+	rows, err := s.db(ctx).Query(ctx, `
+		UPDATE webhook_deliveries d
+		SET next_attempt_at = now() + $2::bigint * interval '1 millisecond'
+		FROM webhooks w
+		WHERE d.webhook_id = w.id AND w.active AND d.id IN (
+			SELECT p.id
+			FROM webhook_deliveries p
+			JOIN webhooks a ON a.id = p.webhook_id AND a.active
+			WHERE p.status = 'pending' AND p.next_attempt_at <= now()
+			ORDER BY p.next_attempt_at
+			LIMIT $1
+			FOR UPDATE OF p SKIP LOCKED
+		)
+		RETURNING d.id, d.webhook_id, d.event_id, d.event_type, d.payload, d.attempts, w.url, w.secret
+	`, limit, lease.Milliseconds())
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	var deliveries []models.WebhookDelivery
+	for rows.Next() {
+		var d models.WebhookDelivery
+
+		err := rows.Scan(&d.ID, &d.WebhookID, &d.EventID, &d.EventType, &d.Payload, &d.Attempts, &d.URL, &d.Secret)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+
+		deliveries = append(deliveries, d)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return deliveries, nil
+}
+
+// CompleteDelivery records a successful attempt.
+func (s *Storage) CompleteDelivery(ctx context.Context, deliveryID string, statusCode int) error {
+	const op = "repository.postgres.CompleteDelivery"
+
+	_, err := s.db(ctx).Exec(ctx, `
+		UPDATE webhook_deliveries
+		SET status = 'delivered', attempts = attempts + 1, last_status_code = $2, last_error = NULL, delivered_at = now()
+		WHERE id = $1
+	`, deliveryID, statusCode)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// FailDelivery records a failed attempt. A nil nextAttempt moves the
+// delivery to the dead letter queue.
+func (s *Storage) FailDelivery(ctx context.Context, deliveryID string, statusCode int, reason string, nextAttempt *time.Time) error {
+	const op = "repository.postgres.FailDelivery"
+
+	var code sql.NullInt32
+	if statusCode != 0 {
+		code = sql.NullInt32{Int32: int32(statusCode), Valid: true}
+	}
+
+	status := models.DeliveryPending
+	next := time.Now()
+	if nextAttempt == nil {
+		status = models.DeliveryDead
+	} else {
+		next = *nextAttempt
+	}
+
+	_, err := s.db(ctx).Exec(ctx, `
+		UPDATE webhook_deliveries
+		SET status = $2, attempts = attempts + 1, last_status_code = $3, last_error = $4, next_attempt_at = $5
+		WHERE id = $1
+	`, deliveryID, status, code, reason, next)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (s *Storage) CreateWebhook(ctx context.Context, webhook *models.Webhook) (*models.Webhook, error) {
+	const op = "repository.postgres.CreateWebhook"
+
+	err := s.db(ctx).QueryRow(ctx,
+		`INSERT INTO webhooks (url, secret, event_types) VALUES ($1, $2, $3) RETURNING id, active, created_at`,
+		webhook.URL, webhook.Secret, webhook.EventTypes,
+	).Scan(&webhook.ID, &webhook.Active, &webhook.CreatedAt)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return webhook, nil
+}
+
+func (s *Storage) GetWebhooks(ctx context.Context) ([]models.Webhook, error) {
+	const op = "repository.postgres.GetWebhooks"
+
+	rows, err := s.db(ctx).Query(ctx, `
+		SELECT id, url, event_types, active, created_at
+		FROM webhooks
+		ORDER BY created_at
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	webhooks := []models.Webhook{}
+	for rows.Next() {
+		var webhook models.Webhook
+
+		err := rows.Scan(&webhook.ID, &webhook.URL, &webhook.EventTypes, &webhook.Active, &webhook.CreatedAt)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+
+		webhooks = append(webhooks, webhook)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return webhooks, nil
+}
+
+func (s *Storage) GetWebhook(ctx context.Context, webhookID string) (*models.Webhook, error) {
+	const op = "repository.postgres.GetWebhook"
+
+	var webhook models.Webhook
+
+	err := s.db(ctx).QueryRow(ctx, `
+		SELECT id, url, event_types, active, created_at
+		FROM webhooks
+		WHERE id = $1
+	`, webhookID).Scan(&webhook.ID, &webhook.URL, &webhook.EventTypes, &webhook.Active, &webhook.CreatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("%s: %w", op, repository.ErrWebhookNotFound)
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return &webhook, nil
+}
+
+func (s *Storage) SetWebhookActive(ctx context.Context, webhookID string, active bool) (*models.Webhook, error) {
+	const op = "repository.postgres.SetWebhookActive"
+
+	var webhook models.Webhook
+
+	err := s.db(ctx).QueryRow(ctx, `
+		UPDATE webhooks SET active = $2
+		WHERE id = $1
+		RETURNING id, url, event_types, active, created_at
+	`, webhookID, active).Scan(&webhook.ID, &webhook.URL, &webhook.EventTypes, &webhook.Active, &webhook.CreatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("%s: %w", op, repository.ErrWebhookNotFound)
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return &webhook, nil
+}
+
+func (s *Storage) DeleteWebhook(ctx context.Context, webhookID string) error {
+	const op = "repository.postgres.DeleteWebhook"
+
+	ct, err := s.db(ctx).Exec(ctx, `DELETE FROM webhooks WHERE id = $1`, webhookID)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if ct.RowsAffected() == 0 {
+		return fmt.Errorf("%s: %w", op, repository.ErrWebhookNotFound)
+	}
+
+	return nil
+}
+
+// GetDeliveries returns deliveries of the webhook, newest first. An empty
+// status returns deliveries in any status.
+func (s *Storage) GetDeliveries(ctx context.Context, webhookID, status string, limit, offset int) ([]models.WebhookDelivery, error) {
+	const op = "repository.postgres.GetDeliveries"
+
+	var exists bool
+	err := s.db(ctx).QueryRow(ctx, `SELECT EXISTS(SELECT 1 FROM webhooks WHERE id = $1)`, webhookID).Scan(&exists)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	if !exists {
+		return nil, fmt.Errorf("%s: %w", op, repository.ErrWebhookNotFound)
+	}
+
+	rows, err := s.db(ctx).Query(ctx, `
+		SELECT id, webhook_id, event_id, event_type, payload, status, attempts, next_attempt_at,
+			last_status_code, last_error, created_at, delivered_at
+		FROM webhook_deliveries
+		WHERE webhook_id = $1 AND ($2 = '' OR status = $2)
+		ORDER BY created_at DESC
+		LIMIT $3 OFFSET $4
+	`, webhookID, status, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	deliveries := []models.WebhookDelivery{}
+	for rows.Next() {
+		d, err := scanDelivery(rows)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+
+		deliveries = append(deliveries, *d)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return deliveries, nil
+}
+
+// ReplayDelivery schedules the delivery to be sent again right away,
+// whatever its current status.
+func (s *Storage) ReplayDelivery(ctx context.Context, webhookID, deliveryID string) (*models.WebhookDelivery, error) {
+	const op = "repository.postgres.ReplayDelivery"
+
+	row := s.db(ctx).QueryRow(ctx, `
+		UPDATE webhook_deliveries
+		SET status = 'pending', attempts = 0, next_attempt_at = now(), delivered_at = NULL
+		WHERE webhook_id = $1 AND id = $2
+		RETURNING id, webhook_id, event_id, event_type, payload, status, attempts, next_attempt_at,
+			last_status_code, last_error, created_at, delivered_at
+	`, webhookID, deliveryID)
+
+	d, err := scanDelivery(row)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("%s: %w", op, repository.ErrDeliveryNotFound)
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return d, nil
+}
+
+func scanDelivery(row pgx.Row) (*models.WebhookDelivery, error) {
+	var d models.WebhookDelivery
+
+	var statusCode sql.NullInt32
+	var lastError sql.NullString
+
+	err := row.Scan(&d.ID, &d.WebhookID, &d.EventID, &d.EventType, &d.Payload, &d.Status, &d.Attempts, &d.NextAttemptAt,
+		&statusCode, &lastError, &d.CreatedAt, &d.DeliveredAt)
+	if err != nil {
+		return nil, err
+	}
+
+	d.LastStatusCode = int(statusCode.Int32)
+	d.LastError = lastError.String
+
+	return &d, nil
+}
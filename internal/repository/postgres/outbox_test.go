@@ -0,0 +1,284 @@
+package postgres
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Alhanaqtah/effective-mobile-test-task/internal/models"
+
+	uuidlib "github.com/google/uuid"
+)
+
+// testEvent is a type of event only these tests save, so webhooks
+// subscribed to it get no deliveries of other tests.
+const testEvent = "test.delivery"
+
+// outboxFixture saves events of a user no other test writes events for and
+// creates webhooks for them. Other tests may share the database, so
+// deliveries are counted by the user of their event.
+type outboxFixture struct {
+	s      *Storage
+	userID string
+}
+
+func newOutboxFixture(t *testing.T, s *Storage) *outboxFixture {
+	t.Helper()
+
+	f := &outboxFixture{s: s, userID: uuidlib.NewString()}
+	t.Cleanup(func() {
+		s.pool.Exec(context.Background(), `DELETE FROM outbox WHERE user_id = $1`, f.userID)
+	})
+
+	return f
+}
+
+func (f *outboxFixture) webhook(t *testing.T, active bool, eventTypes ...string) string {
+	t.Helper()
+	ctx := context.Background()
+
+	if eventTypes == nil {
+		eventTypes = []string{}
+	}
+	webhook, err := f.s.CreateWebhook(ctx, &models.Webhook{URL: "https://example.com/hook", Secret: "secret", EventTypes: eventTypes})
+	if err != nil {
+		t.Fatalf("create webhook: %v", err)
+	}
+	t.Cleanup(func() {
+		f.s.pool.Exec(context.Background(), `DELETE FROM webhooks WHERE id = $1`, webhook.ID)
+	})
+
+	if !active {
+		if _, err := f.s.SetWebhookActive(ctx, webhook.ID, false); err != nil {
+			t.Fatalf("deactivate webhook: %v", err)
+		}
+	}
+
+	return webhook.ID
+}
+
+func (f *outboxFixture) save(t *testing.T, eventTypes ...string) {
+	t.Helper()
+
+	for _, eventType := range eventTypes {
+		err := f.s.SaveEvent(context.Background(), models.Event{Type: eventType, UserID: f.userID, Time: time.Now()})
+		if err != nil {
+			t.Fatalf("save event: %v", err)
+		}
+	}
+}
+
+// dispatch dispatches the outbox until it is empty.
+func (f *outboxFixture) dispatch(t *testing.T) {
+	t.Helper()
+
+	for {
+		n, err := f.s.DispatchOutbox(context.Background(), 100)
+		if err != nil {
+			t.Fatalf("dispatch outbox: %v", err)
+		}
+		if n == 0 {
+			return
+		}
+	}
+}
+
+func (f *outboxFixture) deliveries(t *testing.T, webhookID string) int {
+	t.Helper()
+
+	var n int
+	err := f.s.pool.QueryRow(context.Background(), `
+		SELECT count(*)
+		FROM webhook_deliveries d
+		JOIN outbox o ON o.id = d.event_id
+		WHERE d.webhook_id = $1 AND o.user_id = $2
+	`, webhookID, f.userID).Scan(&n)
+	if err != nil {
+		t.Fatalf("count deliveries: %v", err)
+	}
+
+	return n
+}
+
+// claim claims every due delivery and returns those of the webhooks.
+func (f *outboxFixture) claim(t *testing.T, lease time.Duration, webhookIDs ...string) []models.WebhookDelivery {
+	t.Helper()
+
+	deliveries, err := f.s.ClaimDeliveries(context.Background(), 1000, lease)
+	if err != nil {
+		t.Fatalf("claim deliveries: %v", err)
+	}
+
+	return only(deliveries, webhookIDs)
+}
+
+func only(deliveries []models.WebhookDelivery, webhookIDs []string) []models.WebhookDelivery {
+	var own []models.WebhookDelivery
+	for _, d := range deliveries {
+		for _, id := range webhookIDs {
+			if d.WebhookID == id {
+				own = append(own, d)
+			}
+		}
+	}
+	return own
+}
+
+func TestDispatchOutbox(t *testing.T) {
+	s := testStorage(t)
+	f := newOutboxFixture(t, s)
+
+	created := f.webhook(t, true, models.EventTaskCreated)
+	all := f.webhook(t, true)
+	inactive := f.webhook(t, false)
+
+	f.save(t, models.EventTaskCreated, models.EventUserUpdated)
+	f.dispatch(t)
+
+	want := map[string]int{created: 1, all: 2, inactive: 0}
+	for id, n := range want {
+		if got := f.deliveries(t, id); got != n {
+			t.Errorf("webhook %s has %d deliveries, want %d", id, got, n)
+		}
+	}
+
+	// Dispatched events are not dispatched again
+	f.dispatch(t)
+	for id, n := range want {
+		if got := f.deliveries(t, id); got != n {
+			t.Errorf("webhook %s has %d deliveries after a second dispatch, want %d", id, got, n)
+		}
+	}
+}
+
+func TestClaimDeliveries(t *testing.T) {
+	s := testStorage(t)
+	f := newOutboxFixture(t, s)
+	ctx := context.Background()
+
+	webhookID := f.webhook(t, true, testEvent)
+	f.save(t, testEvent, testEvent, testEvent)
+	f.dispatch(t)
+
+	claimed := f.claim(t, time.Minute, webhookID)
+	if len(claimed) != 3 {
+		t.Fatalf("claimed %d deliveries, want 3", len(claimed))
+	}
+	for _, d := range claimed {
+		if d.URL != "https://example.com/hook" || d.Secret != "secret" {
+			t.Errorf("delivery %s was claimed without its webhook", d.ID)
+		}
+	}
+
+	// Leased deliveries are not claimed again until the lease ends
+	if again := f.claim(t, time.Minute, webhookID); len(again) != 0 {
+		t.Fatalf("claimed %d leased deliveries", len(again))
+	}
+
+	// A failure with a retry makes the delivery due at its next attempt,
+	// one without moves it to the dead letter queue
+	past := time.Now().Add(-time.Second)
+	if err := s.FailDelivery(ctx, claimed[0].ID, 500, "server error", &past); err != nil {
+		t.Fatalf("fail delivery: %v", err)
+	}
+	if err := s.FailDelivery(ctx, claimed[1].ID, 410, "gone", nil); err != nil {
+		t.Fatalf("fail delivery: %v", err)
+	}
+	if err := s.CompleteDelivery(ctx, claimed[2].ID, 200); err != nil {
+		t.Fatalf("complete delivery: %v", err)
+	}
+
+	retried := f.claim(t, time.Minute, webhookID)
+	if len(retried) != 1 || retried[0].ID != claimed[0].ID || retried[0].Attempts != 1 {
+		t.Fatalf("claimed %+v, want the retried delivery %s after 1 attempt", retried, claimed[0].ID)
+	}
+}
+
+// TestClaimDeliveriesOfPausedWebhook checks that pending deliveries, retries
+// included, are not sent while their webhook is paused and are sent once it
+// is resumed.
+func TestClaimDeliveriesOfPausedWebhook(t *testing.T) {
+	s := testStorage(t)
+	f := newOutboxFixture(t, s)
+	ctx := context.Background()
+
+	webhookID := f.webhook(t, true, testEvent)
+	f.save(t, testEvent)
+	f.dispatch(t)
+
+	if _, err := s.SetWebhookActive(ctx, webhookID, false); err != nil {
+		t.Fatalf("pause webhook: %v", err)
+	}
+	if paused := f.claim(t, time.Minute, webhookID); len(paused) != 0 {
+		t.Fatalf("claimed %d deliveries of a paused webhook", len(paused))
+	}
+
+	if _, err := s.SetWebhookActive(ctx, webhookID, true); err != nil {
+		t.Fatalf("resume webhook: %v", err)
+	}
+	claimed := f.claim(t, time.Minute, webhookID)
+	if len(claimed) != 1 {
+		t.Fatalf("claimed %d deliveries of a resumed webhook, want 1", len(claimed))
+	}
+
+	// A retry of a delivery that failed before the pause waits as well
+	past := time.Now().Add(-time.Second)
+	if err := s.FailDelivery(ctx, claimed[0].ID, 500, "server error", &past); err != nil {
+		t.Fatalf("fail delivery: %v", err)
+	}
+	if _, err := s.SetWebhookActive(ctx, webhookID, false); err != nil {
+		t.Fatalf("pause webhook: %v", err)
+	}
+	if retried := f.claim(t, time.Minute, webhookID); len(retried) != 0 {
+		t.Fatalf("claimed a retry of a paused webhook")
+	}
+}
+
+// TestClaimDeliveriesConcurrently checks that instances claiming at the same
+// time never get the same delivery.
+func TestClaimDeliveriesConcurrently(t *testing.T) {
+	s := testStorage(t)
+	f := newOutboxFixture(t, s)
+
+	webhookID := f.webhook(t, true, testEvent)
+	const events = 20
+	for range events {
+		f.save(t, testEvent)
+	}
+	f.dispatch(t)
+
+	const claimers = 4
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	seen := map[string]int{}
+	for range claimers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			deliveries, err := s.ClaimDeliveries(context.Background(), events/2, time.Minute)
+			if err != nil {
+				t.Errorf("claim deliveries: %v", err)
+				return
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+			for _, d := range only(deliveries, []string{webhookID}) {
+				seen[d.ID]++
+			}
+		}()
+	}
+	wg.Wait()
+
+	for id, n := range seen {
+		if n > 1 {
+			t.Errorf("delivery %s was claimed %d times", id, n)
+		}
+	}
+	if rest := f.claim(t, time.Minute, webhookID); len(seen)+len(rest) != events {
+		t.Errorf("claimed %d deliveries in total, want %d", len(seen)+len(rest), events)
+	}
+}
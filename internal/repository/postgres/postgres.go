@@ -5,31 +5,46 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"html"
+	"io/fs"
 	"strings"
 	"time"
 
-	"time-tracker/internal/config"
-	"time-tracker/internal/models"
-	"time-tracker/internal/repository"
+	"github.com/Alhanaqtah/effective-mobile-test-task/internal/config"
+	"github.com/Alhanaqtah/effective-mobile-test-task/internal/models"
+	"github.com/Alhanaqtah/effective-mobile-test-task/internal/repository"
 
 	"github.com/golang-migrate/migrate/v4"
 	"github.com/golang-migrate/migrate/v4/database/postgres"
+	"github.com/golang-migrate/migrate/v4/source"
 	_ "github.com/golang-migrate/migrate/v4/source/file"
-	"github.com/jackc/pgconn"
 	"github.com/jackc/pgerrcode"
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/jackc/pgx/v5/stdlib"
 )
 
+// migrationsURL is the source of the migrations applied on start.
+const migrationsURL = "file://./migrations"
+
+// minServerVersion is the oldest PostgreSQL supported, as server_version_num:
+// the change feed needs xid8, added in PostgreSQL 13.
+const minServerVersion = 130000
+
+var ErrServerVersion = errors.New("PostgreSQL 13 or later is required")
+
 type Storage struct {
 	pool *pgxpool.Pool
+	// latestMigration is the version of the last migration in the source
+	latestMigration uint
 }
 
 func New(cfg *config.Storage) (*Storage, error) {
 	const op = "repository.postgres.New"
 
-	pool, err := pgxpool.New(context.Background(), fmt.Sprintf("user=%s password=%s host=%s port=%s dbname=%s",
+	poolConfig, err := pgxpool.ParseConfig(fmt.Sprintf("user=%s password=%s host=%s port=%s dbname=%s",
 		cfg.User,
 		cfg.Password,
 		cfg.Host,
@@ -39,12 +54,36 @@ func New(cfg *config.Storage) (*Storage, error) {
 	if err != nil {
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
+	poolConfig.ConnConfig.Tracer = queryTracer{}
+	// Timestamps are returned in UTC whatever the time zone of the server
+	poolConfig.AfterConnect = func(_ context.Context, conn *pgx.Conn) error {
+		conn.TypeMap().RegisterType(&pgtype.Type{
+			Name:  "timestamptz",
+			OID:   pgtype.TimestamptzOID,
+			Codec: &pgtype.TimestamptzCodec{ScanLocation: time.UTC},
+		})
+		return nil
+	}
+
+	pool, err := pgxpool.NewWithConfig(context.Background(), poolConfig)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
 
 	err = pool.Ping(context.Background())
 	if err != nil {
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
 
+	var version int
+	err = pool.QueryRow(context.Background(), `SELECT current_setting('server_version_num')::int`).Scan(&version)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	if version < minServerVersion {
+		return nil, fmt.Errorf("%s: %w, the server is %d", op, ErrServerVersion, version)
+	}
+
 	db := stdlib.OpenDB(*pool.Config().ConnConfig)
 
 	driver, err := postgres.WithInstance(db, &postgres.Config{})
@@ -52,21 +91,54 @@ func New(cfg *config.Storage) (*Storage, error) {
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
 
-	m, err := migrate.NewWithDatabaseInstance("file://./migrations", "postgres", driver)
+	m, err := migrate.NewWithDatabaseInstance(migrationsURL, "postgres", driver)
 	if err != nil {
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
 
-	m.Up()
+	if err := m.Up(); err != nil && !errors.Is(err, migrate.ErrNoChange) {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
 
-	return &Storage{pool: pool}, nil
+	latestMigration, err := latestMigration(migrationsURL)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return &Storage{pool: pool, latestMigration: latestMigration}, nil
+}
+
+// latestMigration returns the version of the last migration in the source
+// at sourceURL.
+func latestMigration(sourceURL string) (uint, error) {
+	src, err := source.Open(sourceURL)
+	if err != nil {
+		return 0, err
+	}
+	defer src.Close()
+
+	version, err := src.First()
+	if err != nil {
+		return 0, err
+	}
+
+	for {
+		next, err := src.Next(version)
+		if errors.Is(err, fs.ErrNotExist) {
+			return version, nil
+		}
+		if err != nil {
+			return 0, err
+		}
+		version = next
+	}
 }
 
 func (s *Storage) GetTasksInRange(ctx context.Context, userUUID string, startDate, endDate time.Time) ([]models.Task, error) {
 	const op = "repository.postgres.GetTasksInRange"
 
-	rows, err := s.pool.Query(ctx, `
-		SELECT id, title, description, done, created_at, done_at
+	rows, err := s.db(ctx).Query(ctx, `
+		SELECT id, user_id, title, description, done, created_at, started_at, done_at, version
 		FROM tasks 
 		WHERE user_id = $1 AND created_at >= $2 AND created_at <= $3
 		ORDER BY done DESC, done_at DESC
@@ -83,11 +155,16 @@ func (s *Storage) GetTasksInRange(ctx context.Context, userUUID string, startDat
 		var description sql.NullString
 		var doneAt sql.NullTime
 
-		err := rows.Scan(&task.ID, &task.Title, &description, &task.Done, &task.CreatedAt, &doneAt)
+		err := rows.Scan(&task.ID, &task.UserID, &task.Title, &description, &task.Done, &task.CreatedAt, &task.StartedAt, &doneAt, &task.Version)
 		if err != nil {
 			return nil, fmt.Errorf("%s: %w", op, err)
 		}
 
+		startedAt := task.CreatedAt
+		if task.StartedAt != nil {
+			startedAt = *task.StartedAt
+		}
+
 		if description.Valid {
 			task.Description = description.String
 		} else {
@@ -96,14 +173,15 @@ func (s *Storage) GetTasksInRange(ctx context.Context, userUUID string, startDat
 
 		if task.Done {
 			if doneAt.Valid {
-				duration := doneAt.Time.Sub(task.CreatedAt).Minutes()
+				task.DoneAt = &doneAt.Time
+				duration := doneAt.Time.Sub(startedAt).Minutes()
 				task.Duration = &duration
 			} else {
 				task.Duration = nil
 			}
 		} else {
 			now := time.Now()
-			duration := now.Sub(task.CreatedAt).Minutes()
+			duration := now.Sub(startedAt).Minutes()
 			task.Duration = &duration
 		}
 
@@ -113,26 +191,98 @@ func (s *Storage) GetTasksInRange(ctx context.Context, userUUID string, startDat
 	return tasks, nil
 }
 
-func (s *Storage) StartTask(ctx context.Context, uuid string) (*models.Task, error) {
+// Matches are delimited by characters of the private use area, which do not
+// occur in text typed by users, so the text can be escaped before the
+// delimiters are turned into tags.
+const (
+	highlightStart = "\ue000"
+	highlightStop  = "\ue001"
+
+	headlineOptions = "StartSel=" + highlightStart + ", StopSel=" + highlightStop
+)
+
+var highlightTags = strings.NewReplacer(highlightStart, "<mark>", highlightStop, "</mark>")
+
+// highlight returns a headline as HTML: the text of the task escaped and its
+// matches wrapped in <mark> tags.
+func highlight(headline string) string {
+	return highlightTags.Replace(html.EscapeString(headline))
+}
+
+func (s *Storage) SearchTasks(ctx context.Context, query string, userUUIDs []string, limit, offset int) ([]models.TaskSearchResult, error) {
+	const op = "repository.postgres.SearchTasks"
+
+	rows, err := s.db(ctx).Query(ctx, `
+		SELECT id, user_id, title, description, done, created_at, done_at,
+			ts_rank(search_vector, q) AS rank,
+			ts_headline('russian', title, q, $5 || ', HighlightAll=true'),
+			ts_headline('russian', coalesce(description, ''), q, $5 || ', MaxFragments=2')
+		FROM tasks, websearch_to_tsquery('russian', $1) AS q
+		WHERE search_vector @@ q AND user_id = ANY($2::uuid[])
+		ORDER BY rank DESC, created_at DESC
+		LIMIT $3 OFFSET $4
+	`, query, userUUIDs, limit, offset, headlineOptions)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	var results []models.TaskSearchResult
+	for rows.Next() {
+		var result models.TaskSearchResult
+
+		var description sql.NullString
+
+		err := rows.Scan(
+			&result.ID, &result.UserID, &result.Title, &description, &result.Done, &result.CreatedAt, &result.DoneAt,
+			&result.Rank, &result.TitleHighlight, &result.DescriptionHighlight,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+
+		if description.Valid {
+			result.Description = description.String
+		}
+		result.TitleHighlight = highlight(result.TitleHighlight)
+		result.DescriptionHighlight = highlight(result.DescriptionHighlight)
+
+		results = append(results, result)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return results, nil
+}
+
+func (s *Storage) StartTask(ctx context.Context, uuid string, startedAt time.Time, version int) (*models.Task, error) {
 	const op = "repository.postgres.StartTask"
 
-	row := s.pool.QueryRow(ctx,
+	row := s.db(ctx).QueryRow(ctx,
 		`UPDATE tasks
-		 SET done = false, done_at = NULL
-		 WHERE id = $1
-		 RETURNING id, title, description, done, created_at`,
-		uuid,
+		 SET done = false, started_at = $1, done_at = NULL, version = version + 1
+		 WHERE id = $2 AND ($3 = 0 OR version = $3)
+		 RETURNING id, user_id, title, description, done, created_at, started_at, version`,
+		startedAt, uuid, version,
 	)
 
 	var task models.Task
 
+	var userID sql.NullString
 	var description sql.NullString
 
-	err := row.Scan(&task.ID, &task.Title, &description, &task.Done, &task.CreatedAt)
+	err := row.Scan(&task.ID, &userID, &task.Title, &description, &task.Done, &task.CreatedAt, &task.StartedAt, &task.Version)
 	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("%s: %w", op, s.taskUpdateMiss(ctx, uuid))
+		}
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
 
+	task.UserID = userID.String
+
 	if description.Valid {
 		task.Description = description.String
 	} else {
@@ -142,26 +292,32 @@ func (s *Storage) StartTask(ctx context.Context, uuid string) (*models.Task, err
 	return &task, err
 }
 
-func (s *Storage) FinishTask(ctx context.Context, uuid string, doneAt time.Time) (*models.Task, error) {
-	const op = "repository.postgres.StartTask"
+func (s *Storage) FinishTask(ctx context.Context, uuid string, doneAt time.Time, version int) (*models.Task, error) {
+	const op = "repository.postgres.FinishTask"
 
-	row := s.pool.QueryRow(ctx,
+	row := s.db(ctx).QueryRow(ctx,
 		`UPDATE tasks
-		 SET done = true, done_at = $1
-		 WHERE id = $2
-		 RETURNING id, title, description, done, created_at, done_at`,
-		doneAt, uuid,
+		 SET done = true, done_at = $1, version = version + 1
+		 WHERE id = $2 AND ($3 = 0 OR version = $3)
+		 RETURNING id, user_id, title, description, done, created_at, started_at, done_at, version`,
+		doneAt, uuid, version,
 	)
 
 	var task models.Task
 
+	var userID sql.NullString
 	var description sql.NullString
 
-	err := row.Scan(&task.ID, &task.Title, &description, &task.Done, &task.CreatedAt, &task.DoneAt)
+	err := row.Scan(&task.ID, &userID, &task.Title, &description, &task.Done, &task.CreatedAt, &task.StartedAt, &task.DoneAt, &task.Version)
 	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("%s: %w", op, s.taskUpdateMiss(ctx, uuid))
+		}
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
 
+	task.UserID = userID.String
+
 	if description.Valid {
 		task.Description = description.String
 	} else {
@@ -171,13 +327,154 @@ func (s *Storage) FinishTask(ctx context.Context, uuid string, doneAt time.Time)
 	return &task, err
 }
 
+func (s *Storage) CreateTask(ctx context.Context, task *models.Task) (*models.Task, error) {
+	const op = "repository.postgres.CreateTask"
+
+	var description sql.NullString
+	if task.Description != "" {
+		description = sql.NullString{String: task.Description, Valid: true}
+	}
+
+	err := s.db(ctx).QueryRow(ctx,
+		"INSERT INTO tasks (user_id, title, description, created_at) VALUES ($1, $2, $3, $4) RETURNING id, version",
+		task.UserID, task.Title, description, task.CreatedAt,
+	).Scan(&task.ID, &task.Version)
+	if err != nil {
+		var pgError *pgconn.PgError
+		if errors.As(err, &pgError) {
+			switch pgError.Code {
+			case pgerrcode.UniqueViolation:
+				return nil, fmt.Errorf("%s: %w", op, repository.ErrTaskExists)
+			case pgerrcode.ForeignKeyViolation:
+				return nil, fmt.Errorf("%s: %w", op, repository.ErrUserNotFound)
+			}
+		}
+
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return task, nil
+}
+
+// assignments collects the SET clause of an UPDATE statement with its
+// arguments, numbered from $1.
+type assignments struct {
+	fields []string
+	values []interface{}
+}
+
+// setOptional assigns field to column if it is set, NULL if it is null.
+func setOptional[T any](a *assignments, column string, field models.Optional[T]) {
+	switch {
+	case !field.Set:
+		return
+	case field.Null:
+		a.values = append(a.values, nil)
+	default:
+		a.values = append(a.values, field.Value)
+	}
+	a.fields = append(a.fields, fmt.Sprintf("%s = $%d", column, len(a.values)))
+}
+
+func (s *Storage) UpdateTask(ctx context.Context, uuid string, update models.TaskUpdate, version int) (*models.Task, error) {
+	const op = "repository.postgres.UpdateTask"
+
+	var set assignments
+	setOptional(&set, "title", update.Title)
+	setOptional(&set, "description", update.Description)
+
+	fields := append(set.fields, "version = version + 1")
+	values := append(set.values, uuid, version)
+
+	q := fmt.Sprintf(
+		"UPDATE tasks SET %s WHERE id = $%d AND ($%d = 0 OR version = $%d) RETURNING id, user_id, title, description, done, created_at, started_at, done_at, version",
+		strings.Join(fields, ", "), len(values)-1, len(values), len(values),
+	)
+
+	var task models.Task
+
+	var userID sql.NullString
+	var description sql.NullString
+
+	err := s.db(ctx).QueryRow(ctx, q, values...).Scan(&task.ID, &userID, &task.Title, &description, &task.Done, &task.CreatedAt, &task.StartedAt, &task.DoneAt, &task.Version)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("%s: %w", op, s.taskUpdateMiss(ctx, uuid))
+		}
+
+		var pgError *pgconn.PgError
+		if errors.As(err, &pgError) {
+			if pgError.Code == pgerrcode.UniqueViolation {
+				return nil, fmt.Errorf("%s: %w", op, repository.ErrTaskExists)
+			}
+		}
+
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	task.UserID = userID.String
+	task.Description = description.String
+
+	return &task, nil
+}
+
+func (s *Storage) DeleteTask(ctx context.Context, uuid string, version int) (*models.Task, error) {
+	const op = "repository.postgres.DeleteTask"
+
+	row := s.db(ctx).QueryRow(ctx, `
+		DELETE FROM tasks
+		WHERE id = $1 AND ($2 = 0 OR version = $2)
+		RETURNING id, user_id, title, description, done, created_at, started_at, done_at, version
+	`, uuid, version)
+
+	var task models.Task
+
+	var userID sql.NullString
+	var description sql.NullString
+
+	err := row.Scan(&task.ID, &userID, &task.Title, &description, &task.Done, &task.CreatedAt, &task.StartedAt, &task.DoneAt, &task.Version)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("%s: %w", op, s.taskUpdateMiss(ctx, uuid))
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	task.UserID = userID.String
+	task.Description = description.String
+
+	return &task, nil
+}
+
+// taskUpdateMiss explains why a conditional task update matched no rows.
+func (s *Storage) taskUpdateMiss(ctx context.Context, uuid string) error {
+	var exists bool
+	err := s.db(ctx).QueryRow(ctx, `SELECT EXISTS(SELECT 1 FROM tasks WHERE id = $1)`, uuid).Scan(&exists)
+	if err != nil {
+		return err
+	}
+
+	if exists {
+		return repository.ErrVersionMismatch
+	}
+	return repository.ErrTaskNotFound
+}
+
 func (s *Storage) FindTask(ctx context.Context, uuid string) (*models.Task, error) {
-	const op = "repository.postgres.FindUser"
+	const op = "repository.postgres.FindTask"
 
-	row := s.pool.QueryRow(ctx, `SELECT id FROM tasks WHERE id = $1`, uuid)
+	row := s.db(ctx).QueryRow(ctx, `
+		SELECT id, user_id, title, description, done, created_at, started_at, done_at, version
+		FROM tasks
+		WHERE id = $1
+	`, uuid)
 
 	var task models.Task
-	err := row.Scan(&task.ID)
+
+	var userID sql.NullString
+	var description sql.NullString
+
+	err := row.Scan(&task.ID, &userID, &task.Title, &description, &task.Done, &task.CreatedAt, &task.StartedAt, &task.DoneAt, &task.Version)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, fmt.Errorf("%s: %w", op, repository.ErrTaskNotFound)
@@ -185,13 +482,59 @@ func (s *Storage) FindTask(ctx context.Context, uuid string) (*models.Task, erro
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
 
+	task.UserID = userID.String
+	task.Description = description.String
+
 	return &task, nil
 }
 
+// running is the condition of a task whose timer runs: it was started and
+// has not been finished since. Tasks that were never started do not count.
+const running = `NOT done AND started_at IS NOT NULL`
+
+// RunningTasks returns running tasks of the given users, the most recently
+// started first.
+func (s *Storage) RunningTasks(ctx context.Context, userUUIDs []string) ([]models.Task, error) {
+	const op = "repository.postgres.RunningTasks"
+
+	rows, err := s.db(ctx).Query(ctx, `
+		SELECT id, user_id, title, description, done, created_at, started_at, done_at, version
+		FROM tasks
+		WHERE user_id = ANY($1::uuid[]) AND `+running+`
+		ORDER BY started_at DESC
+	`, userUUIDs)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	var tasks []models.Task
+	for rows.Next() {
+		var task models.Task
+
+		var description sql.NullString
+
+		err := rows.Scan(&task.ID, &task.UserID, &task.Title, &description, &task.Done, &task.CreatedAt, &task.StartedAt, &task.DoneAt, &task.Version)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+
+		task.Description = description.String
+
+		tasks = append(tasks, task)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return tasks, nil
+}
+
 func (s *Storage) FindUser(ctx context.Context, passportSerie, passportNumber int) (*models.User, error) {
 	const op = "repository.postgres.FindUser"
 
-	row := s.pool.QueryRow(ctx, `SELECT id FROM users WHERE passport_serie = $1 AND passport_number = $2`, passportSerie, passportNumber)
+	row := s.db(ctx).QueryRow(ctx, `SELECT id FROM users WHERE passport_serie = $1 AND passport_number = $2`, passportSerie, passportNumber)
 
 	var user models.User
 	err := row.Scan(&user.ID)
@@ -205,13 +548,39 @@ func (s *Storage) FindUser(ctx context.Context, passportSerie, passportNumber in
 	return &user, nil
 }
 
+func (s *Storage) GetUser(ctx context.Context, uuid string) (*models.User, error) {
+	const op = "repository.postgres.GetUser"
+
+	row := s.db(ctx).QueryRow(ctx, `
+		SELECT id, name, surname, patronymic, address, passport_serie, passport_number, version
+		FROM users
+		WHERE id = $1
+	`, uuid)
+
+	var user models.User
+
+	var address sql.NullString
+
+	err := row.Scan(&user.ID, &user.Name, &user.Surname, &user.Patronymic, &address, &user.PassportSerie, &user.PassportNumber, &user.Version)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("%s: %w", op, repository.ErrUserNotFound)
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	user.Address = address.String
+
+	return &user, nil
+}
+
 func (s *Storage) CreateUser(ctx context.Context, user *models.User) (*models.User, error) {
-	const op = "repository.postgresGetUsers"
+	const op = "repository.postgres.CreateUser"
 
-	_, err := s.pool.Exec(ctx,
-		"INSERT INTO users (name, surname, patronymic, address, passport_serie, passport_number) VALUES ($1, $2, $3, $4, $5, $6)",
+	err := s.db(ctx).QueryRow(ctx,
+		"INSERT INTO users (name, surname, patronymic, address, passport_serie, passport_number) VALUES ($1, $2, $3, $4, $5, $6) RETURNING id, version",
 		user.Name, user.Surname, user.Patronymic, user.Address, user.PassportSerie, user.PassportNumber,
-	)
+	).Scan(&user.ID, &user.Version)
 	if err != nil {
 		var pgError *pgconn.PgError
 		if errors.As(err, &pgError) {
@@ -231,10 +600,10 @@ func (s *Storage) GetUsers(ctx context.Context, limit int, offset int, filter st
 
 	filter = "%" + filter + "%"
 
-	rows, err := s.pool.Query(ctx, `
-	SELECT id, name, surname, patronymic, address, passport_serie, passport_number 
+	rows, err := s.db(ctx).Query(ctx, `
+	SELECT id, name, surname, patronymic, address, passport_serie, passport_number, version
 	FROM users 
-	WHERE name ILIKE $1 OR surname ILIKE $1 OR patronymic ILIKE $1 OR address ILIKE $1 OR CAST(passport_serie AS TEXT) ILIKE $1 OR CAST(passport_number AS TEXT) ILIKE $1 
+	WHERE name ILIKE $1 OR surname ILIKE $1 OR patronymic ILIKE $1 OR coalesce(address, '') ILIKE $1 OR CAST(passport_serie AS TEXT) ILIKE $1 OR CAST(passport_number AS TEXT) ILIKE $1 
 	ORDER BY id 
 	LIMIT $2 OFFSET $3
 	`, filter, limit, offset)
@@ -246,11 +615,16 @@ func (s *Storage) GetUsers(ctx context.Context, limit int, offset int, filter st
 	var users []models.User
 	for rows.Next() {
 		var user models.User
-		err = rows.Scan(&user.ID, &user.Name, &user.Surname, &user.Patronymic, &user.Address, &user.PassportSerie, &user.PassportNumber)
+
+		var address sql.NullString
+
+		err = rows.Scan(&user.ID, &user.Name, &user.Surname, &user.Patronymic, &address, &user.PassportSerie, &user.PassportNumber, &user.Version)
 		if err != nil {
 			return nil, fmt.Errorf("%s: %w", op, err)
 		}
 
+		user.Address = address.String
+
 		users = append(users, user)
 	}
 
@@ -261,47 +635,150 @@ func (s *Storage) GetUsers(ctx context.Context, limit int, offset int, filter st
 	return users, nil
 }
 
-func (s *Storage) UpdateUser(ctx context.Context, fields []string, values []string) (*models.User, error) {
+func (s *Storage) UpdateUser(ctx context.Context, uuid string, update models.UserUpdate, version int) (*models.User, error) {
 	const op = "repository.postgres.UpdateUser"
 
-	f := strings.Join(fields[:len(fields)], ", ")
+	var set assignments
+	setOptional(&set, "name", update.Name)
+	setOptional(&set, "surname", update.Surname)
+	setOptional(&set, "patronymic", update.Patronymic)
+	setOptional(&set, "address", update.Address)
+	setOptional(&set, "passport_serie", update.PassportSerie)
+	setOptional(&set, "passport_number", update.PassportNumber)
 
-	v := make([]interface{}, len(values))
-	for i, j := range values {
-		v[i] = j
-	}
+	fields := append(set.fields, "version = version + 1")
+	values := append(set.values, uuid, version)
 
-	q := fmt.Sprintf("UPDATE users SET %s WHERE id = $%d RETURNING id, name, surname, patronymic, address, passport_serie, passport_number", f, len(values))
+	q := fmt.Sprintf(
+		"UPDATE users SET %s WHERE id = $%d AND ($%d = 0 OR version = $%d) RETURNING id, name, surname, patronymic, address, passport_serie, passport_number, version",
+		strings.Join(fields, ", "), len(values)-1, len(values), len(values),
+	)
 
-	row := s.pool.QueryRow(ctx, q, v...)
+	row := s.db(ctx).QueryRow(ctx, q, values...)
 
 	var user models.User
-	err := row.Scan(&user.ID, &user.Name, &user.Surname, &user.Patronymic, &user.Address, &user.PassportSerie, &user.PassportNumber)
+
+	var address sql.NullString
+
+	err := row.Scan(&user.ID, &user.Name, &user.Surname, &user.Patronymic, &address, &user.PassportSerie, &user.PassportNumber, &user.Version)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
-			return nil, fmt.Errorf("%s: %w", op, repository.ErrUserNotFound)
+			return nil, fmt.Errorf("%s: %w", op, s.userUpdateMiss(ctx, uuid))
 		}
+
+		var pgError *pgconn.PgError
+		if errors.As(err, &pgError) {
+			if pgError.Code == pgerrcode.UniqueViolation {
+				return nil, fmt.Errorf("%s: %w", op, repository.ErrExists)
+			}
+		}
+
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
 
+	user.Address = address.String
+
 	return &user, nil
 }
 
-func (s *Storage) RemoveUser(ctx context.Context, uuid string) error {
+// userUpdateMiss explains why a conditional user update matched no rows.
+func (s *Storage) userUpdateMiss(ctx context.Context, uuid string) error {
+	var exists bool
+	err := s.db(ctx).QueryRow(ctx, `SELECT EXISTS(SELECT 1 FROM users WHERE id = $1)`, uuid).Scan(&exists)
+	if err != nil {
+		return err
+	}
+
+	if exists {
+		return repository.ErrVersionMismatch
+	}
+	return repository.ErrUserNotFound
+}
+
+func (s *Storage) RemoveUser(ctx context.Context, uuid string, version int) error {
 	const op = "repository.postgres.RemoveUser"
 
-	ct, err := s.pool.Exec(ctx, `DELETE FROM users WHERE id = $1`, uuid)
+	ct, err := s.db(ctx).Exec(ctx, `DELETE FROM users WHERE id = $1 AND ($2 = 0 OR version = $2)`, uuid, version)
 	if err != nil {
 		return fmt.Errorf("%s: %w", op, err)
 	}
 
 	if ct.RowsAffected() == 0 {
-		return fmt.Errorf("%s: %w", op, repository.ErrUserNotFound)
+		return fmt.Errorf("%s: %w", op, s.userUpdateMiss(ctx, uuid))
 	}
 
 	return nil
 }
 
+// CountRunningTasks returns the number of running tasks.
+func (s *Storage) CountRunningTasks(ctx context.Context) (int, error) {
+	const op = "repository.postgres.CountRunningTasks"
+
+	var count int
+	err := s.db(ctx).QueryRow(ctx, `
+		SELECT count(*) FROM tasks WHERE `+running+`
+	`).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return count, nil
+}
+
+// CountUsers returns the number of users.
+func (s *Storage) CountUsers(ctx context.Context) (int, error) {
+	const op = "repository.postgres.CountUsers"
+
+	var count int
+	if err := s.db(ctx).QueryRow(ctx, `SELECT count(*) FROM users`).Scan(&count); err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return count, nil
+}
+
+// Ping checks that a connection to the database can be acquired and used.
+func (s *Storage) Ping(ctx context.Context) error {
+	const op = "repository.postgres.Ping"
+
+	if err := s.pool.Ping(ctx); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// MigrationVersion returns the version the schema is migrated to and
+// whether its migration failed halfway, as recorded by golang-migrate.
+func (s *Storage) MigrationVersion(ctx context.Context) (uint, bool, error) {
+	const op = "repository.postgres.MigrationVersion"
+
+	var (
+		version int64
+		dirty   bool
+	)
+	err := s.db(ctx).QueryRow(ctx, `SELECT version, dirty FROM schema_migrations LIMIT 1`).Scan(&version, &dirty)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return 0, false, nil
+	}
+	if err != nil {
+		return 0, false, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return uint(version), dirty, nil
+}
+
+// LatestMigration returns the version of the last migration known to this
+// build.
+func (s *Storage) LatestMigration() uint {
+	return s.latestMigration
+}
+
+// Stat returns the statistics of the connection pool.
+func (s *Storage) Stat() *pgxpool.Stat {
+	return s.pool.Stat()
+}
+
 func (s *Storage) Close() {
 	s.pool.Close()
 }
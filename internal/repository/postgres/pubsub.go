@@ -0,0 +1,53 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// Notify publishes payload on channel to every session listening on it.
+// Called within InTx the notification is sent only if the transaction
+// commits.
+func (s *Storage) Notify(ctx context.Context, channel string, payload []byte) error {
+	const op = "repository.postgres.Notify"
+
+	if _, err := s.db(ctx).Exec(ctx, `SELECT pg_notify($1, $2)`, channel, string(payload)); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// Listen calls ready once it listens on channel and handle for every
+// notification on it until ctx is done or the connection fails. It holds
+// a dedicated connection taken out of the pool, so callers should run
+// a single listener per channel and call Listen again after an error.
+// Notifications sent while no listener is connected are lost.
+func (s *Storage) Listen(ctx context.Context, channel string, ready func(), handle func(payload []byte)) error {
+	const op = "repository.postgres.Listen"
+
+	pooled, err := s.pool.Acquire(ctx)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	// A listening connection must not go back to the pool.
+	conn := pooled.Hijack()
+	defer conn.Close(context.Background())
+
+	if _, err := conn.Exec(ctx, "LISTEN "+pgx.Identifier{channel}.Sanitize()); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	ready()
+
+	for {
+		n, err := conn.WaitForNotification(ctx)
+		if err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+
+		handle([]byte(n.Payload))
+	}
+}
@@ -0,0 +1,86 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// TakeRateLimitToken takes a token from the bucket key, which holds up to
+// burst tokens and gains rate tokens per second. Buckets are refilled and
+// taken from in a single statement using the clock of the database, so
+// concurrent requests to several instances share the bucket. It reports
+// whether a token was taken and returns the tokens left in the bucket.
+func (s *Storage) TakeRateLimitToken(ctx context.Context, key string, rate float64, burst int) (bool, float64, error) {
+	const op = "repository.postgres.TakeRateLimitToken"
+
+	// refilled is the number of tokens in the bucket b after refilling it
+	// since its last update. The bucket is updated only if it has a token
+	// left then; a missing bucket is full, so the insert always takes one.
+	// The refill is computed from the latest version of the row, not from
+	// the snapshot of the statement, so concurrent takes do not overlap.
+	const refilled = `least($3::float8, b.tokens + $2::float8 * extract(epoch FROM now() - b.updated_at)::float8)`
+
+	var tokens float64
+	var taken bool
+	err := s.db(ctx).QueryRow(ctx, `
+		WITH taken AS (
+			INSERT INTO rate_limit_buckets AS b (key, tokens, updated_at, full_at)
+			VALUES ($1, $3::float8 - 1, now(), now() + make_interval(secs => 1 / $2::float8))
+			ON CONFLICT (key) DO UPDATE
+			SET tokens = `+refilled+` - 1,
+				updated_at = now(),
+				full_at = now() + make_interval(secs => ($3::float8 - `+refilled+` + 1) / $2::float8)
+			WHERE `+refilled+` >= 1
+			RETURNING b.tokens
+		)
+		SELECT tokens, true FROM taken
+		UNION ALL
+		SELECT `+refilled+`, false
+		FROM rate_limit_buckets b
+		WHERE b.key = $1 AND NOT EXISTS (SELECT 1 FROM taken)
+	`, key, rate, burst).Scan(&tokens, &taken)
+	if errors.Is(err, pgx.ErrNoRows) {
+		// The bucket was created by a concurrent request after the statement
+		// started and is empty already.
+		return false, 0, nil
+	}
+	if err != nil {
+		return false, 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return taken, tokens, nil
+}
+
+// ReturnRateLimitToken puts back a token taken from the bucket key, which
+// holds up to burst tokens and gains rate tokens per second.
+func (s *Storage) ReturnRateLimitToken(ctx context.Context, key string, rate float64, burst int) error {
+	const op = "repository.postgres.ReturnRateLimitToken"
+
+	_, err := s.db(ctx).Exec(ctx, `
+		UPDATE rate_limit_buckets
+		SET tokens = least($3::float8, tokens + 1),
+			full_at = updated_at + make_interval(secs => ($3::float8 - least($3::float8, tokens + 1)) / $2::float8)
+		WHERE key = $1
+	`, key, rate, burst)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// DeleteFullRateLimitBuckets deletes the buckets that have refilled, which
+// are the same as missing ones.
+func (s *Storage) DeleteFullRateLimitBuckets(ctx context.Context) (int64, error) {
+	const op = "repository.postgres.DeleteFullRateLimitBuckets"
+
+	ct, err := s.db(ctx).Exec(ctx, `DELETE FROM rate_limit_buckets WHERE full_at <= now()`)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return ct.RowsAffected(), nil
+}
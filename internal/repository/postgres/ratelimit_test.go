@@ -0,0 +1,161 @@
+package postgres
+
+import (
+	"context"
+	"math"
+	"sync"
+	"testing"
+	"time"
+)
+
+// TestTakeRateLimitToken runs the refill of a bucket through the statement,
+// moving the bucket back in time instead of waiting for the clock of the
+// database.
+func TestTakeRateLimitToken(t *testing.T) {
+	s := testStorage(t)
+	ctx := context.Background()
+
+	key := "test:" + t.Name() + ":" + time.Now().Format(time.RFC3339Nano)
+	t.Cleanup(func() {
+		s.pool.Exec(context.Background(), `DELETE FROM rate_limit_buckets WHERE key = $1`, key)
+	})
+
+	age := func(d time.Duration) {
+		_, err := s.pool.Exec(ctx, `
+			UPDATE rate_limit_buckets
+			SET updated_at = updated_at - make_interval(secs => $2),
+				full_at = full_at - make_interval(secs => $2)
+			WHERE key = $1
+		`, key, d.Seconds())
+		if err != nil {
+			t.Fatalf("age bucket: %v", err)
+		}
+	}
+
+	// 2 requests per 20 seconds: a token every 10 seconds. The clock moves
+	// on while the test runs, so tokens are compared roughly.
+	const rate, burst = 0.1, 2
+
+	steps := []struct {
+		name      string
+		age       time.Duration
+		taken     bool
+		remaining float64
+	}{
+		{"a missing bucket is full", 0, true, 1},
+		{"last token", 0, true, 0},
+		{"empty", 0, false, 0},
+		{"half a token", 5 * time.Second, false, 0.5},
+		{"a failed take keeps the refill", 10 * time.Second, true, 0.5},
+		{"refill stops at the burst", time.Hour, true, 1},
+	}
+	for _, step := range steps {
+		age(step.age)
+
+		taken, remaining, err := s.TakeRateLimitToken(ctx, key, rate, burst)
+		if err != nil {
+			t.Fatalf("%s: %v", step.name, err)
+		}
+		if taken != step.taken || math.Abs(remaining-step.remaining) > 0.01 {
+			t.Fatalf("%s: taken %v with %v left, want %v with %v left", step.name, taken, remaining, step.taken, step.remaining)
+		}
+	}
+
+	// The bucket holds 1 token and is full in 10 seconds
+	age(10 * time.Second)
+	if _, err := s.DeleteFullRateLimitBuckets(ctx); err != nil {
+		t.Fatalf("delete full buckets: %v", err)
+	}
+
+	var n int
+	if err := s.pool.QueryRow(ctx, `SELECT count(*) FROM rate_limit_buckets WHERE key = $1`, key).Scan(&n); err != nil {
+		t.Fatalf("count buckets: %v", err)
+	}
+	if n != 0 {
+		t.Errorf("refilled bucket was not deleted")
+	}
+}
+
+// TestReturnRateLimitToken checks that a returned token can be taken again
+// and that returns do not fill a bucket over its burst.
+func TestReturnRateLimitToken(t *testing.T) {
+	s := testStorage(t)
+	ctx := context.Background()
+
+	key := "test:" + t.Name() + ":" + time.Now().Format(time.RFC3339Nano)
+	t.Cleanup(func() {
+		s.pool.Exec(context.Background(), `DELETE FROM rate_limit_buckets WHERE key = $1`, key)
+	})
+
+	const rate, burst = 1.0 / 3600, 2
+
+	for range burst {
+		if _, _, err := s.TakeRateLimitToken(ctx, key, rate, burst); err != nil {
+			t.Fatalf("take: %v", err)
+		}
+	}
+
+	if err := s.ReturnRateLimitToken(ctx, key, rate, burst); err != nil {
+		t.Fatalf("return: %v", err)
+	}
+	taken, remaining, err := s.TakeRateLimitToken(ctx, key, rate, burst)
+	if err != nil || !taken || math.Abs(remaining) > 0.01 {
+		t.Fatalf("take after a return: taken %v with %v left, %v, want true with 0 left", taken, remaining, err)
+	}
+
+	for range 3 {
+		if err := s.ReturnRateLimitToken(ctx, key, rate, burst); err != nil {
+			t.Fatalf("return: %v", err)
+		}
+	}
+
+	var tokens float64
+	var full bool
+	err = s.pool.QueryRow(ctx, `SELECT tokens, full_at <= now() FROM rate_limit_buckets WHERE key = $1`, key).Scan(&tokens, &full)
+	if err != nil {
+		t.Fatalf("read bucket: %v", err)
+	}
+	if tokens != burst || !full {
+		t.Errorf("bucket holds %v tokens, full %v, want %d tokens and full", tokens, full, burst)
+	}
+}
+
+// TestTakeRateLimitTokenConcurrently checks that concurrent takes from a
+// bucket never hand out more tokens than it holds.
+func TestTakeRateLimitTokenConcurrently(t *testing.T) {
+	s := testStorage(t)
+	ctx := context.Background()
+
+	key := "test:" + t.Name() + ":" + time.Now().Format(time.RFC3339Nano)
+	t.Cleanup(func() {
+		s.pool.Exec(context.Background(), `DELETE FROM rate_limit_buckets WHERE key = $1`, key)
+	})
+
+	const burst, requests = 5, 20
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	taken := 0
+	for range requests {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			ok, _, err := s.TakeRateLimitToken(ctx, key, 1.0/3600, burst)
+			if err != nil {
+				t.Errorf("take: %v", err)
+				return
+			}
+			if ok {
+				mu.Lock()
+				taken++
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	if taken != burst {
+		t.Errorf("took %d tokens from a bucket of %d", taken, burst)
+	}
+}
@@ -0,0 +1,181 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/Alhanaqtah/effective-mobile-test-task/internal/models"
+)
+
+// GetUsersByIDs returns the users with the given ids; missing ids are
+// skipped.
+func (s *Storage) GetUsersByIDs(ctx context.Context, userUUIDs []string) ([]models.User, error) {
+	const op = "repository.postgres.GetUsersByIDs"
+
+	rows, err := s.db(ctx).Query(ctx, `
+		SELECT id, name, surname, patronymic, address, passport_serie, passport_number, version
+		FROM users
+		WHERE id = ANY($1::uuid[])
+	`, userUUIDs)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	var users []models.User
+	for rows.Next() {
+		var user models.User
+
+		var address sql.NullString
+
+		err = rows.Scan(&user.ID, &user.Name, &user.Surname, &user.Patronymic, &address, &user.PassportSerie, &user.PassportNumber, &user.Version)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+
+		user.Address = address.String
+
+		users = append(users, user)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return users, nil
+}
+
+// GetTasksOfUsers returns up to limit tasks of each of the given users
+// created within the range after skipping offset of them, ordered like
+// GetTasksInRange within each user.
+func (s *Storage) GetTasksOfUsers(ctx context.Context, userUUIDs []string, startDate, endDate time.Time, limit, offset int) ([]models.Task, error) {
+	const op = "repository.postgres.GetTasksOfUsers"
+
+	rows, err := s.db(ctx).Query(ctx, `
+		SELECT id, user_id, title, description, done, created_at, started_at, done_at, version
+		FROM (
+			SELECT *, row_number() OVER (PARTITION BY user_id ORDER BY done DESC, done_at DESC, id) AS n
+			FROM tasks
+			WHERE user_id = ANY($1::uuid[]) AND created_at >= $2 AND created_at <= $3
+		) t
+		WHERE n > $5 AND n <= $4 + $5
+		ORDER BY user_id, n
+	`, userUUIDs, startDate, endDate, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	now := time.Now()
+
+	var tasks []models.Task
+	for rows.Next() {
+		var task models.Task
+
+		var description sql.NullString
+
+		err := rows.Scan(&task.ID, &task.UserID, &task.Title, &description, &task.Done, &task.CreatedAt, &task.StartedAt, &task.DoneAt, &task.Version)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+
+		task.Description = description.String
+
+		startedAt := task.CreatedAt
+		if task.StartedAt != nil {
+			startedAt = *task.StartedAt
+		}
+
+		end := now
+		if task.Done && task.DoneAt != nil {
+			end = *task.DoneAt
+		}
+		if !task.Done || task.DoneAt != nil {
+			duration := end.Sub(startedAt).Minutes()
+			task.Duration = &duration
+		}
+
+		tasks = append(tasks, task)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return tasks, nil
+}
+
+// CountTasksOfUsers returns the number of tasks of each of the given users
+// created within the range; users without tasks are missing from the result.
+func (s *Storage) CountTasksOfUsers(ctx context.Context, userUUIDs []string, startDate, endDate time.Time) (map[string]int, error) {
+	const op = "repository.postgres.CountTasksOfUsers"
+
+	rows, err := s.db(ctx).Query(ctx, `
+		SELECT user_id, count(*)
+		FROM tasks
+		WHERE user_id = ANY($1::uuid[]) AND created_at >= $2 AND created_at <= $3
+		GROUP BY user_id
+	`, userUUIDs, startDate, endDate)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	counts := make(map[string]int)
+	for rows.Next() {
+		var userID string
+		var count int
+
+		if err := rows.Scan(&userID, &count); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+
+		counts[userID] = count
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return counts, nil
+}
+
+// TotalDurations returns the minutes spent by each of the given users on
+// tasks created within the range. Running tasks count up to now; users
+// without tasks are missing from the result.
+func (s *Storage) TotalDurations(ctx context.Context, userUUIDs []string, startDate, endDate time.Time) (map[string]float64, error) {
+	const op = "repository.postgres.TotalDurations"
+
+	rows, err := s.db(ctx).Query(ctx, `
+		SELECT user_id,
+			sum(extract(epoch FROM coalesce(done_at, now()) - coalesce(started_at, created_at))) / 60
+		FROM tasks
+		WHERE user_id = ANY($1::uuid[]) AND created_at >= $2 AND created_at <= $3
+			AND (NOT done OR done_at IS NOT NULL)
+		GROUP BY user_id
+	`, userUUIDs, startDate, endDate)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	durations := make(map[string]float64)
+	for rows.Next() {
+		var userID string
+		var minutes float64
+
+		if err := rows.Scan(&userID, &minutes); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+
+		durations[userID] = minutes
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return durations, nil
+}
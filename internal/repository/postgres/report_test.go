@@ -0,0 +1,51 @@
+package postgres
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Alhanaqtah/effective-mobile-test-task/internal/models"
+)
+
+func TestGetTasksOfUsersPages(t *testing.T) {
+	s := testStorage(t)
+	ctx := context.Background()
+
+	busy := createUser(t, s)
+	idle := createUser(t, s)
+
+	now := time.Now()
+	for i := range 3 {
+		_, err := s.CreateTask(ctx, &models.Task{UserID: busy, Title: "task " + string(rune('a'+i)) + " " + busy, CreatedAt: now})
+		if err != nil {
+			t.Fatalf("CreateTask() error = %v", err)
+		}
+	}
+	if _, err := s.CreateTask(ctx, &models.Task{UserID: idle, Title: "only " + idle, CreatedAt: now}); err != nil {
+		t.Fatalf("CreateTask() error = %v", err)
+	}
+
+	from, to := now.Add(-time.Minute), now.Add(time.Minute)
+
+	all, err := s.GetTasksOfUsers(ctx, []string{busy}, from, to, 3, 0)
+	if err != nil {
+		t.Fatalf("GetTasksOfUsers() error = %v", err)
+	}
+
+	tasks, err := s.GetTasksOfUsers(ctx, []string{busy, idle}, from, to, 2, 1)
+	if err != nil {
+		t.Fatalf("GetTasksOfUsers() error = %v", err)
+	}
+	if len(all) != 3 || len(tasks) != 2 || tasks[0].ID != all[1].ID || tasks[1].ID != all[2].ID {
+		t.Errorf("GetTasksOfUsers(limit 2, offset 1) = %+v, want the last two of %+v", tasks, all)
+	}
+
+	counts, err := s.CountTasksOfUsers(ctx, []string{busy, idle}, from, to)
+	if err != nil {
+		t.Fatalf("CountTasksOfUsers() error = %v", err)
+	}
+	if counts[busy] != 3 || counts[idle] != 1 {
+		t.Errorf("CountTasksOfUsers() = %v, want 3 and 1", counts)
+	}
+}
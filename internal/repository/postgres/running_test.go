@@ -0,0 +1,49 @@
+package postgres
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Alhanaqtah/effective-mobile-test-task/internal/models"
+)
+
+func TestRunningTasks(t *testing.T) {
+	s := testStorage(t)
+	ctx := context.Background()
+
+	userID := createUser(t, s)
+
+	before, err := s.CountRunningTasks(ctx)
+	if err != nil {
+		t.Fatalf("CountRunningTasks() error = %v", err)
+	}
+
+	now := time.Now()
+	if _, err := s.CreateTask(ctx, &models.Task{UserID: userID, Title: "never started " + userID, CreatedAt: now}); err != nil {
+		t.Fatalf("CreateTask() error = %v", err)
+	}
+	started, err := s.CreateTask(ctx, &models.Task{UserID: userID, Title: "started " + userID, CreatedAt: now})
+	if err != nil {
+		t.Fatalf("CreateTask() error = %v", err)
+	}
+	if _, err := s.StartTask(ctx, started.ID, now, 0); err != nil {
+		t.Fatalf("StartTask() error = %v", err)
+	}
+
+	tasks, err := s.RunningTasks(ctx, []string{userID})
+	if err != nil {
+		t.Fatalf("RunningTasks() error = %v", err)
+	}
+	if len(tasks) != 1 || tasks[0].ID != started.ID {
+		t.Errorf("RunningTasks() = %+v, want only task %s", tasks, started.ID)
+	}
+
+	after, err := s.CountRunningTasks(ctx)
+	if err != nil {
+		t.Fatalf("CountRunningTasks() error = %v", err)
+	}
+	if after-before != 1 {
+		t.Errorf("CountRunningTasks() grew by %d, want 1", after-before)
+	}
+}
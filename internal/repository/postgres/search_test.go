@@ -0,0 +1,40 @@
+package postgres
+
+import "testing"
+
+func TestHighlight(t *testing.T) {
+	tests := []struct {
+		name     string
+		headline string
+		want     string
+	}{
+		{
+			name:     "plain text",
+			headline: "Fix bug",
+			want:     "Fix bug",
+		},
+		{
+			name:     "match",
+			headline: "Fix " + highlightStart + "bug" + highlightStop,
+			want:     "Fix <mark>bug</mark>",
+		},
+		{
+			name:     "markup of the user is escaped",
+			headline: `<script>alert("x")</script> ` + highlightStart + "bug" + highlightStop,
+			want:     "&lt;script&gt;alert(&#34;x&#34;)&lt;/script&gt; <mark>bug</mark>",
+		},
+		{
+			name:     "tags typed by the user are not highlights",
+			headline: "<mark>bug</mark> & co",
+			want:     "&lt;mark&gt;bug&lt;/mark&gt; &amp; co",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := highlight(tt.headline); got != tt.want {
+				t.Errorf("highlight(%q) = %q, want %q", tt.headline, got, tt.want)
+			}
+		})
+	}
+}
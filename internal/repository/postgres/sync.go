@@ -0,0 +1,233 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/Alhanaqtah/effective-mobile-test-task/internal/models"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// Changes returns users, tasks and deletions recorded after the since
+// position of the change feed, at most limit of them. All entities share
+// one feed, so the returned Cursor orders changes across types.
+//
+// Changes are ordered by the transaction that wrote them first. Sequence
+// values are taken before commit, so ordering by them alone would let a slow
+// writer commit a change below the cursor of an already served page. Only
+// changes of transactions older than every running one are served: nothing
+// can be committed below them anymore.
+//
+// The oldest running transaction is that of the whole server, so one long
+// transaction in any database holds the feed back. The last page reports
+// committed changes held back this way as Delayed, with the age of the
+// oldest transaction the role may see.
+func (s *Storage) Changes(ctx context.Context, since models.ChangePosition, limit int) (*models.ChangeSet, error) {
+	const op = "repository.postgres.Changes"
+
+	tx, err := s.pool.BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.RepeatableRead, AccessMode: pgx.ReadOnly})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback(ctx)
+
+	rows, err := tx.Query(ctx, `
+		WITH horizon AS (SELECT pg_snapshot_xmin(pg_current_snapshot()) AS xmin)
+		SELECT change_xid::text::bigint, change_seq FROM (
+			SELECT change_xid, change_seq FROM users, horizon
+			WHERE (change_xid, change_seq) > ($1::bigint::text::xid8, $2) AND change_xid < horizon.xmin
+			UNION ALL
+			SELECT change_xid, change_seq FROM tasks, horizon
+			WHERE (change_xid, change_seq) > ($1::bigint::text::xid8, $2) AND change_xid < horizon.xmin
+			UNION ALL
+			SELECT change_xid, change_seq FROM tombstones, horizon
+			WHERE (change_xid, change_seq) > ($1::bigint::text::xid8, $2) AND change_xid < horizon.xmin
+		) changes
+		ORDER BY change_xid, change_seq
+		LIMIT $3
+	`, since.Tx, since.Seq, limit+1)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	positions, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (models.ChangePosition, error) {
+		var p models.ChangePosition
+		err := row.Scan(&p.Tx, &p.Seq)
+		return p, err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	changes := &models.ChangeSet{
+		Users:  models.UserChanges{Created: []models.User{}, Updated: []models.User{}, Deleted: []string{}},
+		Tasks:  models.TaskChanges{Created: []models.Task{}, Updated: []models.Task{}, Deleted: []string{}},
+		Cursor: since,
+	}
+
+	if len(positions) > limit {
+		changes.HasMore = true
+		positions = positions[:limit]
+	} else if err := delayed(ctx, tx, since, changes); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if len(positions) == 0 {
+		return changes, nil
+	}
+	changes.Cursor = positions[len(positions)-1]
+
+	if err := changedUsers(ctx, tx, since, changes); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := changedTasks(ctx, tx, since, changes); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := deletions(ctx, tx, since, changes); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return changes, nil
+}
+
+// delayed reports whether changes after since are committed but held back
+// by a running transaction, and how long the oldest one has been running.
+func delayed(ctx context.Context, tx pgx.Tx, since models.ChangePosition, changes *models.ChangeSet) error {
+	var age float64
+	err := tx.QueryRow(ctx, `
+		WITH horizon AS (SELECT pg_snapshot_xmin(pg_current_snapshot()) AS xmin)
+		SELECT
+			EXISTS (
+				SELECT 1 FROM users, horizon
+				WHERE (change_xid, change_seq) > ($1::bigint::text::xid8, $2) AND change_xid >= horizon.xmin
+			) OR EXISTS (
+				SELECT 1 FROM tasks, horizon
+				WHERE (change_xid, change_seq) > ($1::bigint::text::xid8, $2) AND change_xid >= horizon.xmin
+			) OR EXISTS (
+				SELECT 1 FROM tombstones, horizon
+				WHERE (change_xid, change_seq) > ($1::bigint::text::xid8, $2) AND change_xid >= horizon.xmin
+			),
+			coalesce((
+				SELECT extract(epoch FROM max(now() - xact_start))
+				FROM pg_stat_activity
+				WHERE backend_xid IS NOT NULL AND pid <> pg_backend_pid()
+			), 0)::float8
+	`, since.Tx, since.Seq).Scan(&changes.Delayed, &age)
+	if err != nil {
+		return err
+	}
+
+	if changes.Delayed {
+		changes.HorizonAge = time.Duration(age * float64(time.Second))
+	}
+
+	return nil
+}
+
+func changedUsers(ctx context.Context, tx pgx.Tx, since models.ChangePosition, changes *models.ChangeSet) error {
+	rows, err := tx.Query(ctx, `
+		SELECT id, name, surname, patronymic, address, passport_serie, passport_number, version,
+			(created_xid, created_seq) > ($1::bigint::text::xid8, $2)
+		FROM users
+		WHERE (change_xid, change_seq) > ($1::bigint::text::xid8, $2) AND (change_xid, change_seq) <= ($3::bigint::text::xid8, $4)
+		ORDER BY change_xid, change_seq
+	`, since.Tx, since.Seq, changes.Cursor.Tx, changes.Cursor.Seq)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var user models.User
+
+		var address sql.NullString
+		var created bool
+
+		err := rows.Scan(&user.ID, &user.Name, &user.Surname, &user.Patronymic, &address, &user.PassportSerie, &user.PassportNumber, &user.Version, &created)
+		if err != nil {
+			return err
+		}
+
+		user.Address = address.String
+
+		if created {
+			changes.Users.Created = append(changes.Users.Created, user)
+		} else {
+			changes.Users.Updated = append(changes.Users.Updated, user)
+		}
+	}
+
+	return rows.Err()
+}
+
+func changedTasks(ctx context.Context, tx pgx.Tx, since models.ChangePosition, changes *models.ChangeSet) error {
+	rows, err := tx.Query(ctx, `
+		SELECT id, user_id, title, description, done, created_at, started_at, done_at, version,
+			(created_xid, created_seq) > ($1::bigint::text::xid8, $2)
+		FROM tasks
+		WHERE (change_xid, change_seq) > ($1::bigint::text::xid8, $2) AND (change_xid, change_seq) <= ($3::bigint::text::xid8, $4)
+		ORDER BY change_xid, change_seq
+	`, since.Tx, since.Seq, changes.Cursor.Tx, changes.Cursor.Seq)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var task models.Task
+
+		var userID sql.NullString
+		var description sql.NullString
+		var created bool
+
+		err := rows.Scan(&task.ID, &userID, &task.Title, &description, &task.Done, &task.CreatedAt, &task.StartedAt, &task.DoneAt, &task.Version, &created)
+		if err != nil {
+			return err
+		}
+
+		task.UserID = userID.String
+		task.Description = description.String
+
+		if created {
+			changes.Tasks.Created = append(changes.Tasks.Created, task)
+		} else {
+			changes.Tasks.Updated = append(changes.Tasks.Updated, task)
+		}
+	}
+
+	return rows.Err()
+}
+
+func deletions(ctx context.Context, tx pgx.Tx, since models.ChangePosition, changes *models.ChangeSet) error {
+	rows, err := tx.Query(ctx, `
+		SELECT entity, id
+		FROM tombstones
+		WHERE (change_xid, change_seq) > ($1::bigint::text::xid8, $2) AND (change_xid, change_seq) <= ($3::bigint::text::xid8, $4)
+		ORDER BY change_xid, change_seq
+	`, since.Tx, since.Seq, changes.Cursor.Tx, changes.Cursor.Seq)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var entity, id string
+		if err := rows.Scan(&entity, &id); err != nil {
+			return err
+		}
+
+		switch entity {
+		case models.EntityUser:
+			changes.Users.Deleted = append(changes.Users.Deleted, id)
+		case models.EntityTask:
+			changes.Tasks.Deleted = append(changes.Tasks.Deleted, id)
+		}
+	}
+
+	return rows.Err()
+}
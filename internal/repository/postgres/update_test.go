@@ -0,0 +1,26 @@
+package postgres
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/Alhanaqtah/effective-mobile-test-task/internal/models"
+)
+
+func TestSetOptional(t *testing.T) {
+	var set assignments
+	setOptional(&set, "name", models.Some("Ivan"))
+	setOptional(&set, "surname", models.Optional[string]{})
+	setOptional(&set, "address", models.Null[string]())
+	setOptional(&set, "passport_serie", models.Some(1234))
+
+	wantFields := "[name = $1 address = $2 passport_serie = $3]"
+	if got := fmt.Sprint(set.fields); got != wantFields {
+		t.Errorf("fields = %s, want %s", got, wantFields)
+	}
+
+	wantValues := "[Ivan <nil> 1234]"
+	if got := fmt.Sprint(set.values); got != wantValues {
+		t.Errorf("values = %s, want %s", got, wantValues)
+	}
+}
@@ -6,4 +6,12 @@ var (
 	ErrUserNotFound = errors.New("user not found")
 	ErrExists       = errors.New("user already exists")
 	ErrTaskNotFound = errors.New("task not found")
+	ErrTaskExists   = errors.New("task already exists")
+
+	ErrImportJobNotFound = errors.New("import job not found")
+
+	ErrWebhookNotFound  = errors.New("webhook not found")
+	ErrDeliveryNotFound = errors.New("webhook delivery not found")
+
+	ErrVersionMismatch = errors.New("version mismatch")
 )
@@ -0,0 +1,271 @@
+package datasync
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Alhanaqtah/effective-mobile-test-task/internal/lib/logger/sl"
+	"github.com/Alhanaqtah/effective-mobile-test-task/internal/lib/validation"
+	"github.com/Alhanaqtah/effective-mobile-test-task/internal/models"
+	taskService "github.com/Alhanaqtah/effective-mobile-test-task/internal/service/task"
+	userService "github.com/Alhanaqtah/effective-mobile-test-task/internal/service/user"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel"
+)
+
+var tracer = otel.Tracer("github.com/Alhanaqtah/effective-mobile-test-task/internal/service/datasync")
+
+const (
+	// pageSize limits the number of changes returned by one pull.
+	pageSize = 500
+	// MaxPushSize limits the number of changes accepted by one push.
+	MaxPushSize = 500
+
+	// delayWarning is the age of a transaction holding the change feed back
+	// from which pulls are logged as warnings.
+	delayWarning = time.Minute
+)
+
+var (
+	ErrInvalidToken      = errors.New("invalid sync token")
+	ErrEmptyPush         = errors.New("push contains no changes")
+	ErrPushTooLarge      = errors.New("push contains too many changes")
+	ErrUnknownEntity     = errors.New("unknown entity")
+	ErrUnknownUserOp     = errors.New("unknown user operation")
+	ErrOfflineUserCreate = errors.New("users can only be created online")
+)
+
+// Statuses of a pushed change.
+const (
+	ChangeApplied  = "applied"
+	ChangeConflict = "conflict"
+	ChangeFailed   = "failed"
+)
+
+type Storage interface {
+	Changes(ctx context.Context, since models.ChangePosition, limit int) (*models.ChangeSet, error)
+}
+
+type UserService interface {
+	GetUser(ctx context.Context, uuid string) (*models.User, error)
+	UpdateUserInfo(ctx context.Context, uuid string, update models.UserUpdate, version int) (*models.User, error)
+	RemoveUserByUUID(ctx context.Context, uuid string, version int) error
+}
+
+type TaskService interface {
+	GetTask(ctx context.Context, uuid string) (*models.Task, error)
+	Apply(ctx context.Context, operation models.TaskOperation, refs map[string]string) (*models.Task, error)
+}
+
+// ChangeResult is the outcome of a pushed change. On conflict User or Task
+// holds the current server copy the client has to merge with.
+type ChangeResult struct {
+	Status string
+	User   *models.User
+	Task   *models.Task
+	Err    error
+}
+
+type Service struct {
+	storage Storage
+	users   UserService
+	tasks   TaskService
+	log     *slog.Logger
+}
+
+func New(storage Storage, users UserService, tasks TaskService, log *slog.Logger) *Service {
+	return &Service{
+		storage: storage,
+		users:   users,
+		tasks:   tasks,
+		log:     log,
+	}
+}
+
+// Pull returns changes made after the position encoded in token. An empty
+// token starts from the beginning of the feed, i.e. a full snapshot.
+func (s *Service) Pull(ctx context.Context, token string) (*models.ChangeSet, error) {
+	const op = "service.datasync.Pull"
+
+	ctx, span := tracer.Start(ctx, op)
+	defer span.End()
+
+	log := s.log.With(slog.String("op", op), sl.Trace(ctx))
+
+	since, err := decodeToken(token)
+	if err != nil {
+		log.Debug("invalid sync token", slog.String("token", token))
+		return nil, fmt.Errorf("%s: %w", op, validation.For("since", ErrInvalidToken))
+	}
+
+	changes, err := s.storage.Changes(ctx, since, pageSize)
+	if err != nil {
+		log.Error("failed to get changes", sl.Error(err))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	changes.TimeEntries = models.TimeEntryChanges{
+		Created: timeEntries(changes.Tasks.Created),
+		Updated: timeEntries(changes.Tasks.Updated),
+		Deleted: changes.Tasks.Deleted,
+	}
+	changes.Token = encodeToken(changes.Cursor)
+
+	if changes.Delayed && changes.HorizonAge >= delayWarning {
+		// Any database of the server may hold the feed back; clients keep
+		// getting delayed until the transaction ends
+		log.Warn("change feed is held back by a long transaction", slog.Duration("age", changes.HorizonAge))
+	}
+
+	log.Debug("changes pulled", slog.Any("since", since), slog.Any("cursor", changes.Cursor), slog.Bool("has_more", changes.HasMore), slog.Bool("delayed", changes.Delayed))
+
+	return changes, nil
+}
+
+// Push applies client changes in order. Each change carries the entity
+// version it was based on; if the server copy moved on in the meantime the
+// change is not applied and reported as a conflict with the server copy.
+func (s *Service) Push(ctx context.Context, changes []models.SyncChange) ([]ChangeResult, error) {
+	const op = "service.datasync.Push"
+
+	ctx, span := tracer.Start(ctx, op)
+	defer span.End()
+
+	log := s.log.With(slog.String("op", op), sl.Trace(ctx))
+
+	if len(changes) == 0 {
+		log.Debug("push is empty")
+		return nil, fmt.Errorf("%s: %w", op, ErrEmptyPush)
+	}
+	if len(changes) > MaxPushSize {
+		log.Debug("push is too large", slog.Int("size", len(changes)))
+		return nil, fmt.Errorf("%s: %w", op, ErrPushTooLarge)
+	}
+
+	results := make([]ChangeResult, len(changes))
+	refs := make(map[string]string)
+
+	for i, change := range changes {
+		switch change.Entity {
+		case models.EntityUser:
+			results[i] = s.pushUser(ctx, change)
+		case models.EntityTask:
+			results[i] = s.pushTask(ctx, change.Task, refs)
+		default:
+			results[i] = ChangeResult{Status: ChangeFailed, Err: validation.For("entity", ErrUnknownEntity)}
+		}
+	}
+
+	return results, nil
+}
+
+func (s *Service) pushUser(ctx context.Context, change models.SyncChange) ChangeResult {
+	if _, err := uuid.Parse(change.ID); err != nil {
+		return ChangeResult{Status: ChangeFailed, Err: validation.For("id", validation.ErrInvalid)}
+	}
+
+	var user *models.User
+	var err error
+
+	switch change.Op {
+	case "update":
+		user, err = s.users.UpdateUserInfo(ctx, change.ID, change.User, change.Version)
+	case "delete":
+		err = s.users.RemoveUserByUUID(ctx, change.ID, change.Version)
+	case "create":
+		// Creating a user needs the people info API, which an offline client
+		// can't have waited for.
+		err = validation.For("op", ErrOfflineUserCreate)
+	default:
+		err = validation.For("op", ErrUnknownUserOp)
+	}
+
+	if errors.Is(err, userService.ErrVersionMismatch) {
+		server, getErr := s.users.GetUser(ctx, change.ID)
+		if getErr != nil {
+			return ChangeResult{Status: ChangeFailed, Err: getErr}
+		}
+		return ChangeResult{Status: ChangeConflict, User: server, Err: err}
+	}
+	if err != nil {
+		return ChangeResult{Status: ChangeFailed, Err: err}
+	}
+
+	return ChangeResult{Status: ChangeApplied, User: user}
+}
+
+func (s *Service) pushTask(ctx context.Context, operation models.TaskOperation, refs map[string]string) ChangeResult {
+	task, err := s.tasks.Apply(ctx, operation, refs)
+
+	if errors.Is(err, taskService.ErrVersionMismatch) {
+		taskID := operation.TaskID
+		if operation.TaskRef != "" {
+			taskID = refs[operation.TaskRef]
+		}
+
+		server, getErr := s.tasks.GetTask(ctx, taskID)
+		if getErr != nil {
+			return ChangeResult{Status: ChangeFailed, Err: getErr}
+		}
+		return ChangeResult{Status: ChangeConflict, Task: server, Err: err}
+	}
+	if err != nil {
+		return ChangeResult{Status: ChangeFailed, Err: err}
+	}
+
+	return ChangeResult{Status: ChangeApplied, Task: task}
+}
+
+// timeEntries derives time entries from tasks: every task is tracked as a
+// single interval from its last start (or creation) to its completion.
+func timeEntries(tasks []models.Task) []models.TimeEntry {
+	entries := make([]models.TimeEntry, 0, len(tasks))
+
+	for _, task := range tasks {
+		entries = append(entries, models.TimeEntryOf(task))
+	}
+
+	return entries
+}
+
+func encodeToken(cursor models.ChangePosition) string {
+	raw := strconv.FormatInt(cursor.Tx, 10) + "." + strconv.FormatInt(cursor.Seq, 10)
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+func decodeToken(token string) (models.ChangePosition, error) {
+	if token == "" {
+		return models.ChangePosition{}, nil
+	}
+
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return models.ChangePosition{}, err
+	}
+
+	tx, seq, ok := strings.Cut(string(raw), ".")
+	if !ok {
+		return models.ChangePosition{}, ErrInvalidToken
+	}
+
+	var since models.ChangePosition
+
+	since.Tx, err = strconv.ParseInt(tx, 10, 64)
+	if err != nil || since.Tx < 0 {
+		return models.ChangePosition{}, ErrInvalidToken
+	}
+
+	since.Seq, err = strconv.ParseInt(seq, 10, 64)
+	if err != nil || since.Seq < 0 {
+		return models.ChangePosition{}, ErrInvalidToken
+	}
+
+	return since, nil
+}
@@ -0,0 +1,48 @@
+package datasync
+
+import (
+	"encoding/base64"
+	"testing"
+
+	"github.com/Alhanaqtah/effective-mobile-test-task/internal/models"
+)
+
+func TestTokenRoundTrip(t *testing.T) {
+	for _, cursor := range []models.ChangePosition{{}, {Tx: 1, Seq: 1}, {Tx: 4294967296, Seq: 12}} {
+		got, err := decodeToken(encodeToken(cursor))
+		if err != nil || got != cursor {
+			t.Errorf("decodeToken(encodeToken(%+v)) = %+v, %v", cursor, got, err)
+		}
+	}
+}
+
+func TestDecodeToken(t *testing.T) {
+	encode := func(raw string) string {
+		return base64.RawURLEncoding.EncodeToString([]byte(raw))
+	}
+
+	tests := []struct {
+		name    string
+		token   string
+		want    models.ChangePosition
+		wantErr bool
+	}{
+		{name: "empty", token: "", want: models.ChangePosition{}},
+		{name: "valid", token: encode("10.7"), want: models.ChangePosition{Tx: 10, Seq: 7}},
+		{name: "missing sequence", token: encode("10"), wantErr: true},
+		{name: "negative", token: encode("-1.7"), wantErr: true},
+		{name: "not base64", token: "10.7!", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := decodeToken(tt.token)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("decodeToken(%q) error = %v, wantErr %v", tt.token, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("decodeToken(%q) = %+v, want %+v", tt.token, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,223 @@
+// Package report serves read-only queries that combine users, tasks and
+// their aggregates. Methods taking several users are meant for batch
+// loading and cost one query regardless of the number of users.
+package report
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/Alhanaqtah/effective-mobile-test-task/internal/lib/logger/sl"
+	"github.com/Alhanaqtah/effective-mobile-test-task/internal/lib/validation"
+	"github.com/Alhanaqtah/effective-mobile-test-task/internal/models"
+	"github.com/Alhanaqtah/effective-mobile-test-task/internal/repository"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel"
+)
+
+var tracer = otel.Tracer("github.com/Alhanaqtah/effective-mobile-test-task/internal/service/report")
+
+// MaxPageSize limits the number of items returned at once.
+const MaxPageSize = 100
+
+var (
+	ErrInvalidID        = errors.New("invalid id")
+	ErrInvalidPageSize  = errors.New("invalid page size")
+	ErrInvalidDateRange = errors.New("start date is after end date")
+	ErrTaskNotFound     = errors.New("task not found")
+)
+
+type Storage interface {
+	GetUsers(ctx context.Context, limit, offset int, filter string) ([]models.User, error)
+	GetUsersByIDs(ctx context.Context, userUUIDs []string) ([]models.User, error)
+	GetTasksOfUsers(ctx context.Context, userUUIDs []string, startDate, endDate time.Time, limit, offset int) ([]models.Task, error)
+	CountTasksOfUsers(ctx context.Context, userUUIDs []string, startDate, endDate time.Time) (map[string]int, error)
+	TotalDurations(ctx context.Context, userUUIDs []string, startDate, endDate time.Time) (map[string]float64, error)
+	FindTask(ctx context.Context, uuid string) (*models.Task, error)
+}
+
+// TaskPage is a page of the tasks of a user.
+type TaskPage struct {
+	Tasks []models.Task
+	Total int // Number of tasks on all pages
+}
+
+type Service struct {
+	storage Storage
+	log     *slog.Logger
+}
+
+func New(storage Storage, log *slog.Logger) *Service {
+	return &Service{
+		storage: storage,
+		log:     log,
+	}
+}
+
+// Users returns up to limit users matching filter after skipping offset,
+// and whether there are more.
+func (s *Service) Users(ctx context.Context, limit, offset int, filter string) ([]models.User, bool, error) {
+	const op = "service.report.Users"
+
+	ctx, span := tracer.Start(ctx, op)
+	defer span.End()
+
+	log := s.log.With(slog.String("op", op), sl.Trace(ctx))
+
+	if limit < 0 || limit > MaxPageSize {
+		return nil, false, fmt.Errorf("%s: %w", op, validation.For("first", ErrInvalidPageSize))
+	}
+
+	users, err := s.storage.GetUsers(ctx, limit+1, offset, filter)
+	if err != nil {
+		log.Error("failed to get users", sl.Error(err))
+		return nil, false, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if len(users) > limit {
+		return users[:limit], true, nil
+	}
+	return users, false, nil
+}
+
+// UsersByIDs returns the given users keyed by id. Unknown ids are missing
+// from the result.
+func (s *Service) UsersByIDs(ctx context.Context, userIDs []string) (map[string]models.User, error) {
+	const op = "service.report.UsersByIDs"
+
+	ctx, span := tracer.Start(ctx, op)
+	defer span.End()
+
+	log := s.log.With(slog.String("op", op), sl.Trace(ctx))
+
+	if err := validateIDs(userIDs); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	users, err := s.storage.GetUsersByIDs(ctx, userIDs)
+	if err != nil {
+		log.Error("failed to get users", sl.Error(err))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	byID := make(map[string]models.User, len(users))
+	for _, user := range users {
+		byID[user.ID] = user
+	}
+
+	return byID, nil
+}
+
+// Task returns the task with the given id.
+func (s *Service) Task(ctx context.Context, taskID string) (*models.Task, error) {
+	const op = "service.report.Task"
+
+	ctx, span := tracer.Start(ctx, op)
+	defer span.End()
+
+	log := s.log.With(slog.String("op", op), sl.Trace(ctx))
+
+	if err := validateIDs([]string{taskID}); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	task, err := s.storage.FindTask(ctx, taskID)
+	if err != nil {
+		if errors.Is(err, repository.ErrTaskNotFound) {
+			return nil, ErrTaskNotFound
+		}
+		log.Error("failed to find task", sl.Error(err))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return task, nil
+}
+
+// TasksOfUsers returns a page of up to limit tasks of each of the given
+// users created within the range after skipping offset of them, keyed by
+// user id.
+func (s *Service) TasksOfUsers(ctx context.Context, userIDs []string, from, to time.Time, limit, offset int) (map[string]TaskPage, error) {
+	const op = "service.report.TasksOfUsers"
+
+	ctx, span := tracer.Start(ctx, op)
+	defer span.End()
+
+	log := s.log.With(slog.String("op", op), sl.Trace(ctx))
+
+	if limit < 0 || limit > MaxPageSize {
+		return nil, fmt.Errorf("%s: %w", op, validation.For("first", ErrInvalidPageSize))
+	}
+	if err := validateRange(userIDs, from, to); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	counts, err := s.storage.CountTasksOfUsers(ctx, userIDs, from, to)
+	if err != nil {
+		log.Error("failed to count tasks", sl.Error(err))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	tasks, err := s.storage.GetTasksOfUsers(ctx, userIDs, from, to, limit, offset)
+	if err != nil {
+		log.Error("failed to get tasks", sl.Error(err))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	pages := make(map[string]TaskPage, len(userIDs))
+	for _, id := range userIDs {
+		pages[id] = TaskPage{Total: counts[id]}
+	}
+	for _, task := range tasks {
+		page := pages[task.UserID]
+		page.Tasks = append(page.Tasks, task)
+		pages[task.UserID] = page
+	}
+
+	return pages, nil
+}
+
+// TotalDurations returns the minutes each of the given users spent on
+// tasks created within the range, zero for users without tasks.
+func (s *Service) TotalDurations(ctx context.Context, userIDs []string, from, to time.Time) (map[string]float64, error) {
+	const op = "service.report.TotalDurations"
+
+	ctx, span := tracer.Start(ctx, op)
+	defer span.End()
+
+	log := s.log.With(slog.String("op", op), sl.Trace(ctx))
+
+	if err := validateRange(userIDs, from, to); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	durations, err := s.storage.TotalDurations(ctx, userIDs, from, to)
+	if err != nil {
+		log.Error("failed to get durations", sl.Error(err))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return durations, nil
+}
+
+func validateRange(userIDs []string, from, to time.Time) error {
+	if err := validateIDs(userIDs); err != nil {
+		return err
+	}
+	if from.After(to) {
+		return ErrInvalidDateRange
+	}
+	return nil
+}
+
+func validateIDs(ids []string) error {
+	for _, id := range ids {
+		if _, err := uuid.Parse(id); err != nil {
+			return validation.For("id", ErrInvalidID)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,155 @@
+package task
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/Alhanaqtah/effective-mobile-test-task/internal/lib/logger/sl"
+	"github.com/Alhanaqtah/effective-mobile-test-task/internal/lib/validation"
+	"github.com/Alhanaqtah/effective-mobile-test-task/internal/models"
+
+	"github.com/google/uuid"
+)
+
+// MaxBatchSize limits the number of operations in one batch.
+const MaxBatchSize = 500
+
+var (
+	ErrEmptyBatch       = errors.New("batch contains no operations")
+	ErrBatchTooLarge    = errors.New("batch contains too many operations")
+	ErrUnknownOperation = errors.New("unknown operation")
+	ErrUnknownRef       = errors.New("unknown task reference")
+	ErrBatchAborted     = errors.New("batch aborted by a failed operation")
+)
+
+// Statuses of a single operation in a batch.
+const (
+	OperationApplied    = "applied"
+	OperationFailed     = "failed"
+	OperationRolledBack = "rolled_back"
+	OperationSkipped    = "skipped"
+)
+
+// OperationResult is the outcome of a single batch operation.
+type OperationResult struct {
+	Status string
+	Task   *models.Task
+	Err    error
+}
+
+// Batch applies operations in order. In atomic mode they run in a single
+// transaction that is rolled back on the first failure; otherwise every
+// operation is applied on its own and failures don't stop the batch.
+// Later operations may refer to tasks created earlier in the batch by Ref.
+func (s *Service) Batch(ctx context.Context, ops []models.TaskOperation, atomic bool) ([]OperationResult, error) {
+	const op = "service.task.Batch"
+
+	ctx, span := tracer.Start(ctx, op)
+	defer span.End()
+
+	log := s.log.With(slog.String("op", op), sl.Trace(ctx))
+
+	if len(ops) == 0 {
+		log.Debug("batch is empty")
+		return nil, fmt.Errorf("%s: %w", op, ErrEmptyBatch)
+	}
+	if len(ops) > MaxBatchSize {
+		log.Debug("batch is too large", slog.Int("size", len(ops)))
+		return nil, fmt.Errorf("%s: %w", op, ErrBatchTooLarge)
+	}
+
+	results := make([]OperationResult, len(ops))
+
+	if !atomic {
+		refs := make(map[string]string)
+		for i, operation := range ops {
+			task, err := s.Apply(ctx, operation, refs)
+			results[i] = result(task, err)
+		}
+		return results, nil
+	}
+
+	err := s.storage.InTx(ctx, func(ctx context.Context) error {
+		refs := make(map[string]string)
+		for i, operation := range ops {
+			task, err := s.Apply(ctx, operation, refs)
+			results[i] = result(task, err)
+			if err != nil {
+				return ErrBatchAborted
+			}
+		}
+		return nil
+	})
+	if err == nil {
+		return results, nil
+	}
+
+	if !errors.Is(err, ErrBatchAborted) {
+		log.Error("failed to apply batch", sl.Error(err))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	// Nothing was committed: report applied operations as rolled back and
+	// the ones after the failure as skipped.
+	failed := false
+	for i := range results {
+		switch {
+		case failed:
+			results[i] = OperationResult{Status: OperationSkipped}
+		case results[i].Status == OperationFailed:
+			failed = true
+		default:
+			results[i] = OperationResult{Status: OperationRolledBack}
+		}
+	}
+
+	log.Debug("batch rolled back")
+
+	return results, nil
+}
+
+// Apply applies a single operation. refs maps client references of tasks
+// created so far to their ids and is updated by create operations.
+// A successful delete returns a nil task.
+func (s *Service) Apply(ctx context.Context, operation models.TaskOperation, refs map[string]string) (*models.Task, error) {
+	if operation.Op == models.TaskOperationCreate {
+		task, err := s.CreateTask(ctx, operation.UserID, operation.Title, operation.Description, operation.At)
+		if err == nil && operation.Ref != "" {
+			refs[operation.Ref] = task.ID
+		}
+		return task, err
+	}
+
+	taskID := operation.TaskID
+	if operation.TaskRef != "" {
+		id, ok := refs[operation.TaskRef]
+		if !ok {
+			return nil, validation.For("task_ref", ErrUnknownRef)
+		}
+		taskID = id
+	} else if _, err := uuid.Parse(taskID); err != nil {
+		return nil, validation.For("task_id", ErrInvalidUUID)
+	}
+
+	switch operation.Op {
+	case models.TaskOperationUpdate:
+		return s.UpdateTask(ctx, taskID, operation.Update, operation.Version)
+	case models.TaskOperationStart:
+		return s.StartTask(ctx, taskID, operation.At, operation.Version)
+	case models.TaskOperationFinish:
+		return s.FinishTask(ctx, taskID, operation.At, operation.Version)
+	case models.TaskOperationDelete:
+		return nil, s.DeleteTask(ctx, taskID, operation.Version)
+	default:
+		return nil, validation.For("op", ErrUnknownOperation)
+	}
+}
+
+func result(task *models.Task, err error) OperationResult {
+	if err != nil {
+		return OperationResult{Status: OperationFailed, Err: err}
+	}
+	return OperationResult{Status: OperationApplied, Task: task}
+}
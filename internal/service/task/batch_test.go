@@ -0,0 +1,334 @@
+package task
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"maps"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/Alhanaqtah/effective-mobile-test-task/internal/lib/validation"
+	"github.com/Alhanaqtah/effective-mobile-test-task/internal/models"
+	"github.com/Alhanaqtah/effective-mobile-test-task/internal/repository"
+
+	"github.com/google/uuid"
+)
+
+type txKey struct{}
+
+// fakeStorage keeps tasks in memory. InTx runs the callback and restores the
+// tasks and events on failure, as a rolled back transaction would; nested
+// calls join the outer transaction.
+type fakeStorage struct {
+	Storage
+	users  map[string]bool
+	tasks  map[string]models.Task
+	events []models.Event
+}
+
+func newFakeStorage(users ...string) *fakeStorage {
+	s := &fakeStorage{users: map[string]bool{}, tasks: map[string]models.Task{}}
+	for _, id := range users {
+		s.users[id] = true
+	}
+	return s
+}
+
+func (s *fakeStorage) InTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	if ctx.Value(txKey{}) != nil {
+		return fn(ctx)
+	}
+
+	tasks, events := maps.Clone(s.tasks), slices.Clone(s.events)
+	if err := fn(context.WithValue(ctx, txKey{}, true)); err != nil {
+		s.tasks, s.events = tasks, events
+		return err
+	}
+	return nil
+}
+
+func (s *fakeStorage) SaveEvent(_ context.Context, event models.Event) error {
+	s.events = append(s.events, event)
+	return nil
+}
+
+func (s *fakeStorage) CreateTask(_ context.Context, task *models.Task) (*models.Task, error) {
+	if !s.users[task.UserID] {
+		return nil, repository.ErrUserNotFound
+	}
+
+	created := *task
+	created.ID = uuid.NewString()
+	created.Version = 1
+	s.tasks[created.ID] = created
+	return &created, nil
+}
+
+func (s *fakeStorage) FindTask(_ context.Context, id string) (*models.Task, error) {
+	task, ok := s.tasks[id]
+	if !ok {
+		return nil, repository.ErrTaskNotFound
+	}
+	return &task, nil
+}
+
+// change applies fn to the task if its version matches.
+func (s *fakeStorage) change(id string, version int, fn func(task *models.Task)) (*models.Task, error) {
+	task, ok := s.tasks[id]
+	if !ok {
+		return nil, repository.ErrTaskNotFound
+	}
+	if version != 0 && version != task.Version {
+		return nil, repository.ErrVersionMismatch
+	}
+
+	fn(&task)
+	task.Version++
+	s.tasks[id] = task
+	return &task, nil
+}
+
+func (s *fakeStorage) UpdateTask(_ context.Context, id string, update models.TaskUpdate, version int) (*models.Task, error) {
+	return s.change(id, version, func(task *models.Task) {
+		if update.Title.Set {
+			task.Title = update.Title.Value
+		}
+	})
+}
+
+func (s *fakeStorage) StartTask(_ context.Context, id string, at time.Time, version int) (*models.Task, error) {
+	return s.change(id, version, func(task *models.Task) {
+		task.StartedAt = &at
+	})
+}
+
+func (s *fakeStorage) FinishTask(_ context.Context, id string, at time.Time, version int) (*models.Task, error) {
+	return s.change(id, version, func(task *models.Task) {
+		task.Done = true
+		task.DoneAt = &at
+	})
+}
+
+func (s *fakeStorage) DeleteTask(_ context.Context, id string, version int) (*models.Task, error) {
+	task, err := s.change(id, version, func(*models.Task) {})
+	if err != nil {
+		return nil, err
+	}
+	delete(s.tasks, id)
+	return task, nil
+}
+
+type nopPublisher struct{}
+
+func (nopPublisher) Publish(context.Context, models.Event) error {
+	return nil
+}
+
+func TestBatch(t *testing.T) {
+	user := uuid.NewString()
+	missingUser := uuid.NewString()
+	missingTask := uuid.NewString()
+
+	create := func(ref string) models.TaskOperation {
+		return models.TaskOperation{Op: models.TaskOperationCreate, Ref: ref, UserID: user, Title: "task " + ref}
+	}
+	rename := models.TaskOperation{Op: models.TaskOperationUpdate, TaskRef: "a", Update: models.TaskUpdate{Title: models.Some("renamed")}}
+
+	tests := []struct {
+		name   string
+		ops    []models.TaskOperation
+		atomic bool
+		want   []string
+		// errs are the errors of the failed operations, in order
+		errs []error
+		// tasks is the number of tasks stored after the batch
+		tasks int
+	}{
+		{
+			name:   "atomic applied",
+			ops:    []models.TaskOperation{create("a"), rename, {Op: models.TaskOperationStart, TaskRef: "a"}, {Op: models.TaskOperationFinish, TaskRef: "a"}},
+			atomic: true,
+			want:   []string{OperationApplied, OperationApplied, OperationApplied, OperationApplied},
+			tasks:  1,
+		},
+		{
+			name:   "atomic rolled back",
+			ops:    []models.TaskOperation{create("a"), rename, {Op: models.TaskOperationStart, TaskID: missingTask}, create("b"), rename},
+			atomic: true,
+			want:   []string{OperationRolledBack, OperationRolledBack, OperationFailed, OperationSkipped, OperationSkipped},
+			errs:   []error{ErrTaskNotFound},
+			tasks:  0,
+		},
+		{
+			name:   "atomic failed first",
+			ops:    []models.TaskOperation{{Op: "rename", TaskID: missingTask}, create("a")},
+			atomic: true,
+			want:   []string{OperationFailed, OperationSkipped},
+			errs:   []error{ErrUnknownOperation},
+			tasks:  0,
+		},
+		{
+			name:  "independent",
+			ops:   []models.TaskOperation{create("a"), {Op: models.TaskOperationDelete, TaskID: missingTask}, rename, {Op: models.TaskOperationDelete, TaskRef: "a"}},
+			want:  []string{OperationApplied, OperationFailed, OperationApplied, OperationApplied},
+			errs:  []error{ErrTaskNotFound},
+			tasks: 0,
+		},
+		{
+			name:  "independent keeps applied operations",
+			ops:   []models.TaskOperation{create("a"), {Op: models.TaskOperationUpdate, TaskRef: "a", Update: models.TaskUpdate{Title: models.Some("renamed")}, Version: 5}, create("b")},
+			want:  []string{OperationApplied, OperationFailed, OperationApplied},
+			errs:  []error{ErrVersionMismatch},
+			tasks: 2,
+		},
+		{
+			name: "ref of a failed create",
+			ops: []models.TaskOperation{
+				{Op: models.TaskOperationCreate, Ref: "a", UserID: missingUser, Title: "task a"},
+				rename,
+			},
+			want:  []string{OperationFailed, OperationFailed},
+			errs:  []error{ErrUserNotFound, ErrUnknownRef},
+			tasks: 0,
+		},
+		{
+			name:  "ref used before its create",
+			ops:   []models.TaskOperation{rename, create("a")},
+			want:  []string{OperationFailed, OperationApplied},
+			errs:  []error{ErrUnknownRef},
+			tasks: 1,
+		},
+		{
+			name:  "invalid task id",
+			ops:   []models.TaskOperation{{Op: models.TaskOperationFinish, TaskID: "42"}},
+			want:  []string{OperationFailed},
+			errs:  []error{ErrInvalidUUID},
+			tasks: 0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			storage := newFakeStorage(user)
+			s := New(storage, nopPublisher{}, slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+			results, err := s.Batch(context.Background(), tt.ops, tt.atomic)
+			if err != nil {
+				t.Fatalf("Batch() error = %v", err)
+			}
+
+			var statuses []string
+			var errs []error
+			for _, r := range results {
+				statuses = append(statuses, r.Status)
+				if r.Status == OperationFailed {
+					errs = append(errs, r.Err)
+				}
+				if r.Status == OperationApplied && r.Err != nil {
+					t.Errorf("applied operation has error %v", r.Err)
+				}
+			}
+			if !slices.Equal(statuses, tt.want) {
+				t.Fatalf("statuses = %v, want %v", statuses, tt.want)
+			}
+			if len(errs) != len(tt.errs) {
+				t.Fatalf("errors = %v, want %v", errs, tt.errs)
+			}
+			for i := range errs {
+				if !errors.Is(errs[i], tt.errs[i]) {
+					t.Errorf("error %d = %v, want %v", i, errs[i], tt.errs[i])
+				}
+			}
+
+			if len(storage.tasks) != tt.tasks {
+				t.Errorf("%d tasks stored, want %d", len(storage.tasks), tt.tasks)
+			}
+		})
+	}
+}
+
+// TestBatchRolledBackEvents checks that a rolled back batch leaves no events
+// in the outbox.
+func TestBatchRolledBackEvents(t *testing.T) {
+	user := uuid.NewString()
+	storage := newFakeStorage(user)
+	s := New(storage, nopPublisher{}, slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+	ops := []models.TaskOperation{
+		{Op: models.TaskOperationCreate, Ref: "a", UserID: user, Title: "task"},
+		{Op: models.TaskOperationStart, TaskRef: "b"},
+	}
+	if _, err := s.Batch(context.Background(), ops, true); err != nil {
+		t.Fatalf("Batch() error = %v", err)
+	}
+	if len(storage.events) != 0 {
+		t.Errorf("%d events saved by a rolled back batch", len(storage.events))
+	}
+
+	if _, err := s.Batch(context.Background(), ops, false); err != nil {
+		t.Fatalf("Batch() error = %v", err)
+	}
+	if len(storage.events) != 1 || storage.events[0].Type != models.EventTaskCreated {
+		t.Errorf("events = %v, want the created task", storage.events)
+	}
+}
+
+func TestBatchSize(t *testing.T) {
+	user := uuid.NewString()
+	s := New(newFakeStorage(user), nopPublisher{}, slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+	op := models.TaskOperation{Op: models.TaskOperationCreate, UserID: user, Title: "task"}
+
+	tests := []struct {
+		name string
+		size int
+		want error
+	}{
+		{"empty", 0, ErrEmptyBatch},
+		{"largest", MaxBatchSize, nil},
+		{"too large", MaxBatchSize + 1, ErrBatchTooLarge},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, atomic := range []bool{true, false} {
+				ops := make([]models.TaskOperation, tt.size)
+				for i := range ops {
+					ops[i] = op
+				}
+
+				results, err := s.Batch(context.Background(), ops, atomic)
+				if !errors.Is(err, tt.want) {
+					t.Fatalf("Batch(atomic %v) error = %v, want %v", atomic, err, tt.want)
+				}
+				if err == nil && len(results) != tt.size {
+					t.Errorf("Batch(atomic %v) returned %d results, want %d", atomic, len(results), tt.size)
+				}
+			}
+		})
+	}
+}
+
+// TestApplyFieldErrors checks that reference errors point at the field the
+// client has to fix.
+func TestApplyFieldErrors(t *testing.T) {
+	s := New(newFakeStorage(), nopPublisher{}, slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+	tests := []struct {
+		op    models.TaskOperation
+		field string
+	}{
+		{models.TaskOperation{Op: models.TaskOperationStart, TaskRef: "missing"}, "task_ref"},
+		{models.TaskOperation{Op: models.TaskOperationStart, TaskID: "42"}, "task_id"},
+		{models.TaskOperation{Op: "rename", TaskID: uuid.NewString()}, "op"},
+	}
+	for _, tt := range tests {
+		_, err := s.Apply(context.Background(), tt.op, map[string]string{})
+
+		var fe *validation.FieldError
+		if !errors.As(err, &fe) || fe.Field != tt.field {
+			t.Errorf("Apply(%+v) error = %v, want an error of %s", tt.op, err, tt.field)
+		}
+	}
+}
@@ -5,37 +5,66 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	"strings"
 	"time"
 
-	"time-tracker/internal/lib/logger/sl"
-	"time-tracker/internal/models"
-	"time-tracker/internal/repository"
+	"github.com/Alhanaqtah/effective-mobile-test-task/internal/lib/logger/sl"
+	"github.com/Alhanaqtah/effective-mobile-test-task/internal/lib/validation"
+	"github.com/Alhanaqtah/effective-mobile-test-task/internal/models"
+	"github.com/Alhanaqtah/effective-mobile-test-task/internal/repository"
 
 	"github.com/google/uuid"
+	"go.opentelemetry.io/otel"
 )
 
+var tracer = otel.Tracer("github.com/Alhanaqtah/effective-mobile-test-task/internal/service/task")
+
 var (
 	ErrInvalidDateRange = errors.New("invalid date range")
 	ErrInvalidUUID      = errors.New("invalid uuid format")
 	ErrInvalidDate      = errors.New("invalid date format")
 	ErrTaskNotFound     = errors.New("task not found")
+	ErrEmptyQuery       = errors.New("search query is empty")
+	ErrEmptyScope       = errors.New("no users to search in")
+	ErrVersionMismatch  = errors.New("task version mismatch")
+	ErrTaskExists       = errors.New("task already exists")
+	ErrUserNotFound     = errors.New("user not found")
+	ErrEmptyUpdate      = errors.New("task update is empty")
+	ErrFutureTime       = errors.New("timestamp is in the future")
 )
 
+// maxClockSkew is how far ahead of the server clock a client timestamp may be.
+const maxClockSkew = 5 * time.Minute
+
 type Storage interface {
 	GetTasksInRange(ctx context.Context, userUUID string, startDate, endDate time.Time) ([]models.Task, error)
+	SearchTasks(ctx context.Context, query string, userUUIDs []string, limit, offset int) ([]models.TaskSearchResult, error)
 	FindTask(ctx context.Context, uuid string) (*models.Task, error)
-	StartTask(ctx context.Context, uuid string) (*models.Task, error)
-	FinishTask(ctx context.Context, uuid string, doneAt time.Time) (*models.Task, error)
+	RunningTasks(ctx context.Context, userUUIDs []string) ([]models.Task, error)
+	CreateTask(ctx context.Context, task *models.Task) (*models.Task, error)
+	UpdateTask(ctx context.Context, uuid string, update models.TaskUpdate, version int) (*models.Task, error)
+	StartTask(ctx context.Context, uuid string, startedAt time.Time, version int) (*models.Task, error)
+	FinishTask(ctx context.Context, uuid string, doneAt time.Time, version int) (*models.Task, error)
+	DeleteTask(ctx context.Context, uuid string, version int) (*models.Task, error)
+	SaveEvent(ctx context.Context, event models.Event) error
+	InTx(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+type Publisher interface {
+	// Publish sends event once the transaction in ctx commits.
+	Publish(ctx context.Context, event models.Event) error
 }
 
 type Service struct {
 	storage Storage
+	events  Publisher
 	log     *slog.Logger
 }
 
-func New(storage Storage, log *slog.Logger) *Service {
+func New(storage Storage, events Publisher, log *slog.Logger) *Service {
 	return &Service{
 		storage: storage,
+		events:  events,
 		log:     log,
 	}
 }
@@ -43,28 +72,31 @@ func New(storage Storage, log *slog.Logger) *Service {
 func (s *Service) GetTasksInRange(ctx context.Context, userUUID, startDate, endDate string) ([]models.Task, error) {
 	const op = "service.task.GetTasksInRange"
 
-	log := s.log.With(slog.String("op", op))
+	ctx, span := tracer.Start(ctx, op)
+	defer span.End()
+
+	log := s.log.With(slog.String("op", op), sl.Trace(ctx))
 
-	log.Debug("validating input parameters", slog.String("userUUID", userUUID), slog.String("startDate", startDate), slog.String("endDate", endDate))
+	log.Debug("validating input parameters", slog.String("uuid", userUUID), slog.String("startDate", startDate), slog.String("endDate", endDate))
 
 	// Validate userUUID
 	_, err := uuid.Parse(userUUID)
 	if err != nil {
 		log.Error("invalid userUUID", sl.Error(err))
-		return nil, fmt.Errorf("%s: %w", op, ErrInvalidUUID)
+		return nil, fmt.Errorf("%s: %w", op, validation.For("user_id", ErrInvalidUUID))
 	}
 
 	// Parse startDate and endDate
 	start, err := time.Parse(time.RFC3339, startDate)
 	if err != nil {
 		log.Error("invalid startDate", sl.Error(err))
-		return nil, fmt.Errorf("%s: %w", op, ErrInvalidDate)
+		return nil, fmt.Errorf("%s: %w", op, validation.For("start_date", ErrInvalidDate))
 	}
 
 	end, err := time.Parse(time.RFC3339, endDate)
 	if err != nil {
 		log.Error("invalid endDate", sl.Error(err))
-		return nil, fmt.Errorf("%s: %w", op, ErrInvalidDate)
+		return nil, fmt.Errorf("%s: %w", op, validation.For("end_date", ErrInvalidDate))
 	}
 
 	if start.After(end) {
@@ -72,7 +104,7 @@ func (s *Service) GetTasksInRange(ctx context.Context, userUUID, startDate, endD
 		return nil, fmt.Errorf("%s: %w", op, ErrInvalidDateRange)
 	}
 
-	log.Debug("fetching tasks from storage", slog.String("userUUID", userUUID), slog.Time("startDate", start), slog.Time("endDate", end))
+	log.Debug("fetching tasks from storage", slog.String("uuid", userUUID), slog.Time("startDate", start), slog.Time("endDate", end))
 
 	tasks, err := s.storage.GetTasksInRange(ctx, userUUID, start, end)
 	if err != nil {
@@ -85,14 +117,212 @@ func (s *Service) GetTasksInRange(ctx context.Context, userUUID, startDate, endD
 	return tasks, nil
 }
 
-func (s *Service) StartTask(ctx context.Context, uuid string) (*models.Task, error) {
+// SearchTasks runs a full-text search over titles and descriptions of tasks
+// that belong to the given users. The service has no notion of the caller,
+// so the set of visible users is always passed in explicitly.
+func (s *Service) SearchTasks(ctx context.Context, query string, userUUIDs []string, page int) ([]models.TaskSearchResult, error) {
+	const op = "service.task.SearchTasks"
+
+	ctx, span := tracer.Start(ctx, op)
+	defer span.End()
+
+	log := s.log.With(slog.String("op", op), sl.Trace(ctx))
+
+	log.Debug("validating input parameters", slog.String("query", query), slog.Any("userUUIDs", userUUIDs))
+
+	if strings.TrimSpace(query) == "" {
+		log.Error("search query is empty")
+		return nil, fmt.Errorf("%s: %w", op, validation.For("q", ErrEmptyQuery))
+	}
+
+	if len(userUUIDs) == 0 {
+		log.Error("no users to search in")
+		return nil, fmt.Errorf("%s: %w", op, validation.For("user_id", ErrEmptyScope))
+	}
+
+	for _, userUUID := range userUUIDs {
+		if _, err := uuid.Parse(userUUID); err != nil {
+			log.Error("invalid userUUID", sl.Error(err))
+			return nil, fmt.Errorf("%s: %w", op, validation.For("user_id", ErrInvalidUUID))
+		}
+	}
+
+	const limit = 10
+	offset := (page - 1) * limit
+
+	results, err := s.storage.SearchTasks(ctx, query, userUUIDs, limit, offset)
+	if err != nil {
+		log.Error("failed to search tasks in storage", sl.Error(err))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	log.Debug("tasks searched successfully", slog.Int("found", len(results)))
+
+	return results, nil
+}
+
+func (s *Service) GetTask(ctx context.Context, taskUUID string) (*models.Task, error) {
+	const op = "service.task.GetTask"
+
+	ctx, span := tracer.Start(ctx, op)
+	defer span.End()
+
+	log := s.log.With(slog.String("op", op), sl.Trace(ctx))
+
+	if _, err := uuid.Parse(taskUUID); err != nil {
+		log.Debug("invalid taskUUID", sl.Error(err))
+		return nil, fmt.Errorf("%s: %w", op, validation.For("id", ErrInvalidUUID))
+	}
+
+	task, err := s.storage.FindTask(ctx, taskUUID)
+	if err != nil {
+		log.Error("failed to find task in storage", sl.Error(err))
+		if errors.Is(err, repository.ErrTaskNotFound) {
+			return nil, ErrTaskNotFound
+		}
+		return nil, err
+	}
+
+	return task, nil
+}
+
+// RunningTasks returns the task each of the given users is working on, i.e.
+// their most recently started running task. Users without one are absent
+// from the result.
+func (s *Service) RunningTasks(ctx context.Context, userUUIDs []string) (map[string]models.Task, error) {
+	const op = "service.task.RunningTasks"
+
+	ctx, span := tracer.Start(ctx, op)
+	defer span.End()
+
+	log := s.log.With(slog.String("op", op), sl.Trace(ctx))
+
+	for _, userUUID := range userUUIDs {
+		if _, err := uuid.Parse(userUUID); err != nil {
+			log.Error("invalid userUUID", sl.Error(err))
+			return nil, fmt.Errorf("%s: %w", op, validation.For("user_id", ErrInvalidUUID))
+		}
+	}
+
+	tasks, err := s.storage.RunningTasks(ctx, userUUIDs)
+	if err != nil {
+		log.Error("failed to get running tasks", sl.Error(err))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	running := make(map[string]models.Task, len(userUUIDs))
+	for _, task := range tasks {
+		if _, ok := running[task.UserID]; !ok {
+			running[task.UserID] = task
+		}
+	}
+
+	return running, nil
+}
+
+// CreateTask creates a task for the user. A zero createdAt means now,
+// otherwise it is the client time the task was created at.
+func (s *Service) CreateTask(ctx context.Context, userUUID, title, description string, createdAt time.Time) (*models.Task, error) {
+	const op = "service.task.CreateTask"
+
+	ctx, span := tracer.Start(ctx, op)
+	defer span.End()
+
+	log := s.log.With(slog.String("op", op), sl.Trace(ctx))
+
+	if _, err := uuid.Parse(userUUID); err != nil {
+		log.Error("invalid userUUID", sl.Error(err))
+		return nil, fmt.Errorf("%s: %w", op, validation.For("user_id", ErrInvalidUUID))
+	}
+
+	if strings.TrimSpace(title) == "" {
+		log.Debug("task title is empty")
+		return nil, fmt.Errorf("%s: %w", op, validation.For("title", validation.ErrRequired))
+	}
+
+	createdAt, err := clientTime(createdAt)
+	if err != nil {
+		log.Debug("invalid client time", sl.Error(err))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	task, err := s.mutate(ctx, models.EventTaskCreated, func(ctx context.Context) (*models.Task, error) {
+		return s.storage.CreateTask(ctx, &models.Task{
+			UserID:      userUUID,
+			Title:       title,
+			Description: description,
+			CreatedAt:   createdAt,
+		})
+	})
+	if err != nil {
+		log.Error("failed to create task", sl.Error(err))
+		return nil, mapStorageError(err)
+	}
+
+	return task, nil
+}
+
+// UpdateTask applies update to the task if its current version equals
+// version; a zero version skips the check.
+func (s *Service) UpdateTask(ctx context.Context, taskUUID string, update models.TaskUpdate, version int) (*models.Task, error) {
+	const op = "service.task.UpdateTask"
+
+	ctx, span := tracer.Start(ctx, op)
+	defer span.End()
+
+	log := s.log.With(slog.String("op", op), sl.Trace(ctx))
+
+	if _, err := uuid.Parse(taskUUID); err != nil {
+		log.Debug("invalid taskUUID", sl.Error(err))
+		return nil, fmt.Errorf("%s: %w", op, validation.For("id", ErrInvalidUUID))
+	}
+
+	if update.IsEmpty() {
+		log.Debug("task update is empty")
+		return nil, fmt.Errorf("%s: %w", op, ErrEmptyUpdate)
+	}
+
+	if update.Title.Set && (update.Title.Null || strings.TrimSpace(update.Title.Value) == "") {
+		log.Debug("task title is empty")
+		return nil, fmt.Errorf("%s: %w", op, validation.For("title", validation.ErrRequired))
+	}
+
+	task, err := s.mutate(ctx, models.EventTaskUpdated, func(ctx context.Context) (*models.Task, error) {
+		return s.storage.UpdateTask(ctx, taskUUID, update, version)
+	})
+	if err != nil {
+		log.Error("failed to update task", sl.Error(err))
+		return nil, mapStorageError(err)
+	}
+
+	return task, nil
+}
+
+// StartTask starts the task at the given time if its current version equals
+// version; a zero version skips the check and a zero time means now.
+// FinishTask follows the same rules.
+func (s *Service) StartTask(ctx context.Context, taskUUID string, at time.Time, version int) (*models.Task, error) {
 	const op = "service.task.StartTask"
 
-	log := s.log.With(slog.String("op", op))
+	ctx, span := tracer.Start(ctx, op)
+	defer span.End()
 
-	log.Debug("checking if task exists", slog.String("uuid", uuid))
+	log := s.log.With(slog.String("op", op), sl.Trace(ctx))
+
+	if _, err := uuid.Parse(taskUUID); err != nil {
+		log.Debug("invalid taskUUID", sl.Error(err))
+		return nil, fmt.Errorf("%s: %w", op, validation.For("id", ErrInvalidUUID))
+	}
 
-	_, err := s.storage.FindTask(ctx, uuid)
+	at, err := clientTime(at)
+	if err != nil {
+		log.Debug("invalid client time", sl.Error(err))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	log.Debug("checking if task exists", slog.String("uuid", taskUUID))
+
+	_, err = s.storage.FindTask(ctx, taskUUID)
 	if err != nil {
 		log.Error("failed to find task in storage", sl.Error(err))
 		if errors.Is(err, repository.ErrTaskNotFound) {
@@ -101,25 +331,41 @@ func (s *Service) StartTask(ctx context.Context, uuid string) (*models.Task, err
 		return nil, err
 	}
 
-	log.Debug("starting task", slog.String("uuid", uuid))
+	log.Debug("starting task", slog.String("uuid", taskUUID))
 
-	task, err := s.storage.StartTask(ctx, uuid)
+	task, err := s.mutate(ctx, models.EventTaskStarted, func(ctx context.Context) (*models.Task, error) {
+		return s.storage.StartTask(ctx, taskUUID, at, version)
+	})
 	if err != nil {
 		log.Error("failed to start task", sl.Error(err))
-		return nil, err
+		return nil, mapStorageError(err)
 	}
 
 	return task, nil
 }
 
-func (s *Service) FinishTask(ctx context.Context, uuid string) (*models.Task, error) {
+func (s *Service) FinishTask(ctx context.Context, taskUUID string, at time.Time, version int) (*models.Task, error) {
 	const op = "service.task.FinishTask"
 
-	log := s.log.With(slog.String("op", op))
+	ctx, span := tracer.Start(ctx, op)
+	defer span.End()
+
+	log := s.log.With(slog.String("op", op), sl.Trace(ctx))
+
+	if _, err := uuid.Parse(taskUUID); err != nil {
+		log.Debug("invalid taskUUID", sl.Error(err))
+		return nil, fmt.Errorf("%s: %w", op, validation.For("id", ErrInvalidUUID))
+	}
+
+	at, err := clientTime(at)
+	if err != nil {
+		log.Debug("invalid client time", sl.Error(err))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
 
-	log.Debug("checking if task exists", slog.String("uuid", uuid))
+	log.Debug("checking if task exists", slog.String("uuid", taskUUID))
 
-	_, err := s.storage.FindTask(ctx, uuid)
+	task, err := s.storage.FindTask(ctx, taskUUID)
 	if err != nil {
 		log.Error("failed to find task in storage", sl.Error(err))
 		if errors.Is(err, repository.ErrTaskNotFound) {
@@ -128,13 +374,108 @@ func (s *Service) FinishTask(ctx context.Context, uuid string) (*models.Task, er
 		return nil, err
 	}
 
-	log.Debug("finishing task", slog.String("uuid", uuid))
+	startedAt := task.CreatedAt
+	if task.StartedAt != nil {
+		startedAt = *task.StartedAt
+	}
+	if at.Before(startedAt) {
+		log.Debug("task is finished before it was started")
+		return nil, fmt.Errorf("%s: %w", op, validation.For("at", ErrInvalidDateRange))
+	}
 
-	task, err := s.storage.FinishTask(ctx, uuid, time.Now())
+	log.Debug("finishing task", slog.String("uuid", taskUUID))
+
+	task, err = s.mutate(ctx, models.EventTaskFinished, func(ctx context.Context) (*models.Task, error) {
+		return s.storage.FinishTask(ctx, taskUUID, at, version)
+	})
 	if err != nil {
 		log.Error("failed to finish task", sl.Error(err))
+		return nil, mapStorageError(err)
+	}
+
+	return task, nil
+}
+
+// DeleteTask deletes the task if its current version equals version;
+// a zero version skips the check.
+func (s *Service) DeleteTask(ctx context.Context, taskUUID string, version int) error {
+	const op = "service.task.DeleteTask"
+
+	ctx, span := tracer.Start(ctx, op)
+	defer span.End()
+
+	log := s.log.With(slog.String("op", op), sl.Trace(ctx))
+
+	if _, err := uuid.Parse(taskUUID); err != nil {
+		log.Debug("invalid taskUUID", sl.Error(err))
+		return fmt.Errorf("%s: %w", op, validation.For("id", ErrInvalidUUID))
+	}
+
+	log.Debug("deleting task", slog.String("uuid", taskUUID), slog.Int("version", version))
+
+	_, err := s.mutate(ctx, models.EventTaskDeleted, func(ctx context.Context) (*models.Task, error) {
+		return s.storage.DeleteTask(ctx, taskUUID, version)
+	})
+	if err != nil {
+		log.Error("failed to delete task", sl.Error(err))
+		return mapStorageError(err)
+	}
+
+	return nil
+}
+
+// clientTime defaults a zero timestamp to now and rejects timestamps
+// too far in the future. Timestamps are kept in UTC, whatever the offset
+// the client sent them with.
+func clientTime(at time.Time) (time.Time, error) {
+	now := time.Now().UTC()
+	if at.IsZero() {
+		return now, nil
+	}
+	if at.After(now.Add(maxClockSkew)) {
+		return time.Time{}, validation.For("at", ErrFutureTime)
+	}
+	return at.UTC(), nil
+}
+
+// mutate runs fn, records its event in the outbox and announces it to live
+// subscribers in one transaction, so inside an atomic batch rolled back
+// changes are never announced.
+func (s *Service) mutate(ctx context.Context, eventType string, fn func(ctx context.Context) (*models.Task, error)) (*models.Task, error) {
+	var task *models.Task
+
+	err := s.storage.InTx(ctx, func(ctx context.Context) error {
+		var err error
+		task, err = fn(ctx)
+		if err != nil {
+			return err
+		}
+
+		event := models.Event{Type: eventType, UserID: task.UserID, Time: time.Now(), Data: task}
+		if err := s.storage.SaveEvent(ctx, event); err != nil {
+			return err
+		}
+		return s.events.Publish(ctx, event)
+	})
+	if err != nil {
 		return nil, err
 	}
 
 	return task, nil
 }
+
+func mapStorageError(err error) error {
+	if errors.Is(err, repository.ErrTaskNotFound) {
+		return ErrTaskNotFound
+	}
+	if errors.Is(err, repository.ErrVersionMismatch) {
+		return ErrVersionMismatch
+	}
+	if errors.Is(err, repository.ErrTaskExists) {
+		return ErrTaskExists
+	}
+	if errors.Is(err, repository.ErrUserNotFound) {
+		return ErrUserNotFound
+	}
+	return err
+}
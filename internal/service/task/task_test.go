@@ -0,0 +1,44 @@
+package task
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestClientTime(t *testing.T) {
+	moscow := time.FixedZone("MSK", 3*60*60)
+	at := time.Now().Add(-time.Hour).In(moscow)
+
+	got, err := clientTime(at)
+	if err != nil {
+		t.Fatalf("clientTime() error = %v", err)
+	}
+	if !got.Equal(at) {
+		t.Errorf("clientTime() = %v, want the instant %v", got, at)
+	}
+	if got.Location() != time.UTC {
+		t.Errorf("clientTime() location = %v, want UTC", got.Location())
+	}
+}
+
+func TestClientTimeFuture(t *testing.T) {
+	// Ahead of the server clock even though its wall clock reads earlier
+	newYork := time.FixedZone("EST", -5*60*60)
+	at := time.Now().Add(time.Hour).In(newYork)
+
+	_, err := clientTime(at)
+	if !errors.Is(err, ErrFutureTime) {
+		t.Errorf("clientTime() error = %v, want %v", err, ErrFutureTime)
+	}
+}
+
+func TestClientTimeZero(t *testing.T) {
+	got, err := clientTime(time.Time{})
+	if err != nil {
+		t.Fatalf("clientTime() error = %v", err)
+	}
+	if got.IsZero() || got.Location() != time.UTC {
+		t.Errorf("clientTime() = %v, want now in UTC", got)
+	}
+}
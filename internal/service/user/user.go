@@ -5,53 +5,107 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
-
-	"time-tracker/internal/lib/logger/sl"
-	"time-tracker/internal/models"
-	"time-tracker/internal/repository"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/Alhanaqtah/effective-mobile-test-task/internal/lib/logger/sl"
+	"github.com/Alhanaqtah/effective-mobile-test-task/internal/lib/validation"
+	"github.com/Alhanaqtah/effective-mobile-test-task/internal/models"
+	"github.com/Alhanaqtah/effective-mobile-test-task/internal/repository"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel"
 )
 
+var tracer = otel.Tracer("github.com/Alhanaqtah/effective-mobile-test-task/internal/service/user")
+
 var (
 	ErrUserNotFound = errors.New("user not found")
 	ErrExists       = errors.New("user already exists")
 	ErrEmptyBody    = errors.New("request body is empty")
+
+	ErrInvalidPassport = errors.New("invalid passport format")
+	ErrInvalidUUID     = errors.New("invalid uuid format")
+
+	ErrVersionMismatch = errors.New("user version mismatch")
 )
 
 type Storage interface {
-	RemoveUser(ctx context.Context, uuid string) error
-	UpdateUser(ctx context.Context, fields []string, values []string) (*models.User, error)
+	RemoveUser(ctx context.Context, uuid string, version int) error
+	UpdateUser(ctx context.Context, uuid string, update models.UserUpdate, version int) (*models.User, error)
+	GetUser(ctx context.Context, uuid string) (*models.User, error)
 	GetUsers(ctx context.Context, limit, offset int, filter string) ([]models.User, error)
 	CreateUser(ctx context.Context, user *models.User) (*models.User, error)
 	FindUser(ctx context.Context, passportSerie, passportNumber int) (*models.User, error)
+	SaveEvent(ctx context.Context, event models.Event) error
+	InTx(ctx context.Context, fn func(ctx context.Context) error) error
 }
 
 type ExternalAPI interface {
-	GetUserInfo(passportSerie, passportNumber int) (*models.User, error)
+	GetUserInfo(ctx context.Context, passportSerie, passportNumber int) (*models.User, error)
+}
+
+type Publisher interface {
+	// Publish sends event once the transaction in ctx commits.
+	Publish(ctx context.Context, event models.Event) error
 }
 
 type Service struct {
 	storage     Storage
 	externalAPI ExternalAPI
+	events      Publisher
 	log         *slog.Logger
 }
 
-func New(storage Storage, externalAPI ExternalAPI, log *slog.Logger) *Service {
+func New(storage Storage, externalAPI ExternalAPI, events Publisher, log *slog.Logger) *Service {
 	return &Service{
 		storage:     storage,
 		externalAPI: externalAPI,
+		events:      events,
 		log:         log,
 	}
 }
 
+// ParsePassport splits a passport given as "<serie> <number>", e.g.
+// "1234 567890", into its serie and number.
+func ParsePassport(passport string) (int, int, error) {
+	parts := strings.Fields(passport)
+	if len(parts) != 2 {
+		return 0, 0, ErrInvalidPassport
+	}
+
+	passportSerie, err := strconv.Atoi(parts[0])
+	if err != nil || passportSerie < 1 || passportSerie > maxPassportSerie {
+		return 0, 0, ErrInvalidPassport
+	}
+
+	passportNumber, err := strconv.Atoi(parts[1])
+	if err != nil || passportNumber < 1 || passportNumber > maxPassportNumber {
+		return 0, 0, ErrInvalidPassport
+	}
+
+	return passportSerie, passportNumber, nil
+}
+
 func (s *Service) CreateUser(ctx context.Context, passportSerie, passportNumber int) (*models.User, error) {
 	const op = "service.user.CreateUser"
 
-	log := s.log.With(slog.String("op", op))
+	ctx, span := tracer.Start(ctx, op)
+	defer span.End()
+
+	log := s.log.With(slog.String("op", op), sl.Trace(ctx))
 
 	log.Debug("checking if user already exists")
 
 	_, err := s.storage.FindUser(ctx, passportSerie, passportNumber)
-	if err != nil && !errors.Is(err, repository.ErrUserNotFound) {
+	if err == nil {
+		// Don't pay for an external API call that would end up in a conflict.
+		log.Debug("user already exists")
+		return nil, ErrExists
+	}
+	if !errors.Is(err, repository.ErrUserNotFound) {
 		log.Error("failed to find user in storage", sl.Error(err))
 		return nil, err
 	}
@@ -59,7 +113,7 @@ func (s *Service) CreateUser(ctx context.Context, passportSerie, passportNumber
 	log.Debug("checking finished")
 	log.Debug("starting to create new user")
 
-	u, err := s.externalAPI.GetUserInfo(passportSerie, passportNumber)
+	u, err := s.externalAPI.GetUserInfo(ctx, passportSerie, passportNumber)
 	if err != nil {
 		log.Error("failed to get user info from external api", sl.Error(err))
 		return nil, err
@@ -68,7 +122,9 @@ func (s *Service) CreateUser(ctx context.Context, passportSerie, passportNumber
 	u.PassportSerie = passportSerie
 	u.PassportNumber = passportNumber
 
-	user, err := s.storage.CreateUser(ctx, u)
+	user, err := s.mutate(ctx, models.EventUserCreated, func(ctx context.Context) (*models.User, error) {
+		return s.storage.CreateUser(ctx, u)
+	})
 	if err != nil {
 		log.Error("failed to save user in storage", sl.Error(err))
 		if errors.Is(err, repository.ErrExists) {
@@ -83,7 +139,10 @@ func (s *Service) CreateUser(ctx context.Context, passportSerie, passportNumber
 func (s *Service) GetUsers(ctx context.Context, page int, filter string) ([]models.User, error) {
 	const op = "service.user.GetUsers"
 
-	log := s.log.With(slog.String("op", op))
+	ctx, span := tracer.Start(ctx, op)
+	defer span.End()
+
+	log := s.log.With(slog.String("op", op), sl.Trace(ctx))
 
 	const limit = 10
 	offset := (page - 1) * limit
@@ -97,71 +156,169 @@ func (s *Service) GetUsers(ctx context.Context, page int, filter string) ([]mode
 	return users, nil
 }
 
-func (s *Service) UpdateUserInfo(ctx context.Context, userInfo *models.User) (*models.User, error) {
-	const op = "service.user.UpdateUserInfo"
-
-	log := s.log.With(slog.String("op", op))
+func (s *Service) GetUser(ctx context.Context, userUUID string) (*models.User, error) {
+	const op = "service.user.GetUser"
 
-	var fields []string
-	var values []string
-	order := 1
+	ctx, span := tracer.Start(ctx, op)
+	defer span.End()
 
-	log.Debug("request body", slog.Any("body", userInfo))
+	log := s.log.With(slog.String("op", op), sl.Trace(ctx))
 
-	if userInfo.Name != "" {
-		fields = append(fields, fmt.Sprintf("name = $%d", order))
-		values = append(values, userInfo.Name)
-		order++
-	}
-	if userInfo.Surname != "" {
-		fields = append(fields, fmt.Sprintf("surname = $%d", order))
-		values = append(values, userInfo.Surname)
-		order++
+	if _, err := uuid.Parse(userUUID); err != nil {
+		log.Debug("invalid userUUID", sl.Error(err))
+		return nil, fmt.Errorf("%s: %w", op, validation.For("id", ErrInvalidUUID))
 	}
-	if userInfo.Patronymic != "" {
-		fields = append(fields, fmt.Sprintf("patronymic = $%d", order))
-		values = append(values, userInfo.Patronymic)
-		order++
+
+	user, err := s.storage.GetUser(ctx, userUUID)
+	if err != nil {
+		log.Error("failed to get user", sl.Error(err))
+		if errors.Is(err, repository.ErrUserNotFound) {
+			return nil, ErrUserNotFound
+		}
+		return nil, err
 	}
-	if userInfo.Address != "" {
-		fields = append(fields, fmt.Sprintf("address = $%d", order))
-		values = append(values, userInfo.Address)
+
+	return user, nil
+}
+
+// UpdateUserInfo applies update to the user if its current version equals
+// version; a zero version skips the check.
+func (s *Service) UpdateUserInfo(ctx context.Context, userUUID string, update models.UserUpdate, version int) (*models.User, error) {
+	const op = "service.user.UpdateUserInfo"
+
+	ctx, span := tracer.Start(ctx, op)
+	defer span.End()
+
+	log := s.log.With(slog.String("op", op), sl.Trace(ctx))
+
+	if _, err := uuid.Parse(userUUID); err != nil {
+		log.Debug("invalid userUUID", sl.Error(err))
+		return nil, fmt.Errorf("%s: %w", op, validation.For("id", ErrInvalidUUID))
 	}
 
-	if len(values) == 0 {
+	log.Debug("user update", slog.String("uuid", userUUID), slog.Any("update", update))
+
+	if update.IsEmpty() {
 		log.Debug("request body is empty")
 		return nil, ErrEmptyBody
 	}
 
-	values = append(values, userInfo.ID)
-
-	log.Debug("new user info", slog.Any("fields", fields), slog.Any("values", values))
+	if err := validateUpdate(update); err != nil {
+		log.Debug("invalid user update", sl.Error(err))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
 
-	user, err := s.storage.UpdateUser(ctx, fields, values)
+	user, err := s.mutate(ctx, models.EventUserUpdated, func(ctx context.Context) (*models.User, error) {
+		return s.storage.UpdateUser(ctx, userUUID, update, version)
+	})
 	if err != nil {
 		log.Error("failed to update user info", sl.Error(err))
 		if errors.Is(err, repository.ErrUserNotFound) {
 			return nil, ErrUserNotFound
 		}
+		if errors.Is(err, repository.ErrVersionMismatch) {
+			return nil, ErrVersionMismatch
+		}
+		if errors.Is(err, repository.ErrExists) {
+			return nil, ErrExists
+		}
 		return nil, err
 	}
 
 	return user, nil
 }
 
-func (s *Service) RemoveUserByUUID(ctx context.Context, uuid string) error {
+const (
+	maxNameLength     = 50
+	maxPassportSerie  = 9999
+	maxPassportNumber = 999999
+)
+
+func validateUpdate(update models.UserUpdate) error {
+	var errs validation.Errors
+
+	name := func(field string, value models.Optional[string]) {
+		switch {
+		case !value.Set:
+		case value.Null || strings.TrimSpace(value.Value) == "":
+			errs = append(errs, validation.For(field, validation.ErrRequired))
+		case utf8.RuneCountInString(value.Value) > maxNameLength:
+			errs = append(errs, validation.For(field, validation.ErrTooLong))
+		}
+	}
+
+	passport := func(field string, value models.Optional[int], max int) {
+		switch {
+		case !value.Set:
+		case value.Null:
+			errs = append(errs, validation.For(field, validation.ErrRequired))
+		case value.Value < 1 || value.Value > max:
+			errs = append(errs, validation.For(field, validation.ErrInvalid))
+		}
+	}
+
+	name("name", update.Name)
+	name("surname", update.Surname)
+	name("patronymic", update.Patronymic)
+	passport("passport_serie", update.PassportSerie, maxPassportSerie)
+	passport("passport_number", update.PassportNumber, maxPassportNumber)
+
+	return errs.Err()
+}
+
+// RemoveUserByUUID removes the user if its current version equals version;
+// a zero version skips the check.
+func (s *Service) RemoveUserByUUID(ctx context.Context, userUUID string, version int) error {
 	const op = "service.user.RemoveUserByUUID"
 
-	log := s.log.With(slog.String("op", op))
+	ctx, span := tracer.Start(ctx, op)
+	defer span.End()
+
+	log := s.log.With(slog.String("op", op), sl.Trace(ctx))
+
+	if _, err := uuid.Parse(userUUID); err != nil {
+		log.Debug("invalid userUUID", sl.Error(err))
+		return fmt.Errorf("%s: %w", op, validation.For("id", ErrInvalidUUID))
+	}
 
-	err := s.storage.RemoveUser(ctx, uuid)
+	_, err := s.mutate(ctx, models.EventUserDeleted, func(ctx context.Context) (*models.User, error) {
+		return &models.User{ID: userUUID}, s.storage.RemoveUser(ctx, userUUID, version)
+	})
 	if err != nil {
 		log.Error("failed to remove user by uuid", sl.Error(err))
 		if errors.Is(err, repository.ErrUserNotFound) {
 			return ErrUserNotFound
 		}
+		if errors.Is(err, repository.ErrVersionMismatch) {
+			return ErrVersionMismatch
+		}
 		return err
 	}
 
 	return nil
 }
+
+// mutate runs fn, records its event in the outbox and announces it to live
+// subscribers in one transaction.
+func (s *Service) mutate(ctx context.Context, eventType string, fn func(ctx context.Context) (*models.User, error)) (*models.User, error) {
+	var user *models.User
+
+	err := s.storage.InTx(ctx, func(ctx context.Context) error {
+		var err error
+		user, err = fn(ctx)
+		if err != nil {
+			return err
+		}
+
+		event := models.Event{Type: eventType, UserID: user.ID, Time: time.Now(), Data: user}
+		if err := s.storage.SaveEvent(ctx, event); err != nil {
+			return err
+		}
+		return s.events.Publish(ctx, event)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
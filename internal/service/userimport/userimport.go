@@ -0,0 +1,483 @@
+package userimport
+
+import (
+	"bufio"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+
+	"github.com/Alhanaqtah/effective-mobile-test-task/internal/lib/logger/sl"
+	"github.com/Alhanaqtah/effective-mobile-test-task/internal/lib/request"
+	"github.com/Alhanaqtah/effective-mobile-test-task/internal/models"
+	"github.com/Alhanaqtah/effective-mobile-test-task/internal/repository"
+	"github.com/Alhanaqtah/effective-mobile-test-task/internal/repository/externalapi"
+	userService "github.com/Alhanaqtah/effective-mobile-test-task/internal/service/user"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel"
+)
+
+var tracer = otel.Tracer("github.com/Alhanaqtah/effective-mobile-test-task/internal/service/userimport")
+
+// Supported input formats.
+const (
+	FormatCSV    = "csv"
+	FormatNDJSON = "ndjson"
+)
+
+// MaxRows limits the number of passports accepted in one import.
+const MaxRows = 10000
+
+const (
+	// jobLease is how long a job stays claimed by the instance running it
+	// without a heartbeat, after which another instance resumes it.
+	jobLease  = time.Minute
+	heartbeat = jobLease / 4
+)
+
+var (
+	ErrJobNotFound       = errors.New("import job not found")
+	ErrEmptyImport       = errors.New("import contains no rows")
+	ErrTooManyRows       = errors.New("import contains too many rows")
+	ErrMalformedInput    = errors.New("import input is malformed")
+	ErrUnsupportedFormat = errors.New("unsupported import format")
+	ErrInvalidJobID      = errors.New("invalid import job id")
+)
+
+type Storage interface {
+	CreateImportJob(ctx context.Context, rows []models.ImportRow, owner string, lease time.Duration) (*models.ImportJob, error)
+	StartImportJob(ctx context.Context, jobID string) error
+	FinishImportJob(ctx context.Context, jobID string) error
+	ClaimImportJobs(ctx context.Context, owner string, lease time.Duration) ([]string, error)
+	RenewImportJob(ctx context.Context, jobID, owner string, lease time.Duration) (bool, error)
+	ReleaseImportJob(ctx context.Context, jobID, owner string) error
+	PendingImportRows(ctx context.Context, jobID string) ([]models.ImportRow, error)
+	SetImportRowResult(ctx context.Context, jobID string, row models.ImportRow) error
+	GetImportJob(ctx context.Context, jobID, rowStatus string, limit, offset int) (*models.ImportJob, error)
+}
+
+type UserCreator interface {
+	CreateUser(ctx context.Context, passportSerie, passportNumber int) (*models.User, error)
+}
+
+// Service imports users in background jobs. Every job processes its rows
+// with at most concurrency parallel calls to the people info API. A job is
+// run by the instance holding its lease, so instances never process the
+// same rows.
+type Service struct {
+	storage     Storage
+	users       UserCreator
+	concurrency int
+	log         *slog.Logger
+
+	// owner identifies the instance in the leases of its jobs
+	owner string
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+func New(storage Storage, users UserCreator, concurrency int, log *slog.Logger) *Service {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	return &Service{
+		storage:     storage,
+		users:       users,
+		concurrency: max(concurrency, 1),
+		log:         log,
+		owner:       uuid.NewString(),
+		ctx:         ctx,
+		cancel:      cancel,
+	}
+}
+
+// Import reads passports from r, stores them as a new job and starts
+// processing it in the background. Rows that cannot be parsed are recorded
+// as invalid right away.
+func (s *Service) Import(ctx context.Context, format string, r io.Reader) (*models.ImportJob, error) {
+	const op = "service.userimport.Import"
+
+	ctx, span := tracer.Start(ctx, op)
+	defer span.End()
+
+	log := s.log.With(slog.String("op", op), sl.Trace(ctx))
+
+	var rows []models.ImportRow
+	var err error
+
+	switch format {
+	case FormatCSV:
+		rows, err = parseCSV(r)
+	case FormatNDJSON:
+		rows, err = parseNDJSON(r)
+	default:
+		err = ErrUnsupportedFormat
+	}
+	if err != nil {
+		log.Error("failed to parse import input", sl.Error(err))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if len(rows) == 0 {
+		log.Debug("import contains no rows")
+		return nil, fmt.Errorf("%s: %w", op, ErrEmptyImport)
+	}
+
+	job, err := s.storage.CreateImportJob(ctx, rows, s.owner, jobLease)
+	if err != nil {
+		log.Error("failed to create import job", sl.Error(err))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	log.Info("import job created", slog.String("job_id", job.ID), slog.Int("rows", job.Total))
+
+	s.start(job.ID)
+
+	return job, nil
+}
+
+func (s *Service) GetJob(ctx context.Context, jobID, rowStatus string, page int) (*models.ImportJob, error) {
+	const op = "service.userimport.GetJob"
+
+	ctx, span := tracer.Start(ctx, op)
+	defer span.End()
+
+	log := s.log.With(slog.String("op", op), sl.Trace(ctx))
+
+	if _, err := uuid.Parse(jobID); err != nil {
+		log.Error("invalid job id", sl.Error(err))
+		return nil, fmt.Errorf("%s: %w", op, ErrInvalidJobID)
+	}
+
+	const limit = 100
+	offset := (page - 1) * limit
+
+	job, err := s.storage.GetImportJob(ctx, jobID, rowStatus, limit, offset)
+	if err != nil {
+		log.Error("failed to get import job", sl.Error(err))
+		if errors.Is(err, repository.ErrImportJobNotFound) {
+			return nil, ErrJobNotFound
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return job, nil
+}
+
+// Resume restarts the unfinished jobs whose lease expired, left by an
+// instance that stopped or crashed, now and then every interval until the
+// service shuts down.
+func (s *Service) Resume(interval time.Duration) {
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			s.resume(s.ctx)
+
+			select {
+			case <-s.ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+func (s *Service) resume(ctx context.Context) {
+	const op = "service.userimport.Resume"
+
+	ctx, span := tracer.Start(ctx, op)
+	defer span.End()
+
+	log := s.log.With(slog.String("op", op), sl.Trace(ctx))
+
+	ids, err := s.storage.ClaimImportJobs(ctx, s.owner, jobLease)
+	if err != nil {
+		log.Error("failed to claim unfinished import jobs", sl.Error(err))
+		return
+	}
+
+	for _, id := range ids {
+		log.Info("resuming import job", slog.String("job_id", id))
+		s.start(id)
+	}
+}
+
+// Shutdown stops running jobs and waits for in-flight rows. Rows that were
+// not processed stay pending and their jobs are released, so Resume of
+// another instance picks them up.
+func (s *Service) Shutdown() {
+	s.cancel()
+	s.wg.Wait()
+}
+
+func (s *Service) start(jobID string) {
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		s.run(s.ctx, jobID)
+	}()
+}
+
+func (s *Service) run(ctx context.Context, jobID string) {
+	const op = "service.userimport.run"
+
+	ctx, span := tracer.Start(ctx, op)
+	defer span.End()
+
+	log := s.log.With(slog.String("op", op), sl.Trace(ctx), slog.String("job_id", jobID))
+
+	// The job stops if its lease is lost, as another instance may run it
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	renewed := make(chan struct{})
+	go func() {
+		defer close(renewed)
+		s.renew(ctx, cancel, log, jobID)
+	}()
+	defer func() {
+		cancel()
+		<-renewed
+	}()
+
+	if err := s.storage.StartImportJob(ctx, jobID); err != nil {
+		log.Error("failed to start import job", sl.Error(err))
+		return
+	}
+
+	rows, err := s.storage.PendingImportRows(ctx, jobID)
+	if err != nil {
+		log.Error("failed to get pending import rows", sl.Error(err))
+		return
+	}
+
+	sem := make(chan struct{}, s.concurrency)
+	var wg sync.WaitGroup
+
+	for _, row := range rows {
+		select {
+		case <-ctx.Done():
+		case sem <- struct{}{}:
+		}
+		if ctx.Err() != nil {
+			break
+		}
+
+		wg.Add(1)
+		go func(row models.ImportRow) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			row = s.process(ctx, row)
+			if row.Status == models.ImportRowPending {
+				return
+			}
+
+			if err := s.storage.SetImportRowResult(context.WithoutCancel(ctx), jobID, row); err != nil {
+				log.Error("failed to save import row result", slog.Int("line", row.Line), sl.Error(err))
+			}
+		}(row)
+	}
+
+	wg.Wait()
+
+	if ctx.Err() != nil {
+		log.Info("import job interrupted")
+		if err := s.storage.ReleaseImportJob(context.WithoutCancel(ctx), jobID, s.owner); err != nil {
+			log.Error("failed to release import job", sl.Error(err))
+		}
+		return
+	}
+
+	if err := s.storage.FinishImportJob(ctx, jobID); err != nil {
+		log.Error("failed to finish import job", sl.Error(err))
+		return
+	}
+
+	log.Info("import job finished")
+}
+
+// renew extends the lease of the job every heartbeat until ctx is done. It
+// cancels the job once the lease is lost or could not be renewed before it
+// expired.
+func (s *Service) renew(ctx context.Context, cancel context.CancelFunc, log *slog.Logger, jobID string) {
+	ticker := time.NewTicker(heartbeat)
+	defer ticker.Stop()
+
+	expires := time.Now().Add(jobLease)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		now := time.Now()
+		owned, err := s.storage.RenewImportJob(ctx, jobID, s.owner, jobLease)
+		switch {
+		case err != nil:
+			log.Error("failed to renew import job lease", sl.Error(err))
+			if time.Until(expires) < heartbeat {
+				log.Warn("import job lease is about to expire, stopping")
+				cancel()
+				return
+			}
+		case !owned:
+			log.Warn("import job lease lost, stopping")
+			cancel()
+			return
+		default:
+			expires = now.Add(jobLease)
+		}
+	}
+}
+
+// process creates the user for one row and records the outcome in it.
+func (s *Service) process(ctx context.Context, row models.ImportRow) models.ImportRow {
+	passportSerie, passportNumber, err := userService.ParsePassport(row.Passport)
+	if err != nil {
+		row.Status = models.ImportRowInvalid
+		row.Error = err.Error()
+		return row
+	}
+
+	user, err := s.users.CreateUser(ctx, passportSerie, passportNumber)
+	switch {
+	case err == nil:
+		row.Status = models.ImportRowCreated
+		row.UserID = user.ID
+	case ctx.Err() != nil:
+		// Interrupted by shutdown, leave the row for the next run.
+	case errors.Is(err, userService.ErrExists):
+		row.Status = models.ImportRowExists
+	case errors.Is(err, externalapi.ErrBadRequest):
+		row.Status = models.ImportRowEnrichmentFailed
+		row.Error = externalapi.ErrBadRequest.Error()
+	case errors.Is(err, externalapi.ErrExternalAPIError):
+		row.Status = models.ImportRowEnrichmentFailed
+		row.Error = externalapi.ErrExternalAPIError.Error()
+	default:
+		row.Status = models.ImportRowFailed
+		row.Error = "internal error"
+	}
+
+	return row
+}
+
+// parseCSV reads one passport per record, either as a single "<serie> <number>"
+// column or as separate serie and number columns. A leading header record is
+// skipped.
+func parseCSV(r io.Reader) ([]models.ImportRow, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	var rows []models.ImportRow
+
+	for first := true; ; first = false {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrMalformedInput, err)
+		}
+
+		if first && isHeader(record) {
+			continue
+		}
+
+		line, _ := reader.FieldPos(0)
+
+		row := models.ImportRow{
+			Line:     line,
+			Passport: strings.Join(record, " "),
+			Status:   models.ImportRowPending,
+		}
+		if len(record) > 2 {
+			row.Status = models.ImportRowInvalid
+			row.Error = "too many columns"
+		}
+
+		rows = append(rows, row)
+		if len(rows) > MaxRows {
+			return nil, ErrTooManyRows
+		}
+	}
+
+	return rows, nil
+}
+
+// headerColumns are the column names a header record may use.
+var headerColumns = map[string]bool{
+	"passport":        true,
+	"passport_number": true,
+	"passportnumber":  true,
+	"serie":           true,
+	"series":          true,
+	"passport_serie":  true,
+	"number":          true,
+}
+
+// isHeader reports whether record names the columns: all of its fields are
+// known column names, or none of them has a digit. Other records with
+// letters are mistyped passports, reported as invalid rows.
+func isHeader(record []string) bool {
+	known, digits := true, false
+	for _, field := range record {
+		field = strings.ToLower(strings.TrimSpace(field))
+		known = known && headerColumns[field]
+		digits = digits || strings.ContainsFunc(field, unicode.IsDigit)
+	}
+	return known || !digits
+}
+
+// parseNDJSON reads one request.CreateUser object per line.
+func parseNDJSON(r io.Reader) ([]models.ImportRow, error) {
+	scanner := bufio.NewScanner(r)
+
+	var rows []models.ImportRow
+
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" {
+			continue
+		}
+
+		row := models.ImportRow{Line: line, Status: models.ImportRowPending}
+
+		var req request.CreateUser
+		if err := json.Unmarshal([]byte(text), &req); err != nil {
+			row.Passport = text
+			row.Status = models.ImportRowInvalid
+			row.Error = "malformed json"
+		} else {
+			row.Passport = req.PassportNumber
+		}
+
+		rows = append(rows, row)
+		if len(rows) > MaxRows {
+			return nil, ErrTooManyRows
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrMalformedInput, err)
+	}
+
+	return rows, nil
+}
@@ -0,0 +1,154 @@
+package userimport
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/Alhanaqtah/effective-mobile-test-task/internal/models"
+)
+
+func TestParseCSV(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  []models.ImportRow
+	}{
+		{
+			name:  "single column without header",
+			input: "1234 567890\n4321 098765\n",
+			want: []models.ImportRow{
+				{Line: 1, Passport: "1234 567890", Status: models.ImportRowPending},
+				{Line: 2, Passport: "4321 098765", Status: models.ImportRowPending},
+			},
+		},
+		{
+			name:  "single column with header",
+			input: "passport\n1234 567890\n",
+			want: []models.ImportRow{
+				{Line: 2, Passport: "1234 567890", Status: models.ImportRowPending},
+			},
+		},
+		{
+			name:  "two columns without header",
+			input: "1234,567890\n",
+			want: []models.ImportRow{
+				{Line: 1, Passport: "1234 567890", Status: models.ImportRowPending},
+			},
+		},
+		{
+			name:  "two columns with header",
+			input: "serie,number\n1234, 567890\n",
+			want: []models.ImportRow{
+				{Line: 2, Passport: "1234 567890", Status: models.ImportRowPending},
+			},
+		},
+		{
+			name:  "mistyped passport first",
+			input: "1234-567890\n4321 098765\n",
+			want: []models.ImportRow{
+				{Line: 1, Passport: "1234-567890", Status: models.ImportRowPending},
+				{Line: 2, Passport: "4321 098765", Status: models.ImportRowPending},
+			},
+		},
+		{
+			name:  "known columns with header",
+			input: "Passport_Serie, Passport_Number\n1234,567890\n",
+			want: []models.ImportRow{
+				{Line: 2, Passport: "1234 567890", Status: models.ImportRowPending},
+			},
+		},
+		{
+			name:  "too many columns",
+			input: "1234,567890,1\n",
+			want: []models.ImportRow{
+				{Line: 1, Passport: "1234 567890 1", Status: models.ImportRowInvalid, Error: "too many columns"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseCSV(strings.NewReader(tt.input))
+			if err != nil {
+				t.Fatalf("parseCSV() error = %v", err)
+			}
+			assertRows(t, got, tt.want)
+		})
+	}
+}
+
+func TestParseNDJSON(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  []models.ImportRow
+	}{
+		{
+			name:  "without header",
+			input: "{\"passportNumber\":\"1234 567890\"}\n\n{\"passportNumber\":\"4321 098765\"}\n",
+			want: []models.ImportRow{
+				{Line: 1, Passport: "1234 567890", Status: models.ImportRowPending},
+				{Line: 3, Passport: "4321 098765", Status: models.ImportRowPending},
+			},
+		},
+		{
+			name:  "with header",
+			input: "passportNumber\n{\"passportNumber\":\"1234 567890\"}\n",
+			want: []models.ImportRow{
+				{Line: 1, Passport: "passportNumber", Status: models.ImportRowInvalid, Error: "malformed json"},
+				{Line: 2, Passport: "1234 567890", Status: models.ImportRowPending},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseNDJSON(strings.NewReader(tt.input))
+			if err != nil {
+				t.Fatalf("parseNDJSON() error = %v", err)
+			}
+			assertRows(t, got, tt.want)
+		})
+	}
+}
+
+func TestParseTooManyRows(t *testing.T) {
+	_, err := parseCSV(strings.NewReader(strings.Repeat("1234 567890\n", MaxRows+1)))
+	if !errors.Is(err, ErrTooManyRows) {
+		t.Errorf("parseCSV() error = %v, want %v", err, ErrTooManyRows)
+	}
+}
+
+// TestParseTooLarge checks that an input cut off by http.MaxBytesReader is
+// reported as such, so the handler can answer 413.
+func TestParseTooLarge(t *testing.T) {
+	for name, parse := range map[string]func(io.Reader) ([]models.ImportRow, error){
+		FormatCSV:    parseCSV,
+		FormatNDJSON: parseNDJSON,
+	} {
+		body := io.NopCloser(strings.NewReader(strings.Repeat("1234 567890\n", 100)))
+		_, err := parse(http.MaxBytesReader(httptest.NewRecorder(), body, 64))
+
+		var tooLarge *http.MaxBytesError
+		if !errors.As(err, &tooLarge) {
+			t.Errorf("%s: error = %v, want a *http.MaxBytesError", name, err)
+		}
+	}
+}
+
+func assertRows(t *testing.T, got, want []models.ImportRow) {
+	t.Helper()
+
+	if len(got) != len(want) {
+		t.Fatalf("got %d rows %+v, want %d %+v", len(got), got, len(want), want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("row %d = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+}
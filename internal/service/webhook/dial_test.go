@@ -0,0 +1,87 @@
+package webhook
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"testing"
+	"time"
+)
+
+func TestIsPublic(t *testing.T) {
+	tests := []struct {
+		addr string
+		want bool
+	}{
+		{"93.184.216.34", true},
+		{"2606:2800:220:1::1", true},
+		{"127.0.0.1", false},
+		{"::1", false},
+		{"10.1.2.3", false},
+		{"172.16.0.1", false},
+		{"192.168.1.1", false},
+		{"169.254.169.254", false},
+		{"fe80::1", false},
+		{"fd00::1", false},
+		{"0.0.0.0", false},
+		{"::", false},
+		{"224.0.0.1", false},
+		{"100.100.100.200", false},
+		{"::ffff:127.0.0.1", false},
+	}
+
+	for _, tt := range tests {
+		if got := isPublic(netip.MustParseAddr(tt.addr)); got != tt.want {
+			t.Errorf("isPublic(%s) = %v, want %v", tt.addr, got, tt.want)
+		}
+	}
+}
+
+func TestIsInternalHost(t *testing.T) {
+	tests := []struct {
+		host string
+		want bool
+	}{
+		{"example.com", false},
+		{"93.184.216.34", false},
+		{"localhost", true},
+		{"LOCALHOST.", true},
+		{"api.localhost", true},
+		{"127.0.0.1", true},
+		{"::1", true},
+		{"169.254.169.254", true},
+	}
+
+	for _, tt := range tests {
+		if got := isInternalHost(tt.host); got != tt.want {
+			t.Errorf("isInternalHost(%q) = %v, want %v", tt.host, got, tt.want)
+		}
+	}
+}
+
+func TestClientRefusesInternalAddresses(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer srv.Close()
+
+	_, err := newClient().Get(srv.URL)
+	if !errors.Is(err, errInternalAddress) {
+		t.Fatalf("got error %v, want %v", err, errInternalAddress)
+	}
+}
+
+func TestClientDoesNotFollowRedirects(t *testing.T) {
+	client := newClient()
+
+	req := httptest.NewRequest(http.MethodPost, "https://example.com/hook", nil)
+	if err := client.CheckRedirect(req, []*http.Request{req}); !errors.Is(err, http.ErrUseLastResponse) {
+		t.Fatalf("got %v, want %v", err, http.ErrUseLastResponse)
+	}
+}
+
+func TestLeaseOutlastsBatch(t *testing.T) {
+	rounds := (batchSize + workers - 1) / workers
+	if worst := time.Duration(rounds) * requestTimeout; lease <= worst {
+		t.Fatalf("lease %v does not outlast a batch timing out in %v", lease, worst)
+	}
+}
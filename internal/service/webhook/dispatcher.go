@@ -0,0 +1,228 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"log/slog"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/Alhanaqtah/effective-mobile-test-task/internal/lib/logger/sl"
+	"github.com/Alhanaqtah/effective-mobile-test-task/internal/models"
+)
+
+// Headers sent with every delivery. The signature is the hex encoded
+// HMAC-SHA256 of "<timestamp>.<body>" keyed with the webhook secret.
+const (
+	HeaderDelivery  = "X-Webhook-Delivery"
+	HeaderEvent     = "X-Webhook-Event"
+	HeaderTimestamp = "X-Webhook-Timestamp"
+	HeaderSignature = "X-Webhook-Signature"
+)
+
+const (
+	pollInterval  = time.Second
+	purgeInterval = time.Hour
+	batchSize     = 100
+	workers       = 8
+
+	requestTimeout = 10 * time.Second
+	// lease hides claimed deliveries from other instances while they are
+	// sent. It outlasts a batch whose every delivery times out, plus the
+	// time to record the results.
+	lease = (batchSize+workers-1)/workers*requestTimeout + 30*time.Second
+
+	maxAttempts = 10
+	baseBackoff = 10 * time.Second
+	maxBackoff  = 6 * time.Hour
+
+	// outboxRetention is how long dispatched events are kept in the outbox.
+	outboxRetention = 7 * 24 * time.Hour
+)
+
+type DispatcherStorage interface {
+	DispatchOutbox(ctx context.Context, limit int) (int, error)
+	PurgeOutbox(ctx context.Context, olderThan time.Time) (int64, error)
+	ClaimDeliveries(ctx context.Context, limit int, lease time.Duration) ([]models.WebhookDelivery, error)
+	CompleteDelivery(ctx context.Context, deliveryID string, statusCode int) error
+	FailDelivery(ctx context.Context, deliveryID string, statusCode int, reason string, nextAttempt *time.Time) error
+}
+
+// Dispatcher moves events from the outbox to webhook deliveries and sends
+// them. Failed deliveries are retried with exponential backoff and end up
+// in the dead letter queue after maxAttempts.
+type Dispatcher struct {
+	storage DispatcherStorage
+	client  *http.Client
+	log     *slog.Logger
+}
+
+func NewDispatcher(storage DispatcherStorage, log *slog.Logger) *Dispatcher {
+	return &Dispatcher{
+		storage: storage,
+		client:  newClient(),
+		log:     log,
+	}
+}
+
+// Run dispatches events until ctx is done.
+func (d *Dispatcher) Run(ctx context.Context) {
+	const op = "service.webhook.Dispatcher.Run"
+
+	log := d.log.With(slog.String("op", op))
+
+	poll := time.NewTicker(pollInterval)
+	defer poll.Stop()
+
+	purge := time.NewTicker(purgeInterval)
+	defer purge.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+
+		case <-poll.C:
+			if _, err := d.storage.DispatchOutbox(ctx, batchSize); err != nil {
+				log.Error("failed to dispatch outbox", sl.Error(err))
+			}
+
+			deliveries, err := d.storage.ClaimDeliveries(ctx, batchSize, lease)
+			if err != nil {
+				log.Error("failed to claim deliveries", sl.Error(err))
+				continue
+			}
+			d.sendAll(ctx, deliveries)
+
+		case <-purge.C:
+			n, err := d.storage.PurgeOutbox(ctx, time.Now().Add(-outboxRetention))
+			if err != nil {
+				log.Error("failed to purge outbox", sl.Error(err))
+				continue
+			}
+			log.Debug("outbox purged", slog.Int64("deleted", n))
+		}
+	}
+}
+
+func (d *Dispatcher) sendAll(ctx context.Context, deliveries []models.WebhookDelivery) {
+	sem := make(chan struct{}, workers)
+	var wg sync.WaitGroup
+
+	for _, delivery := range deliveries {
+		// Deliveries not sent before the dispatcher stops are left to
+		// their lease, so stopping does not count as a failed attempt.
+		select {
+		case <-ctx.Done():
+		case sem <- struct{}{}:
+		}
+		if ctx.Err() != nil {
+			break
+		}
+		wg.Add(1)
+
+		go func(delivery models.WebhookDelivery) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			d.send(ctx, delivery)
+		}(delivery)
+	}
+
+	wg.Wait()
+}
+
+func (d *Dispatcher) send(ctx context.Context, delivery models.WebhookDelivery) {
+	const op = "service.webhook.Dispatcher.send"
+
+	log := d.log.With(
+		slog.String("op", op),
+		slog.String("delivery_id", delivery.ID),
+		slog.String("webhook_id", delivery.WebhookID),
+	)
+
+	statusCode, err := d.post(ctx, delivery)
+	if err != nil && ctx.Err() != nil {
+		log.Debug("delivery interrupted, left to its lease", sl.Error(err))
+		return
+	}
+
+	// Results are recorded even if the dispatcher is stopping.
+	ctx = context.WithoutCancel(ctx)
+
+	if err == nil {
+		if err := d.storage.CompleteDelivery(ctx, delivery.ID, statusCode); err != nil {
+			log.Error("failed to complete delivery", sl.Error(err))
+		}
+		return
+	}
+
+	attempts := delivery.Attempts + 1
+
+	var next *time.Time
+	if attempts < maxAttempts {
+		at := time.Now().Add(Backoff(attempts))
+		next = &at
+	}
+
+	log.Warn("delivery failed", slog.Int("attempt", attempts), slog.Bool("dead", next == nil), sl.Error(err))
+
+	if err := d.storage.FailDelivery(ctx, delivery.ID, statusCode, err.Error(), next); err != nil {
+		log.Error("failed to record delivery failure", sl.Error(err))
+	}
+}
+
+func (d *Dispatcher) post(ctx context.Context, delivery models.WebhookDelivery) (int, error) {
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, delivery.URL, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		return 0, err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(HeaderDelivery, delivery.ID)
+	req.Header.Set(HeaderEvent, delivery.EventType)
+	req.Header.Set(HeaderTimestamp, timestamp)
+	req.Header.Set(HeaderSignature, "sha256="+Sign(delivery.Secret, timestamp, delivery.Payload))
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return resp.StatusCode, fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+
+	return resp.StatusCode, nil
+}
+
+// Sign returns the signature receivers compare X-Webhook-Signature with.
+func Sign(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// Backoff returns the delay before the next attempt after the given number
+// of failed attempts, with up to 20% jitter.
+func Backoff(attempts int) time.Duration {
+	delay := maxBackoff
+	if attempts < 32 {
+		delay = min(baseBackoff<<(attempts-1), maxBackoff)
+	}
+	return delay + rand.N(delay/5+1)
+}
@@ -0,0 +1,86 @@
+package webhook
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Alhanaqtah/effective-mobile-test-task/internal/models"
+)
+
+type recordingStorage struct {
+	DispatcherStorage
+
+	mu        sync.Mutex
+	completed []string
+	failed    []string
+}
+
+func (s *recordingStorage) CompleteDelivery(_ context.Context, deliveryID string, _ int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.completed = append(s.completed, deliveryID)
+	return nil
+}
+
+func (s *recordingStorage) FailDelivery(_ context.Context, deliveryID string, _ int, _ string, _ *time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.failed = append(s.failed, deliveryID)
+	return nil
+}
+
+// TestSendAllStopping checks that deliveries interrupted or not yet sent
+// when the dispatcher stops are neither completed nor charged an attempt.
+func TestSendAllStopping(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	var requests sync.WaitGroup
+	requests.Add(workers)
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Done()
+		<-r.Context().Done()
+	}))
+	defer receiver.Close()
+
+	storage := &recordingStorage{}
+	d := &Dispatcher{
+		storage: storage,
+		client:  receiver.Client(),
+		log:     slog.New(slog.NewTextHandler(io.Discard, nil)),
+	}
+
+	deliveries := make([]models.WebhookDelivery, 2*workers)
+	for i := range deliveries {
+		deliveries[i] = models.WebhookDelivery{
+			ID:       "delivery-" + string(rune('a'+i)),
+			URL:      receiver.URL,
+			Attempts: maxAttempts - 1,
+		}
+	}
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		d.sendAll(ctx, deliveries)
+	}()
+
+	// Stop while the first deliveries are in flight and the rest wait
+	requests.Wait()
+	cancel()
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("sendAll did not return after the context was cancelled")
+	}
+
+	if len(storage.completed) != 0 || len(storage.failed) != 0 {
+		t.Errorf("completed %v and failed %v, want none", storage.completed, storage.failed)
+	}
+}
@@ -0,0 +1,295 @@
+package webhook
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/netip"
+	"net/url"
+	"slices"
+	"strings"
+
+	"github.com/Alhanaqtah/effective-mobile-test-task/internal/lib/logger/sl"
+	"github.com/Alhanaqtah/effective-mobile-test-task/internal/lib/validation"
+	"github.com/Alhanaqtah/effective-mobile-test-task/internal/models"
+	"github.com/Alhanaqtah/effective-mobile-test-task/internal/repository"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel"
+)
+
+var tracer = otel.Tracer("github.com/Alhanaqtah/effective-mobile-test-task/internal/service/webhook")
+
+var (
+	ErrWebhookNotFound  = errors.New("webhook not found")
+	ErrDeliveryNotFound = errors.New("webhook delivery not found")
+	ErrInvalidURL       = errors.New("webhook url must be an absolute http or https url")
+	ErrInternalURL      = errors.New("webhook url must not point to an internal address")
+	ErrUnknownEvent     = errors.New("unknown event type")
+)
+
+// EventTypes lists the events webhooks can subscribe to.
+var EventTypes = []string{
+	models.EventTaskCreated,
+	models.EventTaskUpdated,
+	models.EventTaskStarted,
+	models.EventTaskFinished,
+	models.EventTaskDeleted,
+	models.EventUserCreated,
+	models.EventUserUpdated,
+	models.EventUserDeleted,
+}
+
+type Storage interface {
+	CreateWebhook(ctx context.Context, webhook *models.Webhook) (*models.Webhook, error)
+	GetWebhooks(ctx context.Context) ([]models.Webhook, error)
+	GetWebhook(ctx context.Context, webhookID string) (*models.Webhook, error)
+	SetWebhookActive(ctx context.Context, webhookID string, active bool) (*models.Webhook, error)
+	DeleteWebhook(ctx context.Context, webhookID string) error
+	GetDeliveries(ctx context.Context, webhookID, status string, limit, offset int) ([]models.WebhookDelivery, error)
+	ReplayDelivery(ctx context.Context, webhookID, deliveryID string) (*models.WebhookDelivery, error)
+}
+
+type Service struct {
+	storage Storage
+	log     *slog.Logger
+}
+
+func New(storage Storage, log *slog.Logger) *Service {
+	return &Service{
+		storage: storage,
+		log:     log,
+	}
+}
+
+// CreateWebhook subscribes rawURL to the given event types, all events if
+// none are given. An empty secret is generated; the secret is only returned
+// here, later reads omit it.
+func (s *Service) CreateWebhook(ctx context.Context, rawURL, secret string, eventTypes []string) (*models.Webhook, error) {
+	const op = "service.webhook.CreateWebhook"
+
+	ctx, span := tracer.Start(ctx, op)
+	defer span.End()
+
+	log := s.log.With(slog.String("op", op), sl.Trace(ctx))
+
+	var errs validation.Errors
+
+	u, err := url.Parse(rawURL)
+	switch {
+	case err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "":
+		errs = append(errs, validation.For("url", ErrInvalidURL))
+	case isInternalHost(u.Hostname()):
+		// Names resolving to internal addresses are refused when dialed
+		errs = append(errs, validation.For("url", ErrInternalURL))
+	}
+
+	for _, eventType := range eventTypes {
+		if !slices.Contains(EventTypes, eventType) {
+			errs = append(errs, validation.For("event_types", ErrUnknownEvent))
+			break
+		}
+	}
+
+	if err := errs.Err(); err != nil {
+		log.Debug("invalid webhook", sl.Error(err))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if secret == "" {
+		secret, err = generateSecret()
+		if err != nil {
+			log.Error("failed to generate secret", sl.Error(err))
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	if eventTypes == nil {
+		eventTypes = []string{}
+	}
+
+	webhook, err := s.storage.CreateWebhook(ctx, &models.Webhook{URL: rawURL, Secret: secret, EventTypes: eventTypes})
+	if err != nil {
+		log.Error("failed to create webhook", sl.Error(err))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	log.Info("webhook created", slog.String("webhook_id", webhook.ID))
+
+	return webhook, nil
+}
+
+func (s *Service) GetWebhooks(ctx context.Context) ([]models.Webhook, error) {
+	const op = "service.webhook.GetWebhooks"
+
+	ctx, span := tracer.Start(ctx, op)
+	defer span.End()
+
+	log := s.log.With(slog.String("op", op), sl.Trace(ctx))
+
+	webhooks, err := s.storage.GetWebhooks(ctx)
+	if err != nil {
+		log.Error("failed to get webhooks", sl.Error(err))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return webhooks, nil
+}
+
+func (s *Service) GetWebhook(ctx context.Context, webhookID string) (*models.Webhook, error) {
+	const op = "service.webhook.GetWebhook"
+
+	ctx, span := tracer.Start(ctx, op)
+	defer span.End()
+
+	log := s.log.With(slog.String("op", op), sl.Trace(ctx))
+
+	if err := validateID("webhook_id", webhookID); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	webhook, err := s.storage.GetWebhook(ctx, webhookID)
+	if err != nil {
+		log.Error("failed to get webhook", sl.Error(err))
+		return nil, mapStorageError(err)
+	}
+
+	return webhook, nil
+}
+
+// SetActive pauses or resumes sending events to the webhook. Events that
+// happen while it is paused are not delivered later.
+func (s *Service) SetActive(ctx context.Context, webhookID string, active bool) (*models.Webhook, error) {
+	const op = "service.webhook.SetActive"
+
+	ctx, span := tracer.Start(ctx, op)
+	defer span.End()
+
+	log := s.log.With(slog.String("op", op), sl.Trace(ctx))
+
+	if err := validateID("webhook_id", webhookID); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	webhook, err := s.storage.SetWebhookActive(ctx, webhookID, active)
+	if err != nil {
+		log.Error("failed to update webhook", sl.Error(err))
+		return nil, mapStorageError(err)
+	}
+
+	return webhook, nil
+}
+
+func (s *Service) DeleteWebhook(ctx context.Context, webhookID string) error {
+	const op = "service.webhook.DeleteWebhook"
+
+	ctx, span := tracer.Start(ctx, op)
+	defer span.End()
+
+	log := s.log.With(slog.String("op", op), sl.Trace(ctx))
+
+	if err := validateID("webhook_id", webhookID); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := s.storage.DeleteWebhook(ctx, webhookID); err != nil {
+		log.Error("failed to delete webhook", sl.Error(err))
+		return mapStorageError(err)
+	}
+
+	log.Info("webhook deleted", slog.String("webhook_id", webhookID))
+
+	return nil
+}
+
+// GetDeliveries lists deliveries of the webhook; status "dead" lists its
+// dead letter queue.
+func (s *Service) GetDeliveries(ctx context.Context, webhookID, status string, page int) ([]models.WebhookDelivery, error) {
+	const op = "service.webhook.GetDeliveries"
+
+	ctx, span := tracer.Start(ctx, op)
+	defer span.End()
+
+	log := s.log.With(slog.String("op", op), sl.Trace(ctx))
+
+	if err := validateID("webhook_id", webhookID); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	const limit = 50
+	offset := (page - 1) * limit
+
+	deliveries, err := s.storage.GetDeliveries(ctx, webhookID, status, limit, offset)
+	if err != nil {
+		log.Error("failed to get deliveries", sl.Error(err))
+		return nil, mapStorageError(err)
+	}
+
+	return deliveries, nil
+}
+
+// Replay sends the delivery again with a fresh attempt budget.
+func (s *Service) Replay(ctx context.Context, webhookID, deliveryID string) (*models.WebhookDelivery, error) {
+	const op = "service.webhook.Replay"
+
+	ctx, span := tracer.Start(ctx, op)
+	defer span.End()
+
+	log := s.log.With(slog.String("op", op), sl.Trace(ctx))
+
+	if err := validateID("webhook_id", webhookID); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	if err := validateID("delivery_id", deliveryID); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	delivery, err := s.storage.ReplayDelivery(ctx, webhookID, deliveryID)
+	if err != nil {
+		log.Error("failed to replay delivery", sl.Error(err))
+		return nil, mapStorageError(err)
+	}
+
+	log.Info("delivery replayed", slog.String("delivery_id", deliveryID))
+
+	return delivery, nil
+}
+
+func validateID(field, id string) error {
+	if _, err := uuid.Parse(id); err != nil {
+		return validation.For(field, validation.ErrInvalid)
+	}
+	return nil
+}
+
+func generateSecret() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+func mapStorageError(err error) error {
+	if errors.Is(err, repository.ErrWebhookNotFound) {
+		return ErrWebhookNotFound
+	}
+	if errors.Is(err, repository.ErrDeliveryNotFound) {
+		return ErrDeliveryNotFound
+	}
+	return err
+}
+
+// isInternalHost reports whether host is an internal address or localhost.
+func isInternalHost(host string) bool {
+	host = strings.ToLower(strings.TrimSuffix(host, "."))
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		return true
+	}
+
+	addr, err := netip.ParseAddr(host)
+	return err == nil && !isPublic(addr)
+}
@@ -0,0 +1,124 @@
+// Package timers tracks what watched users are working on: the running task
+// of every user, reloaded from the database when task events arrive.
+package timers
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/Alhanaqtah/effective-mobile-test-task/internal/models"
+)
+
+// Events are the event types that may change a running task.
+var Events = map[string]bool{
+	models.EventTaskCreated:  true,
+	models.EventTaskUpdated:  true,
+	models.EventTaskStarted:  true,
+	models.EventTaskFinished: true,
+	models.EventTaskDeleted:  true,
+}
+
+type TaskService interface {
+	RunningTasks(ctx context.Context, userUUIDs []string) (map[string]models.Task, error)
+}
+
+// Timer is the running task of a user at a point in time.
+type Timer struct {
+	UserID  string
+	Task    *models.Task // nil if the user is idle
+	Elapsed time.Duration
+}
+
+// Set holds the running task of each watched user. It is not safe for
+// concurrent use.
+type Set struct {
+	tasks   TaskService
+	running map[string]*models.Task
+}
+
+func New(tasks TaskService) *Set {
+	return &Set{
+		tasks:   tasks,
+		running: make(map[string]*models.Task),
+	}
+}
+
+// Refresh reloads the running tasks of the given users and starts watching
+// them.
+func (s *Set) Refresh(ctx context.Context, userIDs []string) error {
+	if len(userIDs) == 0 {
+		return nil
+	}
+
+	running, err := s.tasks.RunningTasks(ctx, userIDs)
+	if err != nil {
+		return err
+	}
+
+	for _, id := range userIDs {
+		if task, ok := running[id]; ok {
+			s.running[id] = &task
+		} else {
+			s.running[id] = nil
+		}
+	}
+
+	return nil
+}
+
+// RefreshAll reloads the running tasks of every watched user, e.g. after
+// events were missed.
+func (s *Set) RefreshAll(ctx context.Context) error {
+	return s.Refresh(ctx, s.UserIDs())
+}
+
+// Remove stops watching the given users.
+func (s *Set) Remove(userIDs []string) {
+	for _, id := range userIDs {
+		delete(s.running, id)
+	}
+}
+
+// Watches reports whether the user is watched.
+func (s *Set) Watches(userID string) bool {
+	_, ok := s.running[userID]
+	return ok
+}
+
+func (s *Set) Len() int {
+	return len(s.running)
+}
+
+func (s *Set) UserIDs() []string {
+	ids := make([]string, 0, len(s.running))
+	for id := range s.running {
+		ids = append(ids, id)
+	}
+	return ids
+}
+
+// Timers returns the timer of every watched user at now, ordered by user id.
+func (s *Set) Timers(now time.Time) []Timer {
+	timers := make([]Timer, 0, len(s.running))
+	for id, task := range s.running {
+		timer := Timer{UserID: id, Task: task}
+		if task != nil {
+			timer.Elapsed = Elapsed(task, now)
+		}
+		timers = append(timers, timer)
+	}
+
+	sort.Slice(timers, func(i, j int) bool { return timers[i].UserID < timers[j].UserID })
+
+	return timers
+}
+
+// Elapsed returns the time task has been running at now.
+func Elapsed(task *models.Task, now time.Time) time.Duration {
+	startedAt := task.CreatedAt
+	if task.StartedAt != nil {
+		startedAt = *task.StartedAt
+	}
+	return now.Sub(startedAt)
+}
@@ -0,0 +1,66 @@
+package timers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Alhanaqtah/effective-mobile-test-task/internal/models"
+)
+
+type fakeTasks map[string]models.Task
+
+func (f fakeTasks) RunningTasks(_ context.Context, userUUIDs []string) (map[string]models.Task, error) {
+	running := make(map[string]models.Task)
+	for _, id := range userUUIDs {
+		if task, ok := f[id]; ok {
+			running[id] = task
+		}
+	}
+	return running, nil
+}
+
+func TestSet(t *testing.T) {
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	startedAt := now.Add(-90 * time.Second)
+
+	tasks := fakeTasks{"b": {ID: "t1", UserID: "b", StartedAt: &startedAt}}
+	set := New(tasks)
+
+	if err := set.Refresh(context.Background(), []string{"b", "a"}); err != nil {
+		t.Fatal(err)
+	}
+
+	timers := set.Timers(now)
+	if len(timers) != 2 || timers[0].UserID != "a" || timers[1].UserID != "b" {
+		t.Fatalf("got timers %+v", timers)
+	}
+	if timers[0].Task != nil || timers[0].Elapsed != 0 {
+		t.Errorf("idle user has timer %+v", timers[0])
+	}
+	if timers[1].Task == nil || timers[1].Task.ID != "t1" || timers[1].Elapsed != 90*time.Second {
+		t.Errorf("running user has timer %+v", timers[1])
+	}
+
+	delete(tasks, "b")
+	if err := set.RefreshAll(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if timers := set.Timers(now); timers[1].Task != nil {
+		t.Errorf("finished task is still running: %+v", timers[1])
+	}
+
+	set.Remove([]string{"a"})
+	if set.Watches("a") || !set.Watches("b") || set.Len() != 1 {
+		t.Errorf("watching %v after removing a", set.UserIDs())
+	}
+}
+
+func TestElapsedWithoutStart(t *testing.T) {
+	now := time.Now()
+	task := &models.Task{CreatedAt: now.Add(-time.Minute)}
+
+	if got := Elapsed(task, now); got != time.Minute {
+		t.Errorf("Elapsed = %v, want %v", got, time.Minute)
+	}
+}
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_import_jobs_status;
+DROP TABLE IF EXISTS import_job_rows;
+DROP TABLE IF EXISTS import_jobs;
@@ -0,0 +1,22 @@
+CREATE TABLE IF NOT EXISTS import_jobs (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    status TEXT NOT NULL DEFAULT 'pending',
+    total INTEGER NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    finished_at TIMESTAMPTZ,
+    -- The instance running the job and until when, renewed while it runs
+    owner UUID,
+    lease_until TIMESTAMPTZ
+);
+
+CREATE TABLE IF NOT EXISTS import_job_rows (
+    job_id UUID NOT NULL REFERENCES import_jobs(id) ON DELETE CASCADE,
+    line INTEGER NOT NULL,
+    passport TEXT NOT NULL,
+    status TEXT NOT NULL DEFAULT 'pending',
+    user_id UUID,
+    error TEXT,
+    PRIMARY KEY (job_id, line)
+);
+
+CREATE INDEX IF NOT EXISTS idx_import_jobs_status ON import_jobs (status);
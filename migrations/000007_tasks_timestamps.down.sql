@@ -0,0 +1,6 @@
+ALTER TABLE tasks DROP COLUMN IF EXISTS started_at;
+
+ALTER TABLE tasks
+    ALTER COLUMN created_at TYPE TIMESTAMP USING created_at AT TIME ZONE 'UTC',
+    ALTER COLUMN created_at SET DEFAULT CURRENT_TIMESTAMP,
+    ALTER COLUMN done_at TYPE TIMESTAMP USING done_at AT TIME ZONE 'UTC';
@@ -0,0 +1,8 @@
+-- Timestamps without a time zone dropped the offset of the client. The
+-- stored ones were written as UTC wall clock times.
+ALTER TABLE tasks
+    ALTER COLUMN created_at TYPE TIMESTAMPTZ USING created_at AT TIME ZONE 'UTC',
+    ALTER COLUMN created_at SET DEFAULT now(),
+    ALTER COLUMN done_at TYPE TIMESTAMPTZ USING done_at AT TIME ZONE 'UTC';
+
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS started_at TIMESTAMPTZ DEFAULT NULL;
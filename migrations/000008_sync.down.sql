@@ -0,0 +1,18 @@
+DROP TRIGGER IF EXISTS tasks_track_delete ON tasks;
+DROP TRIGGER IF EXISTS tasks_track_change ON tasks;
+DROP TRIGGER IF EXISTS users_track_delete ON users;
+DROP TRIGGER IF EXISTS users_track_change ON users;
+
+DROP FUNCTION IF EXISTS sync_track_delete();
+DROP FUNCTION IF EXISTS sync_track_change();
+
+DROP TABLE IF EXISTS tombstones;
+
+ALTER TABLE tasks
+    DROP COLUMN IF EXISTS created_xid, DROP COLUMN IF EXISTS change_xid,
+    DROP COLUMN IF EXISTS created_seq, DROP COLUMN IF EXISTS change_seq;
+ALTER TABLE users
+    DROP COLUMN IF EXISTS created_xid, DROP COLUMN IF EXISTS change_xid,
+    DROP COLUMN IF EXISTS created_seq, DROP COLUMN IF EXISTS change_seq;
+
+DROP SEQUENCE IF EXISTS change_seq;
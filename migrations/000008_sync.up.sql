@@ -0,0 +1,72 @@
+CREATE SEQUENCE IF NOT EXISTS change_seq;
+
+-- The feed is ordered by the transaction that wrote a change before its
+-- sequence value. Sequence values are taken before commit, so a slow writer
+-- could commit a value below an already served cursor; transactions older
+-- than every running one cannot commit anything anymore.
+ALTER TABLE users
+    ADD COLUMN IF NOT EXISTS change_seq BIGINT,
+    ADD COLUMN IF NOT EXISTS created_seq BIGINT,
+    ADD COLUMN IF NOT EXISTS change_xid xid8,
+    ADD COLUMN IF NOT EXISTS created_xid xid8;
+UPDATE users SET change_seq = nextval('change_seq'), change_xid = pg_current_xact_id();
+UPDATE users SET created_seq = change_seq, created_xid = change_xid;
+ALTER TABLE users
+    ALTER COLUMN change_seq SET NOT NULL, ALTER COLUMN created_seq SET NOT NULL,
+    ALTER COLUMN change_xid SET NOT NULL, ALTER COLUMN created_xid SET NOT NULL;
+
+ALTER TABLE tasks
+    ADD COLUMN IF NOT EXISTS change_seq BIGINT,
+    ADD COLUMN IF NOT EXISTS created_seq BIGINT,
+    ADD COLUMN IF NOT EXISTS change_xid xid8,
+    ADD COLUMN IF NOT EXISTS created_xid xid8;
+UPDATE tasks SET change_seq = nextval('change_seq'), change_xid = pg_current_xact_id();
+UPDATE tasks SET created_seq = change_seq, created_xid = change_xid;
+ALTER TABLE tasks
+    ALTER COLUMN change_seq SET NOT NULL, ALTER COLUMN created_seq SET NOT NULL,
+    ALTER COLUMN change_xid SET NOT NULL, ALTER COLUMN created_xid SET NOT NULL;
+
+CREATE INDEX IF NOT EXISTS idx_users_change ON users (change_xid, change_seq);
+CREATE INDEX IF NOT EXISTS idx_tasks_change ON tasks (change_xid, change_seq);
+
+CREATE TABLE IF NOT EXISTS tombstones (
+    entity TEXT NOT NULL,
+    id UUID NOT NULL,
+    change_seq BIGINT NOT NULL DEFAULT nextval('change_seq'),
+    change_xid xid8 NOT NULL DEFAULT pg_current_xact_id(),
+    deleted_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    PRIMARY KEY (entity, id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_tombstones_change ON tombstones (change_xid, change_seq);
+
+CREATE OR REPLACE FUNCTION sync_track_change() RETURNS trigger AS $$
+BEGIN
+    NEW.change_seq := nextval('change_seq');
+    NEW.change_xid := pg_current_xact_id();
+    IF TG_OP = 'INSERT' THEN
+        NEW.created_seq := NEW.change_seq;
+        NEW.created_xid := NEW.change_xid;
+    END IF;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE OR REPLACE FUNCTION sync_track_delete() RETURNS trigger AS $$
+BEGIN
+    INSERT INTO tombstones (entity, id) VALUES (TG_ARGV[0], OLD.id)
+    ON CONFLICT (entity, id) DO UPDATE
+    SET change_seq = nextval('change_seq'), change_xid = pg_current_xact_id(), deleted_at = now();
+    RETURN OLD;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER users_track_change BEFORE INSERT OR UPDATE ON users
+    FOR EACH ROW EXECUTE FUNCTION sync_track_change();
+CREATE TRIGGER users_track_delete AFTER DELETE ON users
+    FOR EACH ROW EXECUTE FUNCTION sync_track_delete('user');
+
+CREATE TRIGGER tasks_track_change BEFORE INSERT OR UPDATE ON tasks
+    FOR EACH ROW EXECUTE FUNCTION sync_track_change();
+CREATE TRIGGER tasks_track_delete AFTER DELETE ON tasks
+    FOR EACH ROW EXECUTE FUNCTION sync_track_delete('task');
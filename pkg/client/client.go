@@ -0,0 +1,293 @@
+// Package client is a Go client of the Time Tracker REST API.
+//
+// Every call takes a context, is retried on network errors and temporary
+// server failures unless that could apply an update or deletion twice, and
+// reports API errors as *Error, which matches the errors of the package with
+// errors.Is:
+//
+//	user, err := c.GetUser(ctx, id)
+//	if errors.Is(err, client.ErrUserNotFound) {
+//		...
+//	}
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	uuidlib "github.com/google/uuid"
+)
+
+const (
+	defaultRetries    = 3
+	defaultBackoff    = 200 * time.Millisecond
+	maxBackoff        = 5 * time.Second
+	defaultTimeout    = 30 * time.Second
+	contentTypeJSON   = "application/json"
+	idempotencyHeader = "Idempotency-Key"
+
+	// basePath is the prefix of the API version the client speaks
+	basePath = "/api/v1"
+)
+
+// Client calls the REST API. It is safe for concurrent use.
+type Client struct {
+	baseURL    string
+	httpClient *http.Client
+	apiKey     string
+	language   string
+	retries    int
+	backoff    time.Duration
+}
+
+// Option configures a Client.
+type Option func(*Client)
+
+// WithHTTPClient sets the HTTP client used for requests.
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) {
+		c.httpClient = httpClient
+	}
+}
+
+// WithAPIKey sends key as a bearer token with every request.
+func WithAPIKey(key string) Option {
+	return func(c *Client) {
+		c.apiKey = key
+	}
+}
+
+// WithLanguage sets the Accept-Language of requests, which selects the
+// language of error messages.
+func WithLanguage(lang string) Option {
+	return func(c *Client) {
+		c.language = lang
+	}
+}
+
+// WithRetries sets how many times a failed request is retried and the delay
+// before the first retry, which doubles with every attempt. Zero retries
+// disables them.
+func WithRetries(retries int, backoff time.Duration) Option {
+	return func(c *Client) {
+		c.retries = retries
+		c.backoff = backoff
+	}
+}
+
+// New returns a client of the API served at baseURL, e.g.
+// "http://localhost:8080". The client uses version v1 of the API.
+func New(baseURL string, opts ...Option) *Client {
+	c := &Client{
+		baseURL:    strings.TrimRight(baseURL, "/"),
+		httpClient: &http.Client{Timeout: defaultTimeout},
+		retries:    defaultRetries,
+		backoff:    defaultBackoff,
+	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return c
+}
+
+// call describes a single API call.
+type call struct {
+	method      string
+	path        string
+	query       url.Values
+	header      http.Header
+	contentType string
+	body        []byte
+}
+
+// jsonCall returns a call with v encoded as its JSON body.
+func jsonCall(method, path string, v any) (call, error) {
+	const op = "client.jsonCall"
+
+	body, err := json.Marshal(v)
+	if err != nil {
+		return call{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return call{method: method, path: path, contentType: contentTypeJSON, body: body}, nil
+}
+
+// do sends req, retrying it on failures that may be temporary, and decodes a
+// successful response into out unless it is nil. POST requests carry an
+// Idempotency-Key, the same for every attempt, so the server applies them
+// once even if a response was lost. The server has no such protection for
+// PATCH and DELETE, so they are only retried when it did not act on them.
+// The delay asked by Retry-After is honoured in full, and a call that could
+// not be retried before the deadline of ctx fails right away.
+func (c *Client) do(ctx context.Context, req call, out any) error {
+	const op = "client.do"
+
+	if req.method == http.MethodPost {
+		if req.header == nil {
+			req.header = http.Header{}
+		}
+		if req.header.Get(idempotencyHeader) == "" {
+			req.header.Set(idempotencyHeader, uuidlib.NewString())
+		}
+	}
+
+	for attempt := 0; ; attempt++ {
+		resp, err := c.send(ctx, req)
+
+		var delay time.Duration
+		switch {
+		case err != nil:
+			if ctx.Err() != nil || attempt >= c.retries || !(replayable(req.method) || unsent(err)) {
+				return fmt.Errorf("%s: %w", op, err)
+			}
+		case resp.StatusCode >= 200 && resp.StatusCode < 300:
+			return decode(resp, out)
+		default:
+			apiErr := newError(resp)
+			if !retryable(req.method, apiErr) || attempt >= c.retries {
+				return apiErr
+			}
+			delay = retryAfter(resp, time.Now())
+
+			// Waiting past the deadline would only trade the error for a timeout
+			if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < delay {
+				return apiErr
+			}
+		}
+
+		if delay == 0 {
+			delay = c.delay(attempt)
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return fmt.Errorf("%s: %w", op, ctx.Err())
+		case <-timer.C:
+		}
+	}
+}
+
+func (c *Client) send(ctx context.Context, req call) (*http.Response, error) {
+	u := c.baseURL + basePath + req.path
+	if len(req.query) > 0 {
+		u += "?" + req.query.Encode()
+	}
+
+	var body io.Reader
+	if req.body != nil {
+		body = bytes.NewReader(req.body)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, req.method, u, body)
+	if err != nil {
+		return nil, err
+	}
+
+	for name, values := range req.header {
+		httpReq.Header[name] = values
+	}
+	httpReq.Header.Set("Accept", contentTypeJSON+", "+ProblemContentType)
+	if req.contentType != "" {
+		httpReq.Header.Set("Content-Type", req.contentType)
+	}
+	if c.apiKey != "" {
+		httpReq.Header.Set("Authorization", "Bearer "+c.apiKey)
+	}
+	if c.language != "" {
+		httpReq.Header.Set("Accept-Language", c.language)
+	}
+
+	return c.httpClient.Do(httpReq)
+}
+
+func decode(resp *http.Response, out any) error {
+	const op = "client.decode"
+
+	defer resp.Body.Close()
+
+	if out == nil {
+		_, _ = io.Copy(io.Discard, resp.Body)
+		return nil
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// replayable reports whether a request of method may be sent again even if
+// the server already applied it: reads change nothing and POST requests carry
+// an Idempotency-Key.
+func replayable(method string) bool {
+	return method == http.MethodGet || method == http.MethodPost
+}
+
+// unsent reports whether err means the request never reached the server.
+func unsent(err error) bool {
+	var opErr *net.OpError
+	return errors.As(err, &opErr) && opErr.Op == "dial"
+}
+
+// retryable reports whether a request of method that failed with err may
+// succeed when sent again. Rate limited requests were refused before the
+// server acted on them.
+func retryable(method string, err *Error) bool {
+	switch err.Status {
+	case http.StatusTooManyRequests:
+		return true
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return replayable(method)
+	case http.StatusConflict:
+		// The first attempt is still being processed
+		return errors.Is(err, ErrIdempotencyKeyInProgress)
+	}
+	return false
+}
+
+// retryAfter returns the delay asked by the Retry-After header, given in
+// seconds or as an HTTP date relative to now, zero if there is none.
+func retryAfter(resp *http.Response, now time.Time) time.Duration {
+	value := resp.Header.Get("Retry-After")
+	if seconds, err := strconv.Atoi(value); err == nil {
+		return max(time.Duration(seconds)*time.Second, 0)
+	}
+	if at, err := http.ParseTime(value); err == nil {
+		return max(at.Sub(now), 0)
+	}
+	return 0
+}
+
+// delay returns the exponential backoff before the retry following attempt
+// with up to 20% jitter.
+func (c *Client) delay(attempt int) time.Duration {
+	d := c.backoff << attempt
+	if d <= 0 || d > maxBackoff {
+		d = maxBackoff
+	}
+	return d + time.Duration(rand.Int64N(int64(d)/5+1))
+}
+
+func versionHeader(version int) http.Header {
+	header := http.Header{}
+	if version > 0 {
+		header.Set("If-Match", `"`+strconv.Itoa(version)+`"`)
+	}
+	return header
+}
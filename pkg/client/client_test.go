@@ -0,0 +1,612 @@
+package client_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	v1docs "github.com/Alhanaqtah/effective-mobile-test-task/docs/v1"
+	"github.com/Alhanaqtah/effective-mobile-test-task/internal/controller/openapi"
+	"github.com/Alhanaqtah/effective-mobile-test-task/internal/controller/router"
+	tasksHandler "github.com/Alhanaqtah/effective-mobile-test-task/internal/controller/task"
+	usersHandler "github.com/Alhanaqtah/effective-mobile-test-task/internal/controller/user"
+	importHandler "github.com/Alhanaqtah/effective-mobile-test-task/internal/controller/userimport"
+	"github.com/Alhanaqtah/effective-mobile-test-task/internal/lib/i18n"
+	"github.com/Alhanaqtah/effective-mobile-test-task/internal/models"
+	taskService "github.com/Alhanaqtah/effective-mobile-test-task/internal/service/task"
+	userService "github.com/Alhanaqtah/effective-mobile-test-task/internal/service/user"
+	"github.com/Alhanaqtah/effective-mobile-test-task/internal/service/userimport"
+	"github.com/Alhanaqtah/effective-mobile-test-task/pkg/client"
+
+	uuidlib "github.com/google/uuid"
+)
+
+// newServer serves the real router backed by in-memory services.
+func newServer(t *testing.T, wrap func(http.Handler) http.Handler) *httptest.Server {
+	t.Helper()
+
+	log := slog.New(slog.NewTextHandler(io.Discard, nil))
+	s := &store{users: map[string]models.User{}, tasks: map[string]models.Task{}}
+
+	validator, err := openapi.New(v1docs.Spec, log)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var handler http.Handler = router.New([]router.Version{{
+		Name: "v1",
+		Controllers: router.Controllers{
+			Users:  usersHandler.New(users{s}, log),
+			Import: importHandler.New(imports{}, log),
+			Tasks:  tasksHandler.New(tasks{s}, log),
+		},
+		Validator: validator.Handler,
+	}}, i18n.EN, nil, nil)
+	if wrap != nil {
+		handler = wrap(handler)
+	}
+
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+
+	return srv
+}
+
+func TestUsers(t *testing.T) {
+	srv := newServer(t, nil)
+	c := client.New(srv.URL)
+	ctx := context.Background()
+
+	user, err := c.CreateUser(ctx, client.CreateUserRequest{PassportNumber: "1234 567890"})
+	if err != nil {
+		t.Fatalf("CreateUser: %v", err)
+	}
+	if user.PassportSerie != 1234 || user.PassportNumber != 567890 || user.Address == "" {
+		t.Fatalf("CreateUser returned %+v", user)
+	}
+
+	_, err = c.CreateUser(ctx, client.CreateUserRequest{PassportNumber: "1234 567890"})
+	if !errors.Is(err, client.ErrUserExists) {
+		t.Fatalf("CreateUser of an existing passport: got %v, want ErrUserExists", err)
+	}
+
+	_, err = c.CreateUser(ctx, client.CreateUserRequest{PassportNumber: "1234"})
+	if !errors.Is(err, client.ErrInvalidPassport) {
+		t.Fatalf("CreateUser of an invalid passport: got %v, want ErrInvalidPassport", err)
+	}
+
+	updated, err := c.UpdateUser(ctx, user.ID, client.UserUpdate{
+		Name:    client.Some("Petr"),
+		Address: client.Null[string](),
+	}, user.Version)
+	if err != nil {
+		t.Fatalf("UpdateUser: %v", err)
+	}
+	if updated.Name != "Petr" || updated.Address != "" || updated.Version != user.Version+1 {
+		t.Fatalf("UpdateUser returned %+v", updated)
+	}
+
+	_, err = c.UpdateUser(ctx, user.ID, client.UserUpdate{Name: client.Some("Ivan")}, user.Version)
+	var apiErr *client.Error
+	if !errors.As(err, &apiErr) || apiErr.Status != http.StatusPreconditionFailed {
+		t.Fatalf("UpdateUser with a stale version: got %v, want 412", err)
+	}
+	if !errors.Is(err, client.ErrVersionMismatch) {
+		t.Fatalf("UpdateUser with a stale version: got %v, want ErrVersionMismatch", err)
+	}
+
+	list, err := c.ListUsers(ctx, client.ListUsersRequest{Page: 1})
+	if err != nil || len(list) != 1 {
+		t.Fatalf("ListUsers: got %v, %v", list, err)
+	}
+
+	if err := c.DeleteUser(ctx, user.ID, updated.Version); err != nil {
+		t.Fatalf("DeleteUser: %v", err)
+	}
+
+	_, err = c.GetUser(ctx, user.ID)
+	if !errors.Is(err, client.ErrUserNotFound) {
+		t.Fatalf("GetUser of a deleted user: got %v, want ErrUserNotFound", err)
+	}
+	if errors.Is(err, client.ErrTaskNotFound) {
+		t.Fatalf("GetUser error matches ErrTaskNotFound")
+	}
+}
+
+func TestTasks(t *testing.T) {
+	srv := newServer(t, nil)
+	c := client.New(srv.URL)
+	ctx := context.Background()
+
+	userID := uuidlib.NewString()
+	title := "Fix bug"
+
+	result, err := c.Batch(ctx, client.TaskBatch{Operations: []client.TaskOperation{
+		{Op: client.TaskOperationCreate, Ref: "a", UserID: userID, Title: &title},
+		{Op: client.TaskOperationStart, TaskRef: "a"},
+	}})
+	if err != nil {
+		t.Fatalf("Batch: %v", err)
+	}
+	if !result.Committed || len(result.Results) != 2 || result.Results[1].Task == nil {
+		t.Fatalf("Batch returned %+v", result)
+	}
+	task := result.Results[1].Task
+
+	running, err := c.RunningTasks(ctx, userID, uuidlib.NewString())
+	if err != nil || len(running) != 1 || running[0].ID != task.ID {
+		t.Fatalf("RunningTasks: got %+v, %v", running, err)
+	}
+
+	_, err = c.FinishTask(ctx, task.ID, task.Version+1)
+	if !errors.Is(err, client.ErrVersionMismatch) {
+		t.Fatalf("FinishTask with a wrong version: got %v, want ErrVersionMismatch", err)
+	}
+
+	finished, err := c.FinishTask(ctx, task.ID, task.Version)
+	if err != nil || !finished.Done {
+		t.Fatalf("FinishTask: got %+v, %v", finished, err)
+	}
+
+	worklogs, err := c.Worklogs(ctx, client.WorklogsRequest{UserID: userID, Start: time.Now().Add(-time.Hour), End: time.Now().Add(time.Hour)})
+	if err != nil || len(worklogs) != 1 {
+		t.Fatalf("Worklogs: got %+v, %v", worklogs, err)
+	}
+
+	_, err = c.Worklogs(ctx, client.WorklogsRequest{UserID: userID, Start: time.Now(), End: time.Now().Add(-time.Hour)})
+	if !errors.Is(err, client.ErrInvalidDateRange) {
+		t.Fatalf("Worklogs of an inverted range: got %v, want ErrInvalidDateRange", err)
+	}
+
+	if err := c.DeleteTask(ctx, task.ID, 0); err != nil {
+		t.Fatalf("DeleteTask: %v", err)
+	}
+
+	_, err = c.GetTask(ctx, task.ID)
+	if !errors.Is(err, client.ErrTaskNotFound) {
+		t.Fatalf("GetTask of a deleted task: got %v, want ErrTaskNotFound", err)
+	}
+
+	// Validation failures match the errors of the invalid fields
+	_, err = c.GetTask(ctx, "not-a-uuid")
+	var apiErr *client.Error
+	if !errors.As(err, &apiErr) || len(apiErr.Errors) != 1 || apiErr.Errors[0].Field != "task_id" {
+		t.Fatalf("GetTask with an invalid id: got %v", err)
+	}
+	if !errors.Is(err, client.ErrInvalid) {
+		t.Fatalf("GetTask with an invalid id: got %v, want ErrInvalid", err)
+	}
+}
+
+func TestImport(t *testing.T) {
+	srv := newServer(t, nil)
+	c := client.New(srv.URL)
+
+	job, err := c.ImportUsers(context.Background(), []string{"1234 567890", "4321 098765"})
+	if err != nil || job.Total != 2 {
+		t.Fatalf("ImportUsers: got %+v, %v", job, err)
+	}
+
+	_, err = c.GetImportJob(context.Background(), client.GetImportJobRequest{ID: uuidlib.NewString()})
+	if !errors.Is(err, client.ErrImportJobNotFound) {
+		t.Fatalf("GetImportJob of an unknown job: got %v, want ErrImportJobNotFound", err)
+	}
+}
+
+func TestLanguage(t *testing.T) {
+	srv := newServer(t, nil)
+	c := client.New(srv.URL, client.WithLanguage("ru"))
+
+	_, err := c.GetUser(context.Background(), uuidlib.NewString())
+	var apiErr *client.Error
+	if !errors.As(err, &apiErr) || apiErr.Title != "Пользователь не найден" {
+		t.Fatalf("GetUser: got %v, want a Russian title", err)
+	}
+}
+
+func TestRetries(t *testing.T) {
+	var (
+		mu       sync.Mutex
+		attempts int
+		keys     = map[string]bool{}
+	)
+
+	// The first two attempts fail as if a proxy could not reach the server
+	srv := newServer(t, func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			mu.Lock()
+			attempts++
+			keys[r.Header.Get("Idempotency-Key")] = true
+			n := attempts
+			mu.Unlock()
+
+			if n <= 2 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	})
+
+	c := client.New(srv.URL, client.WithRetries(3, time.Millisecond))
+
+	if _, err := c.CreateUser(context.Background(), client.CreateUserRequest{PassportNumber: "1234 567890"}); err != nil {
+		t.Fatalf("CreateUser: %v", err)
+	}
+	if attempts != 3 {
+		t.Fatalf("got %d attempts, want 3", attempts)
+	}
+	if len(keys) != 1 || keys[""] {
+		t.Fatalf("attempts used Idempotency-Keys %v, want the same one", keys)
+	}
+
+	// Client errors are not retried
+	_, err := c.GetUser(context.Background(), uuidlib.NewString())
+	if !errors.Is(err, client.ErrUserNotFound) || attempts != 4 {
+		t.Fatalf("GetUser: got %v after %d attempts, want ErrUserNotFound after 1", err, attempts-3)
+	}
+
+	// Retries stop with the context
+	mu.Lock()
+	attempts = -1000
+	mu.Unlock()
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	c = client.New(srv.URL, client.WithRetries(100, 20*time.Millisecond))
+	_, err = c.GetUser(ctx, uuidlib.NewString())
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("GetUser with a cancelled context: got %v, want context.DeadlineExceeded", err)
+	}
+}
+
+func TestRetriesOfUpdates(t *testing.T) {
+	var (
+		mu       sync.Mutex
+		attempts int
+		status   int
+	)
+
+	// The first attempt fails with status, which a proxy may return after
+	// the server applied the request
+	srv := newServer(t, func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			mu.Lock()
+			attempts++
+			n := attempts
+			mu.Unlock()
+
+			if n == 1 {
+				w.WriteHeader(status)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	})
+
+	c := client.New(srv.URL, client.WithRetries(3, time.Millisecond))
+
+	tests := []struct {
+		status   int
+		attempts int
+	}{
+		{http.StatusServiceUnavailable, 1},
+		{http.StatusTooManyRequests, 2},
+	}
+
+	for _, tt := range tests {
+		mu.Lock()
+		attempts, status = 0, tt.status
+		mu.Unlock()
+
+		_ = c.DeleteTask(context.Background(), uuidlib.NewString(), 0)
+		if attempts != tt.attempts {
+			t.Errorf("DeleteTask failing with %d: got %d attempts, want %d", tt.status, attempts, tt.attempts)
+		}
+	}
+
+	// Requests that never reached the server are sent again, others are not
+	for _, op := range []string{"dial", "read"} {
+		sent := 0
+		transport := roundTripper(func(*http.Request) (*http.Response, error) {
+			sent++
+			return nil, &net.OpError{Op: op, Net: "tcp", Err: errors.New("connection reset")}
+		})
+
+		c := client.New(srv.URL, client.WithRetries(3, time.Millisecond), client.WithHTTPClient(&http.Client{Transport: transport}))
+		_ = c.DeleteTask(context.Background(), uuidlib.NewString(), 0)
+
+		want := 1
+		if op == "dial" {
+			want = 4
+		}
+		if sent != want {
+			t.Errorf("DeleteTask failing to %s: got %d attempts, want %d", op, sent, want)
+		}
+	}
+}
+
+// TestRetryAfterDeadline checks that the client gives up at once when the
+// server asks to wait past the deadline of the call, in either form of
+// Retry-After.
+func TestRetryAfterDeadline(t *testing.T) {
+	for _, retryAfter := range []string{"3600", time.Now().Add(time.Hour).UTC().Format(http.TimeFormat)} {
+		attempts := 0
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			attempts++
+			w.Header().Set("Retry-After", retryAfter)
+			w.WriteHeader(http.StatusTooManyRequests)
+		}))
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		start := time.Now()
+
+		_, err := client.New(srv.URL).GetTask(ctx, uuidlib.NewString())
+
+		cancel()
+		srv.Close()
+
+		var apiErr *client.Error
+		if !errors.As(err, &apiErr) || apiErr.Status != http.StatusTooManyRequests {
+			t.Errorf("Retry-After %s: error = %v, want the 429 response", retryAfter, err)
+		}
+		if attempts != 1 || time.Since(start) > time.Second {
+			t.Errorf("Retry-After %s: %d attempts in %v, want one right away", retryAfter, attempts, time.Since(start))
+		}
+	}
+}
+
+type roundTripper func(*http.Request) (*http.Response, error)
+
+func (f roundTripper) RoundTrip(r *http.Request) (*http.Response, error) { return f(r) }
+
+// store keeps the users and tasks of the fake services.
+type store struct {
+	mu    sync.Mutex
+	users map[string]models.User
+	tasks map[string]models.Task
+}
+
+type users struct{ s *store }
+
+func (u users) CreateUser(_ context.Context, serie, number int) (*models.User, error) {
+	u.s.mu.Lock()
+	defer u.s.mu.Unlock()
+
+	for _, user := range u.s.users {
+		if user.PassportSerie == serie && user.PassportNumber == number {
+			return nil, userService.ErrExists
+		}
+	}
+
+	user := models.User{
+		ID:             uuidlib.NewString(),
+		Name:           "Ivan",
+		Surname:        "Ivanov",
+		Address:        "Moscow",
+		PassportSerie:  serie,
+		PassportNumber: number,
+		Version:        1,
+	}
+	u.s.users[user.ID] = user
+
+	return &user, nil
+}
+
+func (u users) GetUsers(_ context.Context, _ int, _ string) ([]models.User, error) {
+	u.s.mu.Lock()
+	defer u.s.mu.Unlock()
+
+	list := []models.User{}
+	for _, user := range u.s.users {
+		list = append(list, user)
+	}
+	return list, nil
+}
+
+func (u users) GetUser(_ context.Context, id string) (*models.User, error) {
+	u.s.mu.Lock()
+	defer u.s.mu.Unlock()
+
+	user, ok := u.s.users[id]
+	if !ok {
+		return nil, userService.ErrUserNotFound
+	}
+	return &user, nil
+}
+
+func (u users) UpdateUserInfo(_ context.Context, id string, update models.UserUpdate, version int) (*models.User, error) {
+	u.s.mu.Lock()
+	defer u.s.mu.Unlock()
+
+	user, ok := u.s.users[id]
+	if !ok {
+		return nil, userService.ErrUserNotFound
+	}
+	if version != 0 && version != user.Version {
+		return nil, userService.ErrVersionMismatch
+	}
+
+	if update.Name.Set {
+		user.Name = update.Name.Value
+	}
+	if update.Address.Set {
+		user.Address = update.Address.Value
+	}
+	user.Version++
+	u.s.users[id] = user
+
+	return &user, nil
+}
+
+func (u users) RemoveUserByUUID(_ context.Context, id string, version int) error {
+	u.s.mu.Lock()
+	defer u.s.mu.Unlock()
+
+	user, ok := u.s.users[id]
+	if !ok {
+		return userService.ErrUserNotFound
+	}
+	if version != 0 && version != user.Version {
+		return userService.ErrVersionMismatch
+	}
+
+	delete(u.s.users, id)
+	return nil
+}
+
+type tasks struct{ s *store }
+
+func (t tasks) GetTasksInRange(_ context.Context, userID, startDate, endDate string) ([]models.Task, error) {
+	start, err := time.Parse(time.RFC3339, startDate)
+	if err != nil {
+		return nil, taskService.ErrInvalidDate
+	}
+	end, err := time.Parse(time.RFC3339, endDate)
+	if err != nil {
+		return nil, taskService.ErrInvalidDate
+	}
+	if start.After(end) {
+		return nil, taskService.ErrInvalidDateRange
+	}
+
+	t.s.mu.Lock()
+	defer t.s.mu.Unlock()
+
+	var list []models.Task
+	for _, task := range t.s.tasks {
+		if task.UserID == userID && !task.CreatedAt.Before(start) && !task.CreatedAt.After(end) {
+			list = append(list, task)
+		}
+	}
+	return list, nil
+}
+
+func (t tasks) SearchTasks(context.Context, string, []string, int) ([]models.TaskSearchResult, error) {
+	return nil, taskService.ErrEmptyQuery
+}
+
+func (t tasks) GetTask(_ context.Context, id string) (*models.Task, error) {
+	t.s.mu.Lock()
+	defer t.s.mu.Unlock()
+
+	task, ok := t.s.tasks[id]
+	if !ok {
+		return nil, taskService.ErrTaskNotFound
+	}
+	return &task, nil
+}
+
+func (t tasks) RunningTasks(_ context.Context, userIDs []string) (map[string]models.Task, error) {
+	t.s.mu.Lock()
+	defer t.s.mu.Unlock()
+
+	running := map[string]models.Task{}
+	for _, task := range t.s.tasks {
+		if task.StartedAt != nil && !task.Done {
+			running[task.UserID] = task
+		}
+	}
+	return running, nil
+}
+
+func (t tasks) StartTask(_ context.Context, id string, _ time.Time, version int) (*models.Task, error) {
+	return t.change(id, version, func(task *models.Task) {
+		now := time.Now()
+		task.StartedAt = &now
+	})
+}
+
+func (t tasks) FinishTask(_ context.Context, id string, _ time.Time, version int) (*models.Task, error) {
+	return t.change(id, version, func(task *models.Task) {
+		now := time.Now()
+		task.Done = true
+		task.DoneAt = &now
+	})
+}
+
+func (t tasks) DeleteTask(_ context.Context, id string, version int) error {
+	_, err := t.change(id, version, nil)
+	return err
+}
+
+func (t tasks) change(id string, version int, apply func(task *models.Task)) (*models.Task, error) {
+	t.s.mu.Lock()
+	defer t.s.mu.Unlock()
+
+	task, ok := t.s.tasks[id]
+	if !ok {
+		return nil, taskService.ErrTaskNotFound
+	}
+	if version != 0 && version != task.Version {
+		return nil, taskService.ErrVersionMismatch
+	}
+
+	if apply == nil {
+		delete(t.s.tasks, id)
+		return nil, nil
+	}
+
+	apply(&task)
+	task.Version++
+	t.s.tasks[id] = task
+
+	return &task, nil
+}
+
+// Batch supports the create and start operations only.
+func (t tasks) Batch(ctx context.Context, ops []models.TaskOperation, _ bool) ([]taskService.OperationResult, error) {
+	refs := map[string]string{}
+	results := make([]taskService.OperationResult, len(ops))
+
+	for i, op := range ops {
+		var task *models.Task
+		var err error
+
+		switch op.Op {
+		case models.TaskOperationCreate:
+			t.s.mu.Lock()
+			created := models.Task{ID: uuidlib.NewString(), UserID: op.UserID, Title: op.Title, CreatedAt: time.Now(), Version: 1}
+			t.s.tasks[created.ID] = created
+			t.s.mu.Unlock()
+
+			refs[op.Ref] = created.ID
+			task = &created
+		case models.TaskOperationStart:
+			task, err = t.StartTask(ctx, refs[op.TaskRef], time.Time{}, op.Version)
+		default:
+			err = taskService.ErrUnknownOperation
+		}
+
+		if err != nil {
+			return nil, err
+		}
+		results[i] = taskService.OperationResult{Status: taskService.OperationApplied, Task: task}
+	}
+
+	return results, nil
+}
+
+type imports struct{}
+
+func (imports) Import(_ context.Context, _ string, r io.Reader) (*models.ImportJob, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	return &models.ImportJob{ID: uuidlib.NewString(), Status: models.ImportJobPending, Total: len(lines), CreatedAt: time.Now()}, nil
+}
+
+func (imports) GetJob(context.Context, string, string, int) (*models.ImportJob, error) {
+	return nil, userimport.ErrJobNotFound
+}
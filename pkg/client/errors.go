@@ -0,0 +1,154 @@
+package client
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// Errors reported by the API. An *Error matches every error presented with
+// its code, so errors.Is(err, ErrVersionMismatch) holds for both users and
+// tasks.
+var (
+	ErrRequired      = errors.New("field is required")
+	ErrInvalid       = errors.New("field is invalid")
+	ErrMalformedBody = errors.New("request body is malformed")
+	ErrTooLong       = errors.New("field is too long")
+	ErrUnknownField  = errors.New("unknown field")
+
+	ErrUnsupportedMediaType     = errors.New("unsupported media type")
+	ErrBodyTooLarge             = errors.New("request body is too large")
+	ErrPatchFailed              = errors.New("patch cannot be applied")
+	ErrIdempotencyKeyReused     = errors.New("idempotency key is reused with a different request")
+	ErrIdempotencyKeyInProgress = errors.New("request with the same idempotency key is in progress")
+	ErrRateLimited              = errors.New("rate limit exceeded")
+
+	ErrUserNotFound            = errors.New("user not found")
+	ErrUserExists              = errors.New("user already exists")
+	ErrEmptyBody               = errors.New("request body is empty")
+	ErrInvalidPassport         = errors.New("invalid passport")
+	ErrVersionMismatch         = errors.New("version mismatch")
+	ErrPassportRejected        = errors.New("passport rejected by the people info service")
+	ErrPeopleInfoUnavailable   = errors.New("people info service is unavailable")
+	ErrTaskNotFound            = errors.New("task not found")
+	ErrInvalidUUID             = errors.New("invalid uuid")
+	ErrInvalidDate             = errors.New("invalid date")
+	ErrInvalidDateRange        = errors.New("start date is after end date")
+	ErrEmptyQuery              = errors.New("search query is empty")
+	ErrEmptyScope              = errors.New("no users to search")
+	ErrTaskExists              = errors.New("task already exists")
+	ErrFutureTime              = errors.New("time is in the future")
+	ErrEmptyBatch              = errors.New("batch is empty")
+	ErrBatchTooLarge           = errors.New("batch is too large")
+	ErrUnknownOperation        = errors.New("unknown operation")
+	ErrUnknownTaskRef          = errors.New("unknown task reference")
+	ErrImportJobNotFound       = errors.New("import job not found")
+	ErrEmptyImport             = errors.New("import is empty")
+	ErrImportTooLarge          = errors.New("import is too large")
+	ErrMalformedImport         = errors.New("import is malformed")
+	ErrUnsupportedImportFormat = errors.New("unsupported import format")
+)
+
+// codes maps the errors to the stable codes the API presents them with.
+var codes = map[error]string{
+	ErrRequired:      "required",
+	ErrInvalid:       "invalid",
+	ErrMalformedBody: "malformed_body",
+	ErrTooLong:       "too_long",
+	ErrUnknownField:  "unknown_field",
+
+	ErrUnsupportedMediaType:     "unsupported_media_type",
+	ErrBodyTooLarge:             "body_too_large",
+	ErrPatchFailed:              "patch_failed",
+	ErrIdempotencyKeyReused:     "idempotency_key_reused",
+	ErrIdempotencyKeyInProgress: "idempotency_key_in_progress",
+	ErrRateLimited:              "rate_limited",
+
+	ErrUserNotFound:            "user_not_found",
+	ErrUserExists:              "user_exists",
+	ErrEmptyBody:               "empty_body",
+	ErrInvalidPassport:         "invalid_passport",
+	ErrVersionMismatch:         "version_mismatch",
+	ErrPassportRejected:        "passport_rejected",
+	ErrPeopleInfoUnavailable:   "people_info_unavailable",
+	ErrTaskNotFound:            "task_not_found",
+	ErrInvalidUUID:             "invalid_uuid",
+	ErrInvalidDate:             "invalid_date",
+	ErrInvalidDateRange:        "invalid_date_range",
+	ErrEmptyQuery:              "empty_query",
+	ErrEmptyScope:              "empty_scope",
+	ErrTaskExists:              "task_exists",
+	ErrFutureTime:              "future_time",
+	ErrEmptyBatch:              "empty_batch",
+	ErrBatchTooLarge:           "batch_too_large",
+	ErrUnknownOperation:        "unknown_operation",
+	ErrUnknownTaskRef:          "unknown_task_ref",
+	ErrImportJobNotFound:       "import_job_not_found",
+	ErrEmptyImport:             "empty_import",
+	ErrImportTooLarge:          "import_too_large",
+	ErrMalformedImport:         "malformed_import",
+	ErrUnsupportedImportFormat: "unsupported_media_type",
+}
+
+// maxErrorBody limits how much of an error response is read.
+const maxErrorBody = 1 << 20
+
+// Error is an error response of the API. Responses without a problem
+// document only have Status and Title set.
+type Error struct {
+	Problem
+}
+
+func (e *Error) Error() string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "%d %s", e.Status, e.Title)
+	if e.Detail != "" {
+		b.WriteString(": " + e.Detail)
+	}
+	for _, f := range e.Errors {
+		fmt.Fprintf(&b, "; %s: %s", f.Field, f.Detail)
+	}
+
+	return b.String()
+}
+
+// Is reports whether target is presented with the code of the response or,
+// for validation failures, of one of the invalid fields.
+func (e *Error) Is(target error) bool {
+	code, ok := codes[target]
+	if !ok {
+		return false
+	}
+	if e.Code == code {
+		return true
+	}
+	for _, f := range e.Errors {
+		if f.Code == code {
+			return true
+		}
+	}
+	return false
+}
+
+// newError reads the problem document of a failed response.
+func newError(resp *http.Response) *Error {
+	defer resp.Body.Close()
+
+	var e Error
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxErrorBody))
+	if err == nil {
+		_ = json.Unmarshal(body, &e.Problem)
+	}
+
+	e.Status = resp.StatusCode
+	if e.Title == "" {
+		e.Title = http.StatusText(resp.StatusCode)
+	}
+
+	return &e
+}
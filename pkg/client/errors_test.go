@@ -0,0 +1,88 @@
+package client
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/Alhanaqtah/effective-mobile-test-task/internal/controller/apierror"
+	"github.com/Alhanaqtah/effective-mobile-test-task/internal/lib/validation"
+	"github.com/Alhanaqtah/effective-mobile-test-task/internal/repository/externalapi"
+	taskService "github.com/Alhanaqtah/effective-mobile-test-task/internal/service/task"
+	userService "github.com/Alhanaqtah/effective-mobile-test-task/internal/service/user"
+	"github.com/Alhanaqtah/effective-mobile-test-task/internal/service/userimport"
+)
+
+// TestCodesMatchServer keeps the codes of the client in sync with the
+// errors of the server they stand for.
+func TestCodesMatchServer(t *testing.T) {
+	server := map[error]error{
+		ErrRequired:      validation.ErrRequired,
+		ErrInvalid:       validation.ErrInvalid,
+		ErrMalformedBody: validation.ErrMalformedBody,
+		ErrTooLong:       validation.ErrTooLong,
+		ErrUnknownField:  validation.ErrUnknownField,
+
+		ErrUnsupportedMediaType:     apierror.ErrUnsupportedMediaType,
+		ErrBodyTooLarge:             apierror.ErrBodyTooLarge,
+		ErrPatchFailed:              apierror.ErrPatchFailed,
+		ErrIdempotencyKeyReused:     apierror.ErrIdempotencyKeyReused,
+		ErrIdempotencyKeyInProgress: apierror.ErrIdempotencyKeyInProgress,
+		ErrRateLimited:              apierror.ErrRateLimited,
+
+		ErrUserNotFound:            userService.ErrUserNotFound,
+		ErrUserExists:              userService.ErrExists,
+		ErrEmptyBody:               userService.ErrEmptyBody,
+		ErrInvalidPassport:         userService.ErrInvalidPassport,
+		ErrVersionMismatch:         taskService.ErrVersionMismatch,
+		ErrPassportRejected:        externalapi.ErrBadRequest,
+		ErrPeopleInfoUnavailable:   externalapi.ErrExternalAPIError,
+		ErrTaskNotFound:            taskService.ErrTaskNotFound,
+		ErrInvalidUUID:             taskService.ErrInvalidUUID,
+		ErrInvalidDate:             taskService.ErrInvalidDate,
+		ErrInvalidDateRange:        taskService.ErrInvalidDateRange,
+		ErrEmptyQuery:              taskService.ErrEmptyQuery,
+		ErrEmptyScope:              taskService.ErrEmptyScope,
+		ErrTaskExists:              taskService.ErrTaskExists,
+		ErrFutureTime:              taskService.ErrFutureTime,
+		ErrEmptyBatch:              taskService.ErrEmptyBatch,
+		ErrBatchTooLarge:           taskService.ErrBatchTooLarge,
+		ErrUnknownOperation:        taskService.ErrUnknownOperation,
+		ErrUnknownTaskRef:          taskService.ErrUnknownRef,
+		ErrImportJobNotFound:       userimport.ErrJobNotFound,
+		ErrEmptyImport:             userimport.ErrEmptyImport,
+		ErrImportTooLarge:          userimport.ErrTooManyRows,
+		ErrMalformedImport:         userimport.ErrMalformedInput,
+		ErrUnsupportedImportFormat: userimport.ErrUnsupportedFormat,
+	}
+
+	if len(server) != len(codes) {
+		t.Errorf("%d errors are checked, the client has %d", len(server), len(codes))
+	}
+
+	for err, code := range codes {
+		serverErr, ok := server[err]
+		if !ok {
+			t.Errorf("%v is not checked", err)
+			continue
+		}
+		if want := apierror.Lookup(serverErr).Code; code != want {
+			t.Errorf("%v has code %q, the server presents it with %q", err, code, want)
+		}
+	}
+}
+
+func TestErrorIs(t *testing.T) {
+	err := &Error{Problem{Code: "validation_failed", Errors: []FieldError{{Field: "task_id", Code: "invalid_uuid"}}}}
+
+	if !errors.Is(err, ErrInvalidUUID) {
+		t.Error("error does not match the code of its field")
+	}
+	if errors.Is(err, ErrInvalid) || errors.Is(err, errors.New("invalid_uuid")) {
+		t.Error("error matches a foreign error")
+	}
+
+	err = &Error{Problem{Code: "unsupported_media_type"}}
+	if !errors.Is(err, ErrUnsupportedMediaType) || !errors.Is(err, ErrUnsupportedImportFormat) {
+		t.Error("error does not match every error sharing its code")
+	}
+}
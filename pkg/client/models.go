@@ -0,0 +1,177 @@
+package client
+
+import "time"
+
+// User is a user of the time tracker.
+type User struct {
+	ID             string `json:"id,omitempty"`
+	Name           string `json:"name,omitempty"`
+	Surname        string `json:"surname,omitempty"`
+	Patronymic     string `json:"patronymic,omitempty"`
+	Address        string `json:"address,omitempty"`
+	PassportSerie  int    `json:"passport_serie,omitempty"`
+	PassportNumber int    `json:"passport_number,omitempty"`
+	Version        int    `json:"version,omitempty"` // Incremented by every change, for If-Match
+}
+
+// Optional is a change of a field: left as is, set to Value or cleared.
+type Optional[T any] struct {
+	Set   bool
+	Null  bool
+	Value T
+}
+
+// Some returns a change setting a field of UserUpdate to value.
+func Some[T any](value T) Optional[T] {
+	return Optional[T]{Set: true, Value: value}
+}
+
+// Null returns a change clearing a field of UserUpdate.
+func Null[T any]() Optional[T] {
+	return Optional[T]{Set: true, Null: true}
+}
+
+// UserUpdate is a set of changes of a user.
+type UserUpdate struct {
+	Name           Optional[string]
+	Surname        Optional[string]
+	Patronymic     Optional[string]
+	Address        Optional[string]
+	PassportSerie  Optional[int]
+	PassportNumber Optional[int]
+}
+
+// CreateUserRequest creates a user from passport data.
+type CreateUserRequest struct {
+	PassportNumber string `json:"passportNumber,omitempty"` // "<serie> <number>"
+}
+
+// Statuses of ImportJob
+const (
+	ImportJobPending  = "pending"
+	ImportJobRunning  = "running"
+	ImportJobFinished = "finished"
+)
+
+// Statuses of ImportRow
+const (
+	ImportRowPending          = "pending"
+	ImportRowCreated          = "created"
+	ImportRowExists           = "exists"
+	ImportRowInvalid          = "invalid"
+	ImportRowEnrichmentFailed = "enrichment_failed"
+	ImportRowFailed           = "failed"
+)
+
+// ImportJob is a background import of users.
+type ImportJob struct {
+	ID         string         `json:"id"`
+	Status     string         `json:"status"`
+	Total      int            `json:"total"`            // Number of rows
+	Counts     map[string]int `json:"counts,omitempty"` // Number of rows in every status
+	CreatedAt  time.Time      `json:"created_at"`
+	FinishedAt *time.Time     `json:"finished_at,omitempty"`
+	Rows       []ImportRow    `json:"rows,omitempty"` // The requested page of rows
+}
+
+// ImportRow is a row of an import job and the result of its processing.
+type ImportRow struct {
+	Line     int    `json:"line"`
+	Passport string `json:"passport"`
+	Status   string `json:"status"`
+	UserID   string `json:"user_id,omitempty"` // The created user
+	Error    string `json:"error,omitempty"`
+}
+
+// Task is a task of a user.
+type Task struct {
+	ID          string     `json:"id,omitempty"`
+	UserID      string     `json:"user_id,omitempty"`
+	Title       string     `json:"title,omitempty"`
+	Description string     `json:"description,omitempty"`
+	Done        bool       `json:"done,omitempty"`
+	CreatedAt   time.Time  `json:"created_at,omitempty"`
+	StartedAt   *time.Time `json:"started_at,omitempty"` // Last start
+	DoneAt      *time.Time `json:"done_at,omitempty"`
+	Duration    *float64   `json:"duration,omitempty"` // Minutes spent on the task
+	Version     int        `json:"version,omitempty"`  // Incremented by every change, for If-Match
+}
+
+// TaskSearchResult is a task found by a full-text search.
+type TaskSearchResult struct {
+	Task
+	Rank                 float32 `json:"rank"`
+	TitleHighlight       string  `json:"title_highlight,omitempty"`       // HTML with matches in <mark> tags
+	DescriptionHighlight string  `json:"description_highlight,omitempty"` // HTML with matches in <mark> tags
+}
+
+// Modes of TaskBatch
+const (
+	BatchModeAtomic      = "atomic"
+	BatchModeIndependent = "independent"
+)
+
+// Operations of TaskOperation
+const (
+	TaskOperationCreate = "create"
+	TaskOperationUpdate = "update"
+	TaskOperationStart  = "start"
+	TaskOperationFinish = "finish"
+	TaskOperationDelete = "delete"
+)
+
+// TaskBatch is an ordered list of task operations.
+type TaskBatch struct {
+	Mode       string          `json:"mode,omitempty"` // BatchModeAtomic if empty
+	Operations []TaskOperation `json:"operations"`
+}
+
+// TaskOperation is an operation of a TaskBatch.
+type TaskOperation struct {
+	Op          string     `json:"op"`
+	Ref         string     `json:"ref,omitempty"`      // Reference to the task created by a create operation
+	TaskID      string     `json:"task_id,omitempty"`  // Task of update, start, finish and delete
+	TaskRef     string     `json:"task_ref,omitempty"` // Reference to a task created earlier in the batch
+	UserID      string     `json:"user_id,omitempty"`  // Owner of the task created by create
+	Title       *string    `json:"title,omitempty"`
+	Description *string    `json:"description,omitempty"` // An empty one clears the description in update
+	Version     int        `json:"version,omitempty"`     // Expected version of the task, not checked if zero
+	At          *time.Time `json:"at,omitempty"`          // Time of the operation, now if nil
+}
+
+// TaskBatchResult is the result of a TaskBatch.
+type TaskBatchResult struct {
+	Committed bool                  `json:"committed"`
+	Results   []TaskOperationResult `json:"results"` // In the order of operations
+}
+
+// TaskOperationResult is the result of an operation of a TaskBatch.
+type TaskOperationResult struct {
+	Index  int      `json:"index"`
+	Status string   `json:"status"` // applied, failed, rolled_back or skipped
+	Task   *Task    `json:"task,omitempty"`
+	Error  *Problem `json:"error,omitempty"`
+}
+
+// ProblemContentType is the content type of error responses (RFC 7807).
+const ProblemContentType = "application/problem+json"
+
+// Problem is an error response of the API (RFC 7807).
+type Problem struct {
+	Type      string       `json:"type"`
+	Title     string       `json:"title"`
+	Status    int          `json:"status"`
+	Detail    string       `json:"detail,omitempty"`
+	Instance  string       `json:"instance,omitempty"`
+	Code      string       `json:"code"` // Stable machine-readable code
+	RequestID string       `json:"request_id,omitempty"`
+	Errors    []FieldError `json:"errors,omitempty"` // Invalid fields of a request
+}
+
+// FieldError is an invalid field of a request.
+type FieldError struct {
+	Field  string `json:"field"`
+	In     string `json:"in,omitempty"` // path, query, header or body
+	Code   string `json:"code"`
+	Detail string `json:"detail"`
+}
@@ -0,0 +1,142 @@
+package client
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Alhanaqtah/effective-mobile-test-task/internal/lib/problem"
+	"github.com/Alhanaqtah/effective-mobile-test-task/internal/lib/request"
+	"github.com/Alhanaqtah/effective-mobile-test-task/internal/lib/response"
+	"github.com/Alhanaqtah/effective-mobile-test-task/internal/models"
+)
+
+// TestModelsMatchServer keeps the models of the client in sync with the
+// types the server encodes and decodes: both must have the same JSON fields,
+// of the same JSON type and omitted in the same cases.
+func TestModelsMatchServer(t *testing.T) {
+	tests := []struct {
+		client, server any
+	}{
+		{User{}, models.User{}},
+		{CreateUserRequest{}, request.CreateUser{}},
+		{ImportJob{}, models.ImportJob{}},
+		{ImportRow{}, models.ImportRow{}},
+		{Task{}, models.Task{}},
+		{TaskSearchResult{}, models.TaskSearchResult{}},
+		{TaskBatch{}, request.TaskBatch{}},
+		{TaskOperation{}, request.TaskOperation{}},
+		{TaskBatchResult{}, response.TaskBatch{}},
+		{TaskOperationResult{}, response.TaskOperationResult{}},
+		{Problem{}, problem.Problem{}},
+		{FieldError{}, problem.FieldError{}},
+	}
+	for _, tt := range tests {
+		client, server := reflect.TypeOf(tt.client), reflect.TypeOf(tt.server)
+
+		want := jsonFields(server)
+		got := jsonFields(client)
+		for name, field := range want {
+			if got[name] != field {
+				t.Errorf("%s.%s is %q, the server has %s as %q", client.Name(), name, got[name], server, field)
+			}
+		}
+		for name := range got {
+			if _, ok := want[name]; !ok {
+				t.Errorf("%s.%s is not a field of %s", client.Name(), name, server)
+			}
+		}
+	}
+}
+
+// jsonFields returns the JSON type of the fields of t by name, followed by
+// ",omitempty" if the field is omitted when empty.
+func jsonFields(t reflect.Type) map[string]string {
+	fields := make(map[string]string)
+
+	for i := range t.NumField() {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+
+		name, opts, _ := strings.Cut(f.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+		if f.Anonymous && name == "" {
+			for name, field := range jsonFields(f.Type) {
+				fields[name] = field
+			}
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+
+		field := jsonType(f.Type)
+		if opts == "omitempty" {
+			field += ",omitempty"
+		}
+		fields[name] = field
+	}
+
+	return fields
+}
+
+func jsonType(t reflect.Type) string {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t == reflect.TypeOf(time.Time{}) {
+		return "string"
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		return "string"
+	case reflect.Bool:
+		return "boolean"
+	case reflect.Int, reflect.Int64, reflect.Float32, reflect.Float64:
+		return "number"
+	case reflect.Slice:
+		return "array of " + jsonType(t.Elem())
+	case reflect.Map:
+		return "object of " + jsonType(t.Elem())
+	default:
+		return "object"
+	}
+}
+
+func TestConstantsMatchServer(t *testing.T) {
+	tests := []struct {
+		name           string
+		client, server string
+	}{
+		{"ImportJobPending", ImportJobPending, models.ImportJobPending},
+		{"ImportJobRunning", ImportJobRunning, models.ImportJobRunning},
+		{"ImportJobFinished", ImportJobFinished, models.ImportJobFinished},
+
+		{"ImportRowPending", ImportRowPending, models.ImportRowPending},
+		{"ImportRowCreated", ImportRowCreated, models.ImportRowCreated},
+		{"ImportRowExists", ImportRowExists, models.ImportRowExists},
+		{"ImportRowInvalid", ImportRowInvalid, models.ImportRowInvalid},
+		{"ImportRowEnrichmentFailed", ImportRowEnrichmentFailed, models.ImportRowEnrichmentFailed},
+		{"ImportRowFailed", ImportRowFailed, models.ImportRowFailed},
+
+		{"BatchModeAtomic", BatchModeAtomic, request.BatchModeAtomic},
+		{"BatchModeIndependent", BatchModeIndependent, request.BatchModeIndependent},
+
+		{"TaskOperationCreate", TaskOperationCreate, models.TaskOperationCreate},
+		{"TaskOperationUpdate", TaskOperationUpdate, models.TaskOperationUpdate},
+		{"TaskOperationStart", TaskOperationStart, models.TaskOperationStart},
+		{"TaskOperationFinish", TaskOperationFinish, models.TaskOperationFinish},
+		{"TaskOperationDelete", TaskOperationDelete, models.TaskOperationDelete},
+	}
+	for _, tt := range tests {
+		if tt.client != tt.server {
+			t.Errorf("%s is %q, the server has %q", tt.name, tt.client, tt.server)
+		}
+	}
+}
@@ -0,0 +1,156 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// WorklogsRequest selects the tasks of a user created in a period.
+type WorklogsRequest struct {
+	UserID string
+	Start  time.Time
+	End    time.Time
+}
+
+// SearchTasksRequest selects a page of a full-text search among the tasks of
+// the given users.
+type SearchTasksRequest struct {
+	Query   string
+	UserIDs []string
+	Page    int // Page number starting from 1, the first page if zero
+}
+
+// Worklogs returns the tasks of a user created in the requested period.
+func (c *Client) Worklogs(ctx context.Context, req WorklogsRequest) ([]Task, error) {
+	const op = "client.Worklogs"
+
+	query := url.Values{}
+	query.Set("start_date", req.Start.Format(time.RFC3339))
+	query.Set("end_date", req.End.Format(time.RFC3339))
+
+	var tasks []Task
+	if err := c.do(ctx, call{method: http.MethodGet, path: "/tasks/" + url.PathEscape(req.UserID) + "/worklogs", query: query}, &tasks); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return tasks, nil
+}
+
+// SearchTasks returns tasks matching the query, the most relevant first.
+func (c *Client) SearchTasks(ctx context.Context, req SearchTasksRequest) ([]TaskSearchResult, error) {
+	const op = "client.SearchTasks"
+
+	query := url.Values{"user_id": req.UserIDs}
+	query.Set("q", req.Query)
+	if req.Page > 0 {
+		query.Set("page", strconv.Itoa(req.Page))
+	}
+
+	var results []TaskSearchResult
+	if err := c.do(ctx, call{method: http.MethodGet, path: "/tasks/search", query: query}, &results); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return results, nil
+}
+
+// RunningTasks returns the task each of the users is working on. Users
+// without a running task are skipped.
+func (c *Client) RunningTasks(ctx context.Context, userIDs ...string) ([]Task, error) {
+	const op = "client.RunningTasks"
+
+	var tasks []Task
+	if err := c.do(ctx, call{method: http.MethodGet, path: "/tasks/running", query: url.Values{"user_id": userIDs}}, &tasks); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return tasks, nil
+}
+
+// GetTask returns the task with the given ID.
+func (c *Client) GetTask(ctx context.Context, id string) (*Task, error) {
+	const op = "client.GetTask"
+
+	var task Task
+	if err := c.do(ctx, call{method: http.MethodGet, path: "/tasks/" + url.PathEscape(id)}, &task); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return &task, nil
+}
+
+// StartTask starts the task. A positive version makes it fail with
+// ErrVersionMismatch if the task was changed since.
+func (c *Client) StartTask(ctx context.Context, id string, version int) (*Task, error) {
+	const op = "client.StartTask"
+
+	task, err := c.taskAction(ctx, id, "start", version)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return task, nil
+}
+
+// FinishTask finishes the task. A positive version makes it fail with
+// ErrVersionMismatch if the task was changed since.
+func (c *Client) FinishTask(ctx context.Context, id string, version int) (*Task, error) {
+	const op = "client.FinishTask"
+
+	task, err := c.taskAction(ctx, id, "finish", version)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return task, nil
+}
+
+func (c *Client) taskAction(ctx context.Context, id, action string, version int) (*Task, error) {
+	r := call{
+		method: http.MethodPost,
+		path:   "/tasks/" + url.PathEscape(id) + "/" + action,
+		header: versionHeader(version),
+	}
+
+	var task Task
+	if err := c.do(ctx, r, &task); err != nil {
+		return nil, err
+	}
+
+	return &task, nil
+}
+
+// DeleteTask deletes the task. A positive version makes it fail with
+// ErrVersionMismatch if the task was changed since.
+func (c *Client) DeleteTask(ctx context.Context, id string, version int) error {
+	const op = "client.DeleteTask"
+
+	r := call{method: http.MethodDelete, path: "/tasks/" + url.PathEscape(id), header: versionHeader(version)}
+	if err := c.do(ctx, r, nil); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// Batch applies task operations in order. Failed operations are reported in
+// the results; the error is returned only if the batch itself is rejected.
+func (c *Client) Batch(ctx context.Context, batch TaskBatch) (*TaskBatchResult, error) {
+	const op = "client.Batch"
+
+	r, err := jsonCall(http.MethodPost, "/tasks/batch", batch)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	var result TaskBatchResult
+	if err := c.do(ctx, r, &result); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return &result, nil
+}
@@ -0,0 +1,179 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// ListUsersRequest selects a page of users.
+type ListUsersRequest struct {
+	Page   int    // Page number starting from 1, the first page if zero
+	Filter string // Filter string, all users if empty
+}
+
+// GetImportJobRequest selects a page of rows of an import job.
+type GetImportJobRequest struct {
+	ID     string // Import job ID
+	Status string // Row status filter, all rows if empty
+	Page   int    // Page number starting from 1, the first page if zero
+}
+
+// CreateUser creates a user from passport data given as "<serie> <number>".
+// The rest of the user is filled in by the people info service.
+func (c *Client) CreateUser(ctx context.Context, req CreateUserRequest) (*User, error) {
+	const op = "client.CreateUser"
+
+	r, err := jsonCall(http.MethodPost, "/users", req)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	var user User
+	if err := c.do(ctx, r, &user); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return &user, nil
+}
+
+// ListUsers returns a page of users.
+func (c *Client) ListUsers(ctx context.Context, req ListUsersRequest) ([]User, error) {
+	const op = "client.ListUsers"
+
+	query := url.Values{}
+	if req.Page > 0 {
+		query.Set("page", strconv.Itoa(req.Page))
+	}
+	if req.Filter != "" {
+		query.Set("filter", req.Filter)
+	}
+
+	var users []User
+	if err := c.do(ctx, call{method: http.MethodGet, path: "/users", query: query}, &users); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return users, nil
+}
+
+// GetUser returns the user with the given ID.
+func (c *Client) GetUser(ctx context.Context, id string) (*User, error) {
+	const op = "client.GetUser"
+
+	var user User
+	if err := c.do(ctx, call{method: http.MethodGet, path: "/users/" + url.PathEscape(id)}, &user); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return &user, nil
+}
+
+// UpdateUser changes the fields set in update. A positive version makes the
+// update fail with ErrVersionMismatch if the user was changed since.
+func (c *Client) UpdateUser(ctx context.Context, id string, update UserUpdate, version int) (*User, error) {
+	const op = "client.UpdateUser"
+
+	// A merge patch is the only update format that can clear fields
+	patch := map[string]any{}
+	setPatch(patch, "name", update.Name)
+	setPatch(patch, "surname", update.Surname)
+	setPatch(patch, "patronymic", update.Patronymic)
+	setPatch(patch, "address", update.Address)
+	setPatch(patch, "passport_serie", update.PassportSerie)
+	setPatch(patch, "passport_number", update.PassportNumber)
+
+	body, err := json.Marshal(patch)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	r := call{
+		method:      http.MethodPatch,
+		path:        "/users/" + url.PathEscape(id),
+		header:      versionHeader(version),
+		contentType: "application/merge-patch+json",
+		body:        body,
+	}
+
+	var user User
+	if err := c.do(ctx, r, &user); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return &user, nil
+}
+
+func setPatch[T any](patch map[string]any, field string, change Optional[T]) {
+	switch {
+	case change.Null:
+		patch[field] = nil
+	case change.Set:
+		patch[field] = change.Value
+	}
+}
+
+// DeleteUser deletes the user with the given ID. A positive version makes the
+// deletion fail with ErrVersionMismatch if the user was changed since.
+func (c *Client) DeleteUser(ctx context.Context, id string, version int) error {
+	const op = "client.DeleteUser"
+
+	r := call{method: http.MethodDelete, path: "/users/" + url.PathEscape(id), header: versionHeader(version)}
+	if err := c.do(ctx, r, nil); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// ImportUsers starts a background job creating users from passports given
+// as "<serie> <number>". Its progress is reported by GetImportJob.
+func (c *Client) ImportUsers(ctx context.Context, passports []string) (*ImportJob, error) {
+	const op = "client.ImportUsers"
+
+	var body bytes.Buffer
+	enc := json.NewEncoder(&body)
+	for _, passport := range passports {
+		if err := enc.Encode(CreateUserRequest{PassportNumber: passport}); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	r := call{
+		method:      http.MethodPost,
+		path:        "/users/import",
+		contentType: "application/x-ndjson",
+		body:        body.Bytes(),
+	}
+
+	var job ImportJob
+	if err := c.do(ctx, r, &job); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return &job, nil
+}
+
+// GetImportJob returns the status of an import job with a page of its rows.
+func (c *Client) GetImportJob(ctx context.Context, req GetImportJobRequest) (*ImportJob, error) {
+	const op = "client.GetImportJob"
+
+	query := url.Values{}
+	if req.Status != "" {
+		query.Set("status", req.Status)
+	}
+	if req.Page > 0 {
+		query.Set("page", strconv.Itoa(req.Page))
+	}
+
+	var job ImportJob
+	if err := c.do(ctx, call{method: http.MethodGet, path: "/users/import/" + url.PathEscape(req.ID), query: query}, &job); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return &job, nil
+}